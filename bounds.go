@@ -8,10 +8,10 @@ import (
 
 // Bounds are the bounds of osm data as defined in the xml file.
 type Bounds struct {
-	MinLat float64 `xml:"minlat,attr"`
-	MaxLat float64 `xml:"maxlat,attr"`
-	MinLon float64 `xml:"minlon,attr"`
-	MaxLon float64 `xml:"maxlon,attr"`
+	MinLat float64 `xml:"minlat,attr" json:"minlat"`
+	MaxLat float64 `xml:"maxlat,attr" json:"maxlat"`
+	MinLon float64 `xml:"minlon,attr" json:"minlon"`
+	MaxLon float64 `xml:"maxlon,attr" json:"maxlon"`
 }
 
 // NewBoundsFromTile creates a bound given an online map tile index.
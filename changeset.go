@@ -93,12 +93,30 @@ func (c *Changeset) Bot() bool {
 }
 
 // Marshal encodes the changeset data using protocol buffers.
-// Does not encode the changeset discussion.
 //
 // Deprecated: encoding could be improved, should be versioned separately.
 func (c *Changeset) Marshal() ([]byte, error) {
 	ss := &stringSet{}
+	encoded := marshalChangeset(c, ss)
+	encoded.Strings = ss.Strings()
 
+	return proto.Marshal(encoded)
+}
+
+// UnmarshalChangeset will unmarshal the data into an OSM object.
+//
+// Deprecated: encoding could be improved, should be versioned separately.
+func UnmarshalChangeset(data []byte) (*Changeset, error) {
+	encoded := &osmpb.Changeset{}
+	err := proto.Unmarshal(data, encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return unmarshalChangeset(encoded, encoded.GetStrings())
+}
+
+func marshalChangeset(c *Changeset, ss *stringSet) *osmpb.Changeset {
 	var userSid *uint32
 	if c.User != "" {
 		v := ss.Add(c.User)
@@ -127,6 +145,14 @@ func (c *Changeset) Marshal() ([]byte, error) {
 		encoded.UserId = proto.Int32(int32(c.UserID))
 	}
 
+	if c.ChangesCount != 0 {
+		encoded.ChangesCount = proto.Int32(int32(c.ChangesCount))
+	}
+
+	if c.CommentsCount != 0 {
+		encoded.CommentsCount = proto.Int32(int32(c.CommentsCount))
+	}
+
 	if c.MinLat != 0 || c.MaxLat != 0 || c.MinLon != 0 || c.MaxLon != 0 {
 		encoded.Bounds = &osmpb.Bounds{
 			MinLat: geoToInt64(c.MinLat),
@@ -141,33 +167,36 @@ func (c *Changeset) Marshal() ([]byte, error) {
 		encoded.Change = marshalChange(c.Change, ss, false)
 	}
 
-	encoded.Strings = ss.Strings()
-	return proto.Marshal(encoded)
-}
-
-// UnmarshalChangeset will unmarshal the data into an OSM object.
-//
-// Deprecated: encoding could be improved, should be versioned separately.
-func UnmarshalChangeset(data []byte) (*Changeset, error) {
-	encoded := &osmpb.Changeset{}
-	err := proto.Unmarshal(data, encoded)
-	if err != nil {
-		return nil, err
+	if c.Discussion != nil {
+		encoded.Discussion = &osmpb.ChangesetDiscussion{}
+		for _, comment := range c.Discussion.Comments {
+			encoded.Discussion.Comments = append(encoded.Discussion.Comments, &osmpb.ChangesetComment{
+				UserId:    int32(comment.UserID),
+				UserSid:   ss.Add(comment.User),
+				Timestamp: timeToUnix(comment.Timestamp),
+				Text:      comment.Text,
+			})
+		}
 	}
 
-	ss := encoded.GetStrings()
+	return encoded
+}
+
+func unmarshalChangeset(encoded *osmpb.Changeset, ss []string) (*Changeset, error) {
 	tags, err := tagsFromStrings(ss, encoded.GetKeys(), encoded.GetVals())
 	if err != nil {
 		return nil, err
 	}
 
 	cs := &Changeset{
-		ID:        ChangesetID(encoded.GetId()),
-		UserID:    UserID(encoded.GetUserId()),
-		CreatedAt: unixToTime(encoded.GetCreatedAt()),
-		ClosedAt:  unixToTime(encoded.GetClosedAt()),
-		Open:      encoded.GetOpen(),
-		Tags:      tags,
+		ID:            ChangesetID(encoded.GetId()),
+		UserID:        UserID(encoded.GetUserId()),
+		CreatedAt:     unixToTime(encoded.GetCreatedAt()),
+		ClosedAt:      unixToTime(encoded.GetClosedAt()),
+		Open:          encoded.GetOpen(),
+		ChangesCount:  int(encoded.GetChangesCount()),
+		CommentsCount: int(encoded.GetCommentsCount()),
+		Tags:          tags,
 	}
 
 	if encoded.UserSid != nil {
@@ -188,6 +217,18 @@ func UnmarshalChangeset(data []byte) (*Changeset, error) {
 		}
 	}
 
+	if encoded.Discussion != nil {
+		cs.Discussion = &ChangesetDiscussion{}
+		for _, comment := range encoded.Discussion.GetComments() {
+			cs.Discussion.Comments = append(cs.Discussion.Comments, &ChangesetComment{
+				User:      ss[comment.GetUserSid()],
+				UserID:    UserID(comment.GetUserId()),
+				Timestamp: unixToTime(comment.GetTimestamp()),
+				Text:      comment.GetText(),
+			})
+		}
+	}
+
 	return cs, nil
 }
 
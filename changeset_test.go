@@ -131,6 +131,49 @@ func TestChangeset(t *testing.T) {
 	}
 }
 
+func TestChangeset_Marshal_discussion(t *testing.T) {
+	cs1 := &Changeset{
+		ID:            123,
+		User:          "user",
+		UserID:        1,
+		CreatedAt:     time.Date(2016, 6, 26, 21, 26, 41, 0, time.UTC),
+		ChangesCount:  10,
+		CommentsCount: 2,
+		Discussion: &ChangesetDiscussion{
+			Comments: []*ChangesetComment{
+				{
+					User:      "commenter",
+					UserID:    2,
+					Timestamp: time.Date(2016, 6, 27, 8, 0, 0, 0, time.UTC),
+					Text:      "first",
+				},
+				{
+					User:      "user",
+					UserID:    1,
+					Timestamp: time.Date(2016, 6, 27, 9, 0, 0, 0, time.UTC),
+					Text:      "second",
+				},
+			},
+		},
+	}
+
+	data, err := cs1.Marshal()
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+
+	cs2, err := UnmarshalChangeset(data)
+	if err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	if !reflect.DeepEqual(cs1, cs2) {
+		t.Errorf("changesets are not equal")
+		t.Logf("%+v", cs1.Discussion)
+		t.Logf("%+v", cs2.Discussion)
+	}
+}
+
 func TestChangeset_open(t *testing.T) {
 	data := []byte(`
 <changeset id="40309372" user="Bahntech" uid="3619264" created_at="2016-06-26T21:26:41Z" open="true" min_lat="51.484563" min_lon="12.0995042" max_lat="51.484563" max_lon="12.0995042" comments_count="0">
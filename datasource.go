@@ -35,6 +35,9 @@ func (ds *HistoryDatasource) add(o *OSM, visible ...bool) {
 		}
 
 		for _, n := range o.Nodes {
+			if len(visible) == 1 {
+				n.Visible = visible[0]
+			}
 			ds.Nodes[n.ID] = append(ds.Nodes[n.ID], n)
 		}
 	}
@@ -45,6 +48,9 @@ func (ds *HistoryDatasource) add(o *OSM, visible ...bool) {
 		}
 
 		for _, w := range o.Ways {
+			if len(visible) == 1 {
+				w.Visible = visible[0]
+			}
 			ds.Ways[w.ID] = append(ds.Ways[w.ID], w)
 		}
 	}
@@ -63,6 +69,34 @@ func (ds *HistoryDatasource) add(o *OSM, visible ...bool) {
 	}
 }
 
+// Load adds all the nodes, ways and relations from the scanner to the
+// datasource, other object types are ignored. Versions are appended in
+// the order they are scanned, so the data should be sorted by id and version,
+// as in full history planet files. The scanner is not closed.
+func (ds *HistoryDatasource) Load(s Scanner) error {
+	for s.Scan() {
+		switch o := s.Object().(type) {
+		case *Node:
+			if ds.Nodes == nil {
+				ds.Nodes = make(map[NodeID]Nodes)
+			}
+			ds.Nodes[o.ID] = append(ds.Nodes[o.ID], o)
+		case *Way:
+			if ds.Ways == nil {
+				ds.Ways = make(map[WayID]Ways)
+			}
+			ds.Ways[o.ID] = append(ds.Ways[o.ID], o)
+		case *Relation:
+			if ds.Relations == nil {
+				ds.Relations = make(map[RelationID]Relations)
+			}
+			ds.Relations[o.ID] = append(ds.Relations[o.ID], o)
+		}
+	}
+
+	return s.Err()
+}
+
 // NodeHistory returns the history for the given id from the map.
 func (ds *HistoryDatasource) NodeHistory(ctx context.Context, id NodeID) (Nodes, error) {
 	if ds.Nodes == nil {
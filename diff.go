@@ -3,12 +3,31 @@ package osm
 import "encoding/xml"
 
 // Diff represents a difference of osm data with old and new data.
+// It corresponds to Overpass augmented diffs.
+// See: https://wiki.openstreetmap.org/wiki/Overpass_API/Augmented_Diffs
 type Diff struct {
-	XMLName    xml.Name   `xml:"osm"`
+	XMLName   xml.Name `xml:"osm"`
+	Version   float64  `xml:"version,attr,omitempty"`
+	Generator string   `xml:"generator,attr,omitempty"`
+
+	// Note, Meta and Remark are included by overpass. Note is usually
+	// the license, the remark contains any runtime errors or warnings.
+	Note   string    `xml:"note,omitempty"`
+	Meta   *DiffMeta `xml:"meta,omitempty"`
+	Remark string    `xml:"remark,omitempty"`
+
 	Actions    Actions    `xml:"action"`
 	Changesets Changesets `xml:"changeset"`
 }
 
+// DiffMeta is the overpass meta information of a diff.
+type DiffMeta struct {
+	// OSMBase is the timestamp of the osm data used to create the diff,
+	// e.g. 2017-01-10T22:29:02Z.
+	OSMBase string `xml:"osm_base,attr,omitempty"`
+	Areas   string `xml:"areas,attr,omitempty"`
+}
+
 // Actions is a set of diff actions.
 type Actions []Action
 
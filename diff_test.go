@@ -84,6 +84,14 @@ func TestDiff(t *testing.T) {
 		t.Fatalf("incorrect number of actions, got %d", l)
 	}
 
+	if diff.Version != 0.6 || diff.Generator != "Overpass API" {
+		t.Errorf("incorrect attributes: %v %v", diff.Version, diff.Generator)
+	}
+
+	if diff.Meta == nil || diff.Meta.OSMBase != "2017-01-10T22:29:02Z" {
+		t.Errorf("incorrect meta: %v", diff.Meta)
+	}
+
 	// create way
 	if at := diff.Actions[1075].Type; at != ActionCreate {
 		t.Errorf("not a create action, %v", at)
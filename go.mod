@@ -1,11 +1,14 @@
 module github.com/ich5003/small-osm
 
-go 1.13
+go 1.19
 
 require (
 	github.com/datadog/czlib v0.0.0-20160811164712-4bc9a24e37f2
 	github.com/gogo/protobuf v1.3.1
+	github.com/klauspost/compress v1.17.4
 	github.com/paulmach/orb v0.1.6
 	github.com/paulmach/protoscan v0.1.0
+	github.com/pierrec/lz4/v4 v4.1.18
+	github.com/ulikunitz/xz v0.5.11
 	golang.org/x/time v0.0.0-20190921001708-c4c64cad1fd0
 )
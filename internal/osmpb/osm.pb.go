@@ -4,6 +4,7 @@
 package osmpb
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	github_com_gogo_protobuf_proto "github.com/gogo/protobuf/proto"
@@ -64,21 +65,24 @@ func (x *Relation_MemberType) UnmarshalJSON(data []byte) error {
 }
 
 func (Relation_MemberType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{10, 0}
+	return fileDescriptor_537306c3a4d945df, []int{15, 0}
 }
 
 type Changeset struct {
 	Id *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 	// Parallel arrays.
-	Keys      []uint32 `protobuf:"varint,2,rep,packed,name=keys" json:"keys,omitempty"`
-	Vals      []uint32 `protobuf:"varint,3,rep,packed,name=vals" json:"vals,omitempty"`
-	UserId    *int32   `protobuf:"varint,5,opt,name=user_id,json=userId" json:"user_id,omitempty"`
-	UserSid   *uint32  `protobuf:"varint,6,opt,name=user_sid,json=userSid" json:"user_sid,omitempty"`
-	CreatedAt *int64   `protobuf:"varint,7,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
-	ClosedAt  *int64   `protobuf:"varint,8,opt,name=closed_at,json=closedAt" json:"closed_at,omitempty"`
-	Open      *bool    `protobuf:"varint,9,opt,name=open" json:"open,omitempty"`
-	Bounds    *Bounds  `protobuf:"bytes,10,opt,name=bounds" json:"bounds,omitempty"`
-	Change    *Change  `protobuf:"bytes,11,opt,name=change" json:"change,omitempty"`
+	Keys          []uint32             `protobuf:"varint,2,rep,packed,name=keys" json:"keys,omitempty"`
+	Vals          []uint32             `protobuf:"varint,3,rep,packed,name=vals" json:"vals,omitempty"`
+	UserId        *int32               `protobuf:"varint,5,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	UserSid       *uint32              `protobuf:"varint,6,opt,name=user_sid,json=userSid" json:"user_sid,omitempty"`
+	CreatedAt     *int64               `protobuf:"varint,7,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	ClosedAt      *int64               `protobuf:"varint,8,opt,name=closed_at,json=closedAt" json:"closed_at,omitempty"`
+	Open          *bool                `protobuf:"varint,9,opt,name=open" json:"open,omitempty"`
+	Bounds        *Bounds              `protobuf:"bytes,10,opt,name=bounds" json:"bounds,omitempty"`
+	Change        *Change              `protobuf:"bytes,11,opt,name=change" json:"change,omitempty"`
+	ChangesCount  *int32               `protobuf:"varint,12,opt,name=changes_count,json=changesCount" json:"changes_count,omitempty"`
+	CommentsCount *int32               `protobuf:"varint,13,opt,name=comments_count,json=commentsCount" json:"comments_count,omitempty"`
+	Discussion    *ChangesetDiscussion `protobuf:"bytes,14,opt,name=discussion" json:"discussion,omitempty"`
 	// contains the tag strings for everything
 	// in this entire changeset.
 	Strings []string `protobuf:"bytes,20,rep,name=strings" json:"strings,omitempty"`
@@ -187,6 +191,27 @@ func (m *Changeset) GetChange() *Change {
 	return nil
 }
 
+func (m *Changeset) GetChangesCount() int32 {
+	if m != nil && m.ChangesCount != nil {
+		return *m.ChangesCount
+	}
+	return 0
+}
+
+func (m *Changeset) GetCommentsCount() int32 {
+	if m != nil && m.CommentsCount != nil {
+		return *m.CommentsCount
+	}
+	return 0
+}
+
+func (m *Changeset) GetDiscussion() *ChangesetDiscussion {
+	if m != nil {
+		return m.Discussion
+	}
+	return nil
+}
+
 func (m *Changeset) GetStrings() []string {
 	if m != nil {
 		return m.Strings
@@ -194,6 +219,118 @@ func (m *Changeset) GetStrings() []string {
 	return nil
 }
 
+type ChangesetDiscussion struct {
+	Comments []*ChangesetComment `protobuf:"bytes,1,rep,name=comments" json:"comments,omitempty"`
+}
+
+func (m *ChangesetDiscussion) Reset()         { *m = ChangesetDiscussion{} }
+func (m *ChangesetDiscussion) String() string { return proto.CompactTextString(m) }
+func (*ChangesetDiscussion) ProtoMessage()    {}
+func (*ChangesetDiscussion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{1}
+}
+func (m *ChangesetDiscussion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChangesetDiscussion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChangesetDiscussion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChangesetDiscussion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangesetDiscussion.Merge(m, src)
+}
+func (m *ChangesetDiscussion) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChangesetDiscussion) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangesetDiscussion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangesetDiscussion proto.InternalMessageInfo
+
+func (m *ChangesetDiscussion) GetComments() []*ChangesetComment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
+}
+
+type ChangesetComment struct {
+	UserId    int32  `protobuf:"varint,1,opt,name=user_id,json=userId" json:"user_id"`
+	UserSid   uint32 `protobuf:"varint,2,opt,name=user_sid,json=userSid" json:"user_sid"`
+	Timestamp int64  `protobuf:"varint,3,opt,name=timestamp" json:"timestamp"`
+	Text      string `protobuf:"bytes,4,opt,name=text" json:"text"`
+}
+
+func (m *ChangesetComment) Reset()         { *m = ChangesetComment{} }
+func (m *ChangesetComment) String() string { return proto.CompactTextString(m) }
+func (*ChangesetComment) ProtoMessage()    {}
+func (*ChangesetComment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{2}
+}
+func (m *ChangesetComment) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChangesetComment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChangesetComment.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChangesetComment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChangesetComment.Merge(m, src)
+}
+func (m *ChangesetComment) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChangesetComment) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChangesetComment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ChangesetComment proto.InternalMessageInfo
+
+func (m *ChangesetComment) GetUserId() int32 {
+	if m != nil {
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *ChangesetComment) GetUserSid() uint32 {
+	if m != nil {
+		return m.UserSid
+	}
+	return 0
+}
+
+func (m *ChangesetComment) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *ChangesetComment) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
 type Bounds struct {
 	MinLon int64 `protobuf:"zigzag64,1,req,name=min_lon,json=minLon" json:"min_lon"`
 	MaxLon int64 `protobuf:"zigzag64,2,req,name=max_lon,json=maxLon" json:"max_lon"`
@@ -205,7 +342,7 @@ func (m *Bounds) Reset()         { *m = Bounds{} }
 func (m *Bounds) String() string { return proto.CompactTextString(m) }
 func (*Bounds) ProtoMessage()    {}
 func (*Bounds) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{1}
+	return fileDescriptor_537306c3a4d945df, []int{3}
 }
 func (m *Bounds) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -277,7 +414,7 @@ func (m *Change) Reset()         { *m = Change{} }
 func (m *Change) String() string { return proto.CompactTextString(m) }
 func (*Change) ProtoMessage()    {}
 func (*Change) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{2}
+	return fileDescriptor_537306c3a4d945df, []int{4}
 }
 func (m *Change) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -350,7 +487,7 @@ func (m *Tags) Reset()         { *m = Tags{} }
 func (m *Tags) String() string { return proto.CompactTextString(m) }
 func (*Tags) ProtoMessage()    {}
 func (*Tags) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{3}
+	return fileDescriptor_537306c3a4d945df, []int{5}
 }
 func (m *Tags) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -389,10 +526,19 @@ func (m *Tags) GetKeysVals() []string {
 type OSM struct {
 	Bounds *Bounds `protobuf:"bytes,1,opt,name=bounds" json:"bounds,omitempty"`
 	// an encoded should have either nodes or a dense_nodes, but not both.
-	Nodes      []*Node     `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
-	DenseNodes *DenseNodes `protobuf:"bytes,3,opt,name=dense_nodes,json=denseNodes" json:"dense_nodes,omitempty"`
-	Ways       []*Way      `protobuf:"bytes,4,rep,name=ways" json:"ways,omitempty"`
-	Relations  []*Relation `protobuf:"bytes,5,rep,name=relations" json:"relations,omitempty"`
+	Nodes      []*Node      `protobuf:"bytes,2,rep,name=nodes" json:"nodes,omitempty"`
+	DenseNodes *DenseNodes  `protobuf:"bytes,3,opt,name=dense_nodes,json=denseNodes" json:"dense_nodes,omitempty"`
+	Ways       []*Way       `protobuf:"bytes,4,rep,name=ways" json:"ways,omitempty"`
+	Relations  []*Relation  `protobuf:"bytes,5,rep,name=relations" json:"relations,omitempty"`
+	Changesets []*Changeset `protobuf:"bytes,6,rep,name=changesets" json:"changesets,omitempty"`
+	Notes      []*Note      `protobuf:"bytes,7,rep,name=notes" json:"notes,omitempty"`
+	Users      []*User      `protobuf:"bytes,8,rep,name=users" json:"users,omitempty"`
+	// attributes of the osm root element.
+	Version     *float64 `protobuf:"fixed64,9,opt,name=version" json:"version,omitempty"`
+	Generator   *string  `protobuf:"bytes,10,opt,name=generator" json:"generator,omitempty"`
+	Copyright   *string  `protobuf:"bytes,11,opt,name=copyright" json:"copyright,omitempty"`
+	Attribution *string  `protobuf:"bytes,12,opt,name=attribution" json:"attribution,omitempty"`
+	License     *string  `protobuf:"bytes,13,opt,name=license" json:"license,omitempty"`
 	// contains the tag strings if this is the root of the data.
 	Strings []string `protobuf:"bytes,15,rep,name=strings" json:"strings,omitempty"`
 }
@@ -401,7 +547,7 @@ func (m *OSM) Reset()         { *m = OSM{} }
 func (m *OSM) String() string { return proto.CompactTextString(m) }
 func (*OSM) ProtoMessage()    {}
 func (*OSM) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{4}
+	return fileDescriptor_537306c3a4d945df, []int{6}
 }
 func (m *OSM) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -465,35 +611,95 @@ func (m *OSM) GetRelations() []*Relation {
 	return nil
 }
 
-func (m *OSM) GetStrings() []string {
+func (m *OSM) GetChangesets() []*Changeset {
 	if m != nil {
-		return m.Strings
+		return m.Changesets
 	}
 	return nil
 }
 
-type Node struct {
-	Id int64 `protobuf:"varint,1,req,name=id" json:"id"`
-	// Parallel arrays.
-	Keys []uint32 `protobuf:"varint,2,rep,packed,name=keys" json:"keys,omitempty"`
-	Vals []uint32 `protobuf:"varint,3,rep,packed,name=vals" json:"vals,omitempty"`
-	Info *Info    `protobuf:"bytes,4,opt,name=info" json:"info,omitempty"`
-	Lat  int64    `protobuf:"zigzag64,8,req,name=lat" json:"lat"`
-	Lon  int64    `protobuf:"zigzag64,9,req,name=lon" json:"lon"`
+func (m *OSM) GetNotes() []*Note {
+	if m != nil {
+		return m.Notes
+	}
+	return nil
 }
 
-func (m *Node) Reset()         { *m = Node{} }
-func (m *Node) String() string { return proto.CompactTextString(m) }
-func (*Node) ProtoMessage()    {}
-func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{5}
+func (m *OSM) GetUsers() []*User {
+	if m != nil {
+		return m.Users
+	}
+	return nil
 }
-func (m *Node) XXX_Unmarshal(b []byte) error {
+
+func (m *OSM) GetVersion() float64 {
+	if m != nil && m.Version != nil {
+		return *m.Version
+	}
+	return 0
+}
+
+func (m *OSM) GetGenerator() string {
+	if m != nil && m.Generator != nil {
+		return *m.Generator
+	}
+	return ""
+}
+
+func (m *OSM) GetCopyright() string {
+	if m != nil && m.Copyright != nil {
+		return *m.Copyright
+	}
+	return ""
+}
+
+func (m *OSM) GetAttribution() string {
+	if m != nil && m.Attribution != nil {
+		return *m.Attribution
+	}
+	return ""
+}
+
+func (m *OSM) GetLicense() string {
+	if m != nil && m.License != nil {
+		return *m.License
+	}
+	return ""
+}
+
+func (m *OSM) GetStrings() []string {
+	if m != nil {
+		return m.Strings
+	}
+	return nil
+}
+
+type Note struct {
+	Id          int64          `protobuf:"varint,1,req,name=id" json:"id"`
+	Lat         int64          `protobuf:"zigzag64,2,req,name=lat" json:"lat"`
+	Lon         int64          `protobuf:"zigzag64,3,req,name=lon" json:"lon"`
+	Url         string         `protobuf:"bytes,4,opt,name=url" json:"url"`
+	CommentUrl  string         `protobuf:"bytes,5,opt,name=comment_url,json=commentUrl" json:"comment_url"`
+	CloseUrl    string         `protobuf:"bytes,6,opt,name=close_url,json=closeUrl" json:"close_url"`
+	ReopenUrl   string         `protobuf:"bytes,7,opt,name=reopen_url,json=reopenUrl" json:"reopen_url"`
+	DateCreated int64          `protobuf:"varint,8,opt,name=date_created,json=dateCreated" json:"date_created"`
+	DateClosed  int64          `protobuf:"varint,9,opt,name=date_closed,json=dateClosed" json:"date_closed"`
+	StatusSid   uint32         `protobuf:"varint,10,opt,name=status_sid,json=statusSid" json:"status_sid"`
+	Comments    []*NoteComment `protobuf:"bytes,11,rep,name=comments" json:"comments,omitempty"`
+}
+
+func (m *Note) Reset()         { *m = Note{} }
+func (m *Note) String() string { return proto.CompactTextString(m) }
+func (*Note) ProtoMessage()    {}
+func (*Note) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{7}
+}
+func (m *Note) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Note) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Node.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Note.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -503,91 +709,117 @@ func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Node) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Node.Merge(m, src)
+func (m *Note) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Note.Merge(m, src)
 }
-func (m *Node) XXX_Size() int {
+func (m *Note) XXX_Size() int {
 	return m.Size()
 }
-func (m *Node) XXX_DiscardUnknown() {
-	xxx_messageInfo_Node.DiscardUnknown(m)
+func (m *Note) XXX_DiscardUnknown() {
+	xxx_messageInfo_Note.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Node proto.InternalMessageInfo
+var xxx_messageInfo_Note proto.InternalMessageInfo
 
-func (m *Node) GetId() int64 {
+func (m *Note) GetId() int64 {
 	if m != nil {
 		return m.Id
 	}
 	return 0
 }
 
-func (m *Node) GetKeys() []uint32 {
+func (m *Note) GetLat() int64 {
 	if m != nil {
-		return m.Keys
+		return m.Lat
 	}
-	return nil
+	return 0
 }
 
-func (m *Node) GetVals() []uint32 {
+func (m *Note) GetLon() int64 {
 	if m != nil {
-		return m.Vals
+		return m.Lon
 	}
-	return nil
+	return 0
 }
 
-func (m *Node) GetInfo() *Info {
+func (m *Note) GetUrl() string {
 	if m != nil {
-		return m.Info
+		return m.Url
 	}
-	return nil
+	return ""
 }
 
-func (m *Node) GetLat() int64 {
+func (m *Note) GetCommentUrl() string {
 	if m != nil {
-		return m.Lat
+		return m.CommentUrl
+	}
+	return ""
+}
+
+func (m *Note) GetCloseUrl() string {
+	if m != nil {
+		return m.CloseUrl
+	}
+	return ""
+}
+
+func (m *Note) GetReopenUrl() string {
+	if m != nil {
+		return m.ReopenUrl
+	}
+	return ""
+}
+
+func (m *Note) GetDateCreated() int64 {
+	if m != nil {
+		return m.DateCreated
 	}
 	return 0
 }
 
-func (m *Node) GetLon() int64 {
+func (m *Note) GetDateClosed() int64 {
 	if m != nil {
-		return m.Lon
+		return m.DateClosed
 	}
 	return 0
 }
 
-type Info struct {
-	Version   int32 `protobuf:"varint,1,opt,name=version" json:"version"`
-	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp" json:"timestamp"`
-	// these can be omitted if the object represents one changeset
-	// since they will be all the same. However tests on 200k changesets
-	// show this saves about 17 bytes per changeset on average after gzip.
-	ChangesetId int64  `protobuf:"varint,3,opt,name=changeset_id,json=changesetId" json:"changeset_id"`
-	UserId      int32  `protobuf:"varint,4,opt,name=user_id,json=userId" json:"user_id"`
-	UserSid     uint32 `protobuf:"varint,5,opt,name=user_sid,json=userSid" json:"user_sid"`
-	// The visible flag is used to store history information. It indicates that
-	// the current object version has been created by a delete operation on the
-	// OSM API. This info may be omitted if it can be inferred from its group
-	// ie. create, modify, delete.
-	Visible *bool `protobuf:"varint,6,opt,name=visible" json:"visible,omitempty"`
-	// the time this element was committed into the db. Could be much later than
-	// timestamp for large uploads.
-	Committed *int64 `protobuf:"varint,7,opt,name=committed" json:"committed,omitempty"`
+func (m *Note) GetStatusSid() uint32 {
+	if m != nil {
+		return m.StatusSid
+	}
+	return 0
 }
 
-func (m *Info) Reset()         { *m = Info{} }
-func (m *Info) String() string { return proto.CompactTextString(m) }
-func (*Info) ProtoMessage()    {}
-func (*Info) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{6}
+func (m *Note) GetComments() []*NoteComment {
+	if m != nil {
+		return m.Comments
+	}
+	return nil
 }
-func (m *Info) XXX_Unmarshal(b []byte) error {
+
+type NoteComment struct {
+	Date      int64  `protobuf:"varint,1,opt,name=date" json:"date"`
+	UserId    int32  `protobuf:"varint,2,opt,name=user_id,json=userId" json:"user_id"`
+	UserSid   uint32 `protobuf:"varint,3,opt,name=user_sid,json=userSid" json:"user_sid"`
+	UserUrl   string `protobuf:"bytes,4,opt,name=user_url,json=userUrl" json:"user_url"`
+	ActionSid uint32 `protobuf:"varint,5,opt,name=action_sid,json=actionSid" json:"action_sid"`
+	Text      string `protobuf:"bytes,6,opt,name=text" json:"text"`
+	Html      string `protobuf:"bytes,7,opt,name=html" json:"html"`
+}
+
+func (m *NoteComment) Reset()         { *m = NoteComment{} }
+func (m *NoteComment) String() string { return proto.CompactTextString(m) }
+func (*NoteComment) ProtoMessage()    {}
+func (*NoteComment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{8}
+}
+func (m *NoteComment) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Info) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NoteComment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Info.Marshal(b, m, deterministic)
+		return xxx_messageInfo_NoteComment.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -597,285 +829,253 @@ func (m *Info) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Info) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Info.Merge(m, src)
+func (m *NoteComment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NoteComment.Merge(m, src)
 }
-func (m *Info) XXX_Size() int {
+func (m *NoteComment) XXX_Size() int {
 	return m.Size()
 }
-func (m *Info) XXX_DiscardUnknown() {
-	xxx_messageInfo_Info.DiscardUnknown(m)
+func (m *NoteComment) XXX_DiscardUnknown() {
+	xxx_messageInfo_NoteComment.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Info proto.InternalMessageInfo
+var xxx_messageInfo_NoteComment proto.InternalMessageInfo
 
-func (m *Info) GetVersion() int32 {
+func (m *NoteComment) GetDate() int64 {
 	if m != nil {
-		return m.Version
+		return m.Date
 	}
 	return 0
 }
 
-func (m *Info) GetTimestamp() int64 {
+func (m *NoteComment) GetUserId() int32 {
 	if m != nil {
-		return m.Timestamp
+		return m.UserId
 	}
 	return 0
 }
 
-func (m *Info) GetChangesetId() int64 {
+func (m *NoteComment) GetUserSid() uint32 {
 	if m != nil {
-		return m.ChangesetId
+		return m.UserSid
 	}
 	return 0
 }
 
-func (m *Info) GetUserId() int32 {
+func (m *NoteComment) GetUserUrl() string {
 	if m != nil {
-		return m.UserId
+		return m.UserUrl
 	}
-	return 0
+	return ""
 }
 
-func (m *Info) GetUserSid() uint32 {
+func (m *NoteComment) GetActionSid() uint32 {
 	if m != nil {
-		return m.UserSid
+		return m.ActionSid
 	}
 	return 0
 }
 
-func (m *Info) GetVisible() bool {
-	if m != nil && m.Visible != nil {
-		return *m.Visible
+func (m *NoteComment) GetText() string {
+	if m != nil {
+		return m.Text
 	}
-	return false
+	return ""
 }
 
-func (m *Info) GetCommitted() int64 {
-	if m != nil && m.Committed != nil {
-		return *m.Committed
+func (m *NoteComment) GetHtml() string {
+	if m != nil {
+		return m.Html
+	}
+	return ""
+}
+
+type User struct {
+	Id                     int64    `protobuf:"varint,1,req,name=id" json:"id"`
+	Name                   string   `protobuf:"bytes,2,opt,name=name" json:"name"`
+	Description            string   `protobuf:"bytes,3,opt,name=description" json:"description"`
+	ImgHref                string   `protobuf:"bytes,4,opt,name=img_href,json=imgHref" json:"img_href"`
+	CreatedAt              int64    `protobuf:"varint,5,opt,name=created_at,json=createdAt" json:"created_at"`
+	ChangesetsCount        int32    `protobuf:"varint,6,opt,name=changesets_count,json=changesetsCount" json:"changesets_count"`
+	TracesCount            int32    `protobuf:"varint,7,opt,name=traces_count,json=tracesCount" json:"traces_count"`
+	HomeLat                int64    `protobuf:"zigzag64,8,opt,name=home_lat,json=homeLat" json:"home_lat"`
+	HomeLon                int64    `protobuf:"zigzag64,9,opt,name=home_lon,json=homeLon" json:"home_lon"`
+	HomeZoom               int32    `protobuf:"varint,10,opt,name=home_zoom,json=homeZoom" json:"home_zoom"`
+	Languages              []uint32 `protobuf:"varint,11,rep,packed,name=languages" json:"languages,omitempty"`
+	BlocksReceivedCount    int32    `protobuf:"varint,12,opt,name=blocks_received_count,json=blocksReceivedCount" json:"blocks_received_count"`
+	BlocksReceivedActive   int32    `protobuf:"varint,13,opt,name=blocks_received_active,json=blocksReceivedActive" json:"blocks_received_active"`
+	MessagesReceivedCount  int32    `protobuf:"varint,14,opt,name=messages_received_count,json=messagesReceivedCount" json:"messages_received_count"`
+	MessagesReceivedUnread int32    `protobuf:"varint,15,opt,name=messages_received_unread,json=messagesReceivedUnread" json:"messages_received_unread"`
+	MessagesSentCount      int32    `protobuf:"varint,16,opt,name=messages_sent_count,json=messagesSentCount" json:"messages_sent_count"`
+}
+
+func (m *User) Reset()         { *m = User{} }
+func (m *User) String() string { return proto.CompactTextString(m) }
+func (*User) ProtoMessage()    {}
+func (*User) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{9}
+}
+func (m *User) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *User) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_User.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return 0
 }
-
-type DenseNodes struct {
-	Ids       []int64    `protobuf:"zigzag64,1,rep,packed,name=ids" json:"ids,omitempty"`
-	DenseInfo *DenseInfo `protobuf:"bytes,5,opt,name=dense_info,json=denseInfo" json:"dense_info,omitempty"`
-	Lats      []int64    `protobuf:"zigzag64,8,rep,packed,name=lats" json:"lats,omitempty"`
-	Lons      []int64    `protobuf:"zigzag64,9,rep,packed,name=lons" json:"lons,omitempty"`
-	// Special packing of keys and vals into one array. We use a single stringid
-	// of 0 to delimit when the tags of a node ends and the tags of the next node
-	// begin. The storage pattern is: ((<keyid> <valid>)* '0' )* As an exception,
-	// if no node in the current block has any key/value pairs, this array does
-	// not contain any delimiters, but is simply empty.
-	KeysVals []uint32 `protobuf:"varint,10,rep,packed,name=keys_vals,json=keysVals" json:"keys_vals,omitempty"`
-	Strings  []string `protobuf:"bytes,15,rep,name=strings" json:"strings,omitempty"`
-}
-
-func (m *DenseNodes) Reset()         { *m = DenseNodes{} }
-func (m *DenseNodes) String() string { return proto.CompactTextString(m) }
-func (*DenseNodes) ProtoMessage()    {}
-func (*DenseNodes) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{7}
-}
-func (m *DenseNodes) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *DenseNodes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_DenseNodes.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (m *DenseNodes) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DenseNodes.Merge(m, src)
+func (m *User) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_User.Merge(m, src)
 }
-func (m *DenseNodes) XXX_Size() int {
+func (m *User) XXX_Size() int {
 	return m.Size()
 }
-func (m *DenseNodes) XXX_DiscardUnknown() {
-	xxx_messageInfo_DenseNodes.DiscardUnknown(m)
+func (m *User) XXX_DiscardUnknown() {
+	xxx_messageInfo_User.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DenseNodes proto.InternalMessageInfo
+var xxx_messageInfo_User proto.InternalMessageInfo
 
-func (m *DenseNodes) GetIds() []int64 {
+func (m *User) GetId() int64 {
 	if m != nil {
-		return m.Ids
+		return m.Id
 	}
-	return nil
+	return 0
 }
 
-func (m *DenseNodes) GetDenseInfo() *DenseInfo {
+func (m *User) GetName() string {
 	if m != nil {
-		return m.DenseInfo
+		return m.Name
 	}
-	return nil
+	return ""
 }
 
-func (m *DenseNodes) GetLats() []int64 {
+func (m *User) GetDescription() string {
 	if m != nil {
-		return m.Lats
+		return m.Description
 	}
-	return nil
+	return ""
 }
 
-func (m *DenseNodes) GetLons() []int64 {
+func (m *User) GetImgHref() string {
 	if m != nil {
-		return m.Lons
+		return m.ImgHref
 	}
-	return nil
+	return ""
 }
 
-func (m *DenseNodes) GetKeysVals() []uint32 {
+func (m *User) GetCreatedAt() int64 {
 	if m != nil {
-		return m.KeysVals
+		return m.CreatedAt
 	}
-	return nil
+	return 0
 }
 
-func (m *DenseNodes) GetStrings() []string {
+func (m *User) GetChangesetsCount() int32 {
 	if m != nil {
-		return m.Strings
+		return m.ChangesetsCount
 	}
-	return nil
+	return 0
 }
 
-type DenseInfo struct {
-	Versions   []int32 `protobuf:"varint,1,rep,packed,name=versions" json:"versions,omitempty"`
-	Timestamps []int64 `protobuf:"zigzag64,2,rep,packed,name=timestamps" json:"timestamps,omitempty"`
-	// these will be omitted if the object represents one changeset
-	// and these will be all the same.
-	ChangesetIds []int64 `protobuf:"zigzag64,3,rep,packed,name=changeset_ids,json=changesetIds" json:"changeset_ids,omitempty"`
-	UserIds      []int32 `protobuf:"zigzag32,4,rep,packed,name=user_ids,json=userIds" json:"user_ids,omitempty"`
-	UserSids     []int32 `protobuf:"zigzag32,5,rep,packed,name=user_sids,json=userSids" json:"user_sids,omitempty"`
-	// The visible flag is used to store history information. It indicates that
-	// the current object version has been created by a delete operation on the
-	// OSM API. This info may be omitted if it can be inferred from its group
-	// ie. create, modify, delete.
-	Visibles []bool `protobuf:"varint,6,rep,packed,name=visibles" json:"visibles,omitempty"`
-	// the time this element was committed into the db. Could be much later than
-	// timestamp for large uploads.
-	Committeds []int64 `protobuf:"zigzag64,7,rep,packed,name=committeds" json:"committeds,omitempty"`
+func (m *User) GetTracesCount() int32 {
+	if m != nil {
+		return m.TracesCount
+	}
+	return 0
 }
 
-func (m *DenseInfo) Reset()         { *m = DenseInfo{} }
-func (m *DenseInfo) String() string { return proto.CompactTextString(m) }
-func (*DenseInfo) ProtoMessage()    {}
-func (*DenseInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{8}
-}
-func (m *DenseInfo) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *DenseInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_DenseInfo.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *User) GetHomeLat() int64 {
+	if m != nil {
+		return m.HomeLat
 	}
-}
-func (m *DenseInfo) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DenseInfo.Merge(m, src)
-}
-func (m *DenseInfo) XXX_Size() int {
-	return m.Size()
-}
-func (m *DenseInfo) XXX_DiscardUnknown() {
-	xxx_messageInfo_DenseInfo.DiscardUnknown(m)
+	return 0
 }
 
-var xxx_messageInfo_DenseInfo proto.InternalMessageInfo
+func (m *User) GetHomeLon() int64 {
+	if m != nil {
+		return m.HomeLon
+	}
+	return 0
+}
 
-func (m *DenseInfo) GetVersions() []int32 {
+func (m *User) GetHomeZoom() int32 {
 	if m != nil {
-		return m.Versions
+		return m.HomeZoom
 	}
-	return nil
+	return 0
 }
 
-func (m *DenseInfo) GetTimestamps() []int64 {
+func (m *User) GetLanguages() []uint32 {
 	if m != nil {
-		return m.Timestamps
+		return m.Languages
 	}
 	return nil
 }
 
-func (m *DenseInfo) GetChangesetIds() []int64 {
+func (m *User) GetBlocksReceivedCount() int32 {
 	if m != nil {
-		return m.ChangesetIds
+		return m.BlocksReceivedCount
 	}
-	return nil
+	return 0
 }
 
-func (m *DenseInfo) GetUserIds() []int32 {
+func (m *User) GetBlocksReceivedActive() int32 {
 	if m != nil {
-		return m.UserIds
+		return m.BlocksReceivedActive
 	}
-	return nil
+	return 0
 }
 
-func (m *DenseInfo) GetUserSids() []int32 {
+func (m *User) GetMessagesReceivedCount() int32 {
 	if m != nil {
-		return m.UserSids
+		return m.MessagesReceivedCount
 	}
-	return nil
+	return 0
 }
 
-func (m *DenseInfo) GetVisibles() []bool {
+func (m *User) GetMessagesReceivedUnread() int32 {
 	if m != nil {
-		return m.Visibles
+		return m.MessagesReceivedUnread
 	}
-	return nil
+	return 0
 }
 
-func (m *DenseInfo) GetCommitteds() []int64 {
+func (m *User) GetMessagesSentCount() int32 {
 	if m != nil {
-		return m.Committeds
+		return m.MessagesSentCount
 	}
-	return nil
+	return 0
 }
 
-type Way struct {
+type Node struct {
 	Id int64 `protobuf:"varint,1,req,name=id" json:"id"`
 	// Parallel arrays.
 	Keys []uint32 `protobuf:"varint,2,rep,packed,name=keys" json:"keys,omitempty"`
 	Vals []uint32 `protobuf:"varint,3,rep,packed,name=vals" json:"vals,omitempty"`
 	Info *Info    `protobuf:"bytes,4,opt,name=info" json:"info,omitempty"`
-	// Only one of the next two must be included.
-	// refs are DELTA coded node ids. If there is more info,
-	// versions, changeset ids, lat and lon will be encoded
-	// as a DenseMembers object.
-	Refs         []int64       `protobuf:"zigzag64,8,rep,packed,name=refs" json:"refs,omitempty"`
-	DenseMembers *DenseMembers `protobuf:"bytes,9,opt,name=dense_members,json=denseMembers" json:"dense_members,omitempty"`
-	// updates are changes to members that did not happen
-	// at a similar time to a change in the parent.
-	Updates *DenseMembers `protobuf:"bytes,10,opt,name=updates" json:"updates,omitempty"`
+	Lat  int64    `protobuf:"zigzag64,8,req,name=lat" json:"lat"`
+	Lon  int64    `protobuf:"zigzag64,9,req,name=lon" json:"lon"`
 }
 
-func (m *Way) Reset()         { *m = Way{} }
-func (m *Way) String() string { return proto.CompactTextString(m) }
-func (*Way) ProtoMessage()    {}
-func (*Way) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{9}
+func (m *Node) Reset()         { *m = Node{} }
+func (m *Node) String() string { return proto.CompactTextString(m) }
+func (*Node) ProtoMessage()    {}
+func (*Node) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{10}
 }
-func (m *Way) XXX_Unmarshal(b []byte) error {
+func (m *Node) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Way) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Node) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Way.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Node.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -885,98 +1085,91 @@ func (m *Way) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Way) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Way.Merge(m, src)
+func (m *Node) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Node.Merge(m, src)
 }
-func (m *Way) XXX_Size() int {
+func (m *Node) XXX_Size() int {
 	return m.Size()
 }
-func (m *Way) XXX_DiscardUnknown() {
-	xxx_messageInfo_Way.DiscardUnknown(m)
+func (m *Node) XXX_DiscardUnknown() {
+	xxx_messageInfo_Node.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Way proto.InternalMessageInfo
+var xxx_messageInfo_Node proto.InternalMessageInfo
 
-func (m *Way) GetId() int64 {
+func (m *Node) GetId() int64 {
 	if m != nil {
 		return m.Id
 	}
 	return 0
 }
 
-func (m *Way) GetKeys() []uint32 {
+func (m *Node) GetKeys() []uint32 {
 	if m != nil {
 		return m.Keys
 	}
 	return nil
 }
 
-func (m *Way) GetVals() []uint32 {
+func (m *Node) GetVals() []uint32 {
 	if m != nil {
 		return m.Vals
 	}
 	return nil
 }
 
-func (m *Way) GetInfo() *Info {
+func (m *Node) GetInfo() *Info {
 	if m != nil {
 		return m.Info
 	}
 	return nil
 }
 
-func (m *Way) GetRefs() []int64 {
+func (m *Node) GetLat() int64 {
 	if m != nil {
-		return m.Refs
+		return m.Lat
 	}
-	return nil
+	return 0
 }
 
-func (m *Way) GetDenseMembers() *DenseMembers {
+func (m *Node) GetLon() int64 {
 	if m != nil {
-		return m.DenseMembers
+		return m.Lon
 	}
-	return nil
+	return 0
 }
 
-func (m *Way) GetUpdates() *DenseMembers {
-	if m != nil {
-		return m.Updates
-	}
-	return nil
+type Info struct {
+	Version   int32 `protobuf:"varint,1,opt,name=version" json:"version"`
+	Timestamp int64 `protobuf:"varint,2,opt,name=timestamp" json:"timestamp"`
+	// these can be omitted if the object represents one changeset
+	// since they will be all the same. However tests on 200k changesets
+	// show this saves about 17 bytes per changeset on average after gzip.
+	ChangesetId int64  `protobuf:"varint,3,opt,name=changeset_id,json=changesetId" json:"changeset_id"`
+	UserId      int32  `protobuf:"varint,4,opt,name=user_id,json=userId" json:"user_id"`
+	UserSid     uint32 `protobuf:"varint,5,opt,name=user_sid,json=userSid" json:"user_sid"`
+	// The visible flag is used to store history information. It indicates that
+	// the current object version has been created by a delete operation on the
+	// OSM API. This info may be omitted if it can be inferred from its group
+	// ie. create, modify, delete.
+	Visible *bool `protobuf:"varint,6,opt,name=visible" json:"visible,omitempty"`
+	// the time this element was committed into the db. Could be much later than
+	// timestamp for large uploads.
+	Committed *int64 `protobuf:"varint,7,opt,name=committed" json:"committed,omitempty"`
 }
 
-type Relation struct {
-	Id int64 `protobuf:"varint,1,req,name=id" json:"id"`
-	// Parallel arrays.
-	Keys []uint32 `protobuf:"varint,2,rep,packed,name=keys" json:"keys,omitempty"`
-	Vals []uint32 `protobuf:"varint,3,rep,packed,name=vals" json:"vals,omitempty"`
-	Info *Info    `protobuf:"bytes,4,opt,name=info" json:"info,omitempty"`
-	// Parallel arrays
-	// Roles has been changed int32 -> uint32 form the osm proto,
-	// this is for consistency and backwards compatible.
-	Roles []uint32              `protobuf:"varint,8,rep,packed,name=roles" json:"roles,omitempty"`
-	Refs  []int64               `protobuf:"zigzag64,9,rep,packed,name=refs" json:"refs,omitempty"`
-	Types []Relation_MemberType `protobuf:"varint,10,rep,packed,name=types,enum=osm.Relation_MemberType" json:"types,omitempty"`
-	// DenseMembers includes annotated information about the members
-	DenseMembers *DenseMembers `protobuf:"bytes,11,opt,name=dense_members,json=denseMembers" json:"dense_members,omitempty"`
-	// updates are changes to members that did not happen
-	// at a similar time to a change in the parent.
-	Updates *DenseMembers `protobuf:"bytes,12,opt,name=updates" json:"updates,omitempty"`
+func (m *Info) Reset()         { *m = Info{} }
+func (m *Info) String() string { return proto.CompactTextString(m) }
+func (*Info) ProtoMessage()    {}
+func (*Info) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{11}
 }
-
-func (m *Relation) Reset()         { *m = Relation{} }
-func (m *Relation) String() string { return proto.CompactTextString(m) }
-func (*Relation) ProtoMessage()    {}
-func (*Relation) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{10}
+func (m *Info) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (m *Relation) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *Relation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *Info) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Relation.Marshal(b, m, deterministic)
+		return xxx_messageInfo_Info.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -986,104 +1179,186 @@ func (m *Relation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Relation) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Relation.Merge(m, src)
+func (m *Info) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Info.Merge(m, src)
 }
-func (m *Relation) XXX_Size() int {
+func (m *Info) XXX_Size() int {
 	return m.Size()
 }
-func (m *Relation) XXX_DiscardUnknown() {
-	xxx_messageInfo_Relation.DiscardUnknown(m)
+func (m *Info) XXX_DiscardUnknown() {
+	xxx_messageInfo_Info.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Relation proto.InternalMessageInfo
+var xxx_messageInfo_Info proto.InternalMessageInfo
 
-func (m *Relation) GetId() int64 {
+func (m *Info) GetVersion() int32 {
 	if m != nil {
-		return m.Id
+		return m.Version
 	}
 	return 0
 }
 
-func (m *Relation) GetKeys() []uint32 {
+func (m *Info) GetTimestamp() int64 {
 	if m != nil {
-		return m.Keys
+		return m.Timestamp
 	}
-	return nil
+	return 0
 }
 
-func (m *Relation) GetVals() []uint32 {
+func (m *Info) GetChangesetId() int64 {
 	if m != nil {
-		return m.Vals
+		return m.ChangesetId
 	}
-	return nil
+	return 0
 }
 
-func (m *Relation) GetInfo() *Info {
+func (m *Info) GetUserId() int32 {
 	if m != nil {
-		return m.Info
+		return m.UserId
+	}
+	return 0
+}
+
+func (m *Info) GetUserSid() uint32 {
+	if m != nil {
+		return m.UserSid
+	}
+	return 0
+}
+
+func (m *Info) GetVisible() bool {
+	if m != nil && m.Visible != nil {
+		return *m.Visible
+	}
+	return false
+}
+
+func (m *Info) GetCommitted() int64 {
+	if m != nil && m.Committed != nil {
+		return *m.Committed
+	}
+	return 0
+}
+
+type DenseNodes struct {
+	Ids       []int64    `protobuf:"zigzag64,1,rep,packed,name=ids" json:"ids,omitempty"`
+	DenseInfo *DenseInfo `protobuf:"bytes,5,opt,name=dense_info,json=denseInfo" json:"dense_info,omitempty"`
+	Lats      []int64    `protobuf:"zigzag64,8,rep,packed,name=lats" json:"lats,omitempty"`
+	Lons      []int64    `protobuf:"zigzag64,9,rep,packed,name=lons" json:"lons,omitempty"`
+	// Special packing of keys and vals into one array. We use a single stringid
+	// of 0 to delimit when the tags of a node ends and the tags of the next node
+	// begin. The storage pattern is: ((<keyid> <valid>)* '0' )* As an exception,
+	// if no node in the current block has any key/value pairs, this array does
+	// not contain any delimiters, but is simply empty.
+	KeysVals []uint32 `protobuf:"varint,10,rep,packed,name=keys_vals,json=keysVals" json:"keys_vals,omitempty"`
+	Strings  []string `protobuf:"bytes,15,rep,name=strings" json:"strings,omitempty"`
+}
+
+func (m *DenseNodes) Reset()         { *m = DenseNodes{} }
+func (m *DenseNodes) String() string { return proto.CompactTextString(m) }
+func (*DenseNodes) ProtoMessage()    {}
+func (*DenseNodes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{12}
+}
+func (m *DenseNodes) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DenseNodes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DenseNodes.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DenseNodes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DenseNodes.Merge(m, src)
+}
+func (m *DenseNodes) XXX_Size() int {
+	return m.Size()
+}
+func (m *DenseNodes) XXX_DiscardUnknown() {
+	xxx_messageInfo_DenseNodes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DenseNodes proto.InternalMessageInfo
+
+func (m *DenseNodes) GetIds() []int64 {
+	if m != nil {
+		return m.Ids
 	}
 	return nil
 }
 
-func (m *Relation) GetRoles() []uint32 {
+func (m *DenseNodes) GetDenseInfo() *DenseInfo {
 	if m != nil {
-		return m.Roles
+		return m.DenseInfo
 	}
 	return nil
 }
 
-func (m *Relation) GetRefs() []int64 {
+func (m *DenseNodes) GetLats() []int64 {
 	if m != nil {
-		return m.Refs
+		return m.Lats
 	}
 	return nil
 }
 
-func (m *Relation) GetTypes() []Relation_MemberType {
+func (m *DenseNodes) GetLons() []int64 {
 	if m != nil {
-		return m.Types
+		return m.Lons
 	}
 	return nil
 }
 
-func (m *Relation) GetDenseMembers() *DenseMembers {
+func (m *DenseNodes) GetKeysVals() []uint32 {
 	if m != nil {
-		return m.DenseMembers
+		return m.KeysVals
 	}
 	return nil
 }
 
-func (m *Relation) GetUpdates() *DenseMembers {
+func (m *DenseNodes) GetStrings() []string {
 	if m != nil {
-		return m.Updates
+		return m.Strings
 	}
 	return nil
 }
 
-type DenseMembers struct {
-	Indexes      []int32 `protobuf:"zigzag32,1,rep,packed,name=indexes" json:"indexes,omitempty"`
-	Versions     []int32 `protobuf:"varint,2,rep,packed,name=versions" json:"versions,omitempty"`
-	Timestamps   []int64 `protobuf:"zigzag64,3,rep,packed,name=timestamps" json:"timestamps,omitempty"`
-	ChangesetIds []int64 `protobuf:"zigzag64,4,rep,packed,name=changeset_ids,json=changesetIds" json:"changeset_ids,omitempty"`
-	Orientation  []int32 `protobuf:"zigzag32,5,rep,packed,name=orientation" json:"orientation,omitempty"`
-	// included if some of the members are nodes
-	Lats []int64 `protobuf:"zigzag64,8,rep,packed,name=lats" json:"lats,omitempty"`
-	Lons []int64 `protobuf:"zigzag64,9,rep,packed,name=lons" json:"lons,omitempty"`
+type DenseInfo struct {
+	Versions   []int32 `protobuf:"varint,1,rep,packed,name=versions" json:"versions,omitempty"`
+	Timestamps []int64 `protobuf:"zigzag64,2,rep,packed,name=timestamps" json:"timestamps,omitempty"`
+	// these will be omitted if the object represents one changeset
+	// and these will be all the same.
+	ChangesetIds []int64 `protobuf:"zigzag64,3,rep,packed,name=changeset_ids,json=changesetIds" json:"changeset_ids,omitempty"`
+	UserIds      []int32 `protobuf:"zigzag32,4,rep,packed,name=user_ids,json=userIds" json:"user_ids,omitempty"`
+	UserSids     []int32 `protobuf:"zigzag32,5,rep,packed,name=user_sids,json=userSids" json:"user_sids,omitempty"`
+	// The visible flag is used to store history information. It indicates that
+	// the current object version has been created by a delete operation on the
+	// OSM API. This info may be omitted if it can be inferred from its group
+	// ie. create, modify, delete.
+	Visibles []bool `protobuf:"varint,6,rep,packed,name=visibles" json:"visibles,omitempty"`
+	// the time this element was committed into the db. Could be much later than
+	// timestamp for large uploads.
+	Committeds []int64 `protobuf:"zigzag64,7,rep,packed,name=committeds" json:"committeds,omitempty"`
 }
 
-func (m *DenseMembers) Reset()         { *m = DenseMembers{} }
-func (m *DenseMembers) String() string { return proto.CompactTextString(m) }
-func (*DenseMembers) ProtoMessage()    {}
-func (*DenseMembers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_537306c3a4d945df, []int{11}
+func (m *DenseInfo) Reset()         { *m = DenseInfo{} }
+func (m *DenseInfo) String() string { return proto.CompactTextString(m) }
+func (*DenseInfo) ProtoMessage()    {}
+func (*DenseInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{13}
 }
-func (m *DenseMembers) XXX_Unmarshal(b []byte) error {
+func (m *DenseInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *DenseMembers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *DenseInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_DenseMembers.Marshal(b, m, deterministic)
+		return xxx_messageInfo_DenseInfo.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1093,336 +1368,524 @@ func (m *DenseMembers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *DenseMembers) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DenseMembers.Merge(m, src)
+func (m *DenseInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DenseInfo.Merge(m, src)
 }
-func (m *DenseMembers) XXX_Size() int {
+func (m *DenseInfo) XXX_Size() int {
 	return m.Size()
 }
-func (m *DenseMembers) XXX_DiscardUnknown() {
-	xxx_messageInfo_DenseMembers.DiscardUnknown(m)
+func (m *DenseInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_DenseInfo.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DenseMembers proto.InternalMessageInfo
+var xxx_messageInfo_DenseInfo proto.InternalMessageInfo
 
-func (m *DenseMembers) GetIndexes() []int32 {
+func (m *DenseInfo) GetVersions() []int32 {
 	if m != nil {
-		return m.Indexes
+		return m.Versions
 	}
 	return nil
 }
 
-func (m *DenseMembers) GetVersions() []int32 {
+func (m *DenseInfo) GetTimestamps() []int64 {
 	if m != nil {
-		return m.Versions
+		return m.Timestamps
 	}
 	return nil
 }
 
-func (m *DenseMembers) GetTimestamps() []int64 {
+func (m *DenseInfo) GetChangesetIds() []int64 {
 	if m != nil {
-		return m.Timestamps
+		return m.ChangesetIds
 	}
 	return nil
 }
 
-func (m *DenseMembers) GetChangesetIds() []int64 {
+func (m *DenseInfo) GetUserIds() []int32 {
 	if m != nil {
-		return m.ChangesetIds
+		return m.UserIds
 	}
 	return nil
 }
 
-func (m *DenseMembers) GetOrientation() []int32 {
+func (m *DenseInfo) GetUserSids() []int32 {
 	if m != nil {
-		return m.Orientation
+		return m.UserSids
 	}
 	return nil
 }
 
-func (m *DenseMembers) GetLats() []int64 {
+func (m *DenseInfo) GetVisibles() []bool {
 	if m != nil {
-		return m.Lats
+		return m.Visibles
 	}
 	return nil
 }
 
-func (m *DenseMembers) GetLons() []int64 {
+func (m *DenseInfo) GetCommitteds() []int64 {
 	if m != nil {
-		return m.Lons
+		return m.Committeds
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("osm.Relation_MemberType", Relation_MemberType_name, Relation_MemberType_value)
-	proto.RegisterType((*Changeset)(nil), "osm.Changeset")
-	proto.RegisterType((*Bounds)(nil), "osm.Bounds")
-	proto.RegisterType((*Change)(nil), "osm.Change")
-	proto.RegisterType((*Tags)(nil), "osm.Tags")
-	proto.RegisterType((*OSM)(nil), "osm.OSM")
-	proto.RegisterType((*Node)(nil), "osm.Node")
-	proto.RegisterType((*Info)(nil), "osm.Info")
-	proto.RegisterType((*DenseNodes)(nil), "osm.DenseNodes")
-	proto.RegisterType((*DenseInfo)(nil), "osm.DenseInfo")
-	proto.RegisterType((*Way)(nil), "osm.Way")
-	proto.RegisterType((*Relation)(nil), "osm.Relation")
-	proto.RegisterType((*DenseMembers)(nil), "osm.DenseMembers")
+type Way struct {
+	Id int64 `protobuf:"varint,1,req,name=id" json:"id"`
+	// Parallel arrays.
+	Keys []uint32 `protobuf:"varint,2,rep,packed,name=keys" json:"keys,omitempty"`
+	Vals []uint32 `protobuf:"varint,3,rep,packed,name=vals" json:"vals,omitempty"`
+	Info *Info    `protobuf:"bytes,4,opt,name=info" json:"info,omitempty"`
+	// Only one of the next two must be included.
+	// refs are DELTA coded node ids. If there is more info,
+	// versions, changeset ids, lat and lon will be encoded
+	// as a DenseMembers object.
+	Refs         []int64       `protobuf:"zigzag64,8,rep,packed,name=refs" json:"refs,omitempty"`
+	DenseMembers *DenseMembers `protobuf:"bytes,9,opt,name=dense_members,json=denseMembers" json:"dense_members,omitempty"`
+	// updates are changes to members that did not happen
+	// at a similar time to a change in the parent.
+	Updates *DenseMembers `protobuf:"bytes,10,opt,name=updates" json:"updates,omitempty"`
 }
 
-func init() { proto.RegisterFile("osm.proto", fileDescriptor_537306c3a4d945df) }
-
-var fileDescriptor_537306c3a4d945df = []byte{
-	// 1060 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xbf, 0x6e, 0x1b, 0xc7,
-	0x13, 0xe6, 0xde, 0x1d, 0xc9, 0xbb, 0x21, 0x65, 0xcb, 0x0b, 0xc1, 0x58, 0xfc, 0x7e, 0x0e, 0xc5,
-	0x9c, 0x02, 0x98, 0x80, 0x21, 0x39, 0x60, 0x91, 0x5e, 0x8a, 0x5d, 0x08, 0xb0, 0x24, 0xe0, 0x24,
-	0x44, 0x48, 0x1a, 0xe2, 0xc8, 0x5d, 0xd1, 0x87, 0xf0, 0x6e, 0x09, 0xee, 0xd2, 0x11, 0xfb, 0x3c,
-	0x40, 0xfa, 0xbc, 0x42, 0x1e, 0x21, 0x0f, 0xe0, 0xd2, 0x5d, 0x52, 0x05, 0x81, 0x54, 0x04, 0xa9,
-	0x52, 0xa6, 0x49, 0x11, 0xec, 0xec, 0xfd, 0x23, 0xe3, 0x3f, 0x71, 0x0a, 0x77, 0xdc, 0x6f, 0xbe,
-	0xd9, 0x9b, 0xfd, 0xe6, 0x9b, 0x21, 0x04, 0x52, 0xa5, 0x07, 0xf3, 0x85, 0xd4, 0x92, 0xba, 0x52,
-	0xa5, 0xff, 0xdb, 0x9f, 0x26, 0xfa, 0xf9, 0x72, 0x7c, 0x30, 0x91, 0xe9, 0xe3, 0xa9, 0x9c, 0xca,
-	0xc7, 0x18, 0x1b, 0x2f, 0xaf, 0xf0, 0x84, 0x07, 0xfc, 0x65, 0x73, 0xc2, 0x9f, 0x1c, 0x08, 0x3e,
-	0x7f, 0x1e, 0x67, 0x53, 0xa1, 0x84, 0xa6, 0x3b, 0xe0, 0x24, 0x9c, 0x91, 0x3e, 0x19, 0xb8, 0x47,
-	0xde, 0xcb, 0x5f, 0x76, 0x49, 0xe4, 0x24, 0x9c, 0xde, 0x07, 0xef, 0x6b, 0xb1, 0x52, 0xcc, 0xe9,
-	0xbb, 0x83, 0xad, 0x23, 0x67, 0x9b, 0x44, 0x78, 0x36, 0xf8, 0x8b, 0x78, 0xa6, 0x98, 0x5b, 0xe1,
-	0xe6, 0x4c, 0x3f, 0x82, 0xf6, 0x52, 0x89, 0xc5, 0x28, 0xe1, 0xac, 0xd9, 0x27, 0x83, 0x66, 0x7e,
-	0x55, 0xcb, 0x80, 0xc7, 0x9c, 0xee, 0x82, 0x8f, 0x61, 0x95, 0x70, 0xd6, 0xea, 0x93, 0xc1, 0x56,
-	0x1e, 0xc7, 0xa4, 0xf3, 0x84, 0xd3, 0x3d, 0x80, 0xc9, 0x42, 0xc4, 0x5a, 0xf0, 0x51, 0xac, 0x59,
-	0xbb, 0x56, 0x4d, 0x90, 0xe3, 0x87, 0x9a, 0x7e, 0x0c, 0xc1, 0x64, 0x26, 0x95, 0xe5, 0xf8, 0x35,
-	0x8e, 0x6f, 0xe1, 0x43, 0x4d, 0x19, 0x78, 0x72, 0x2e, 0x32, 0x16, 0xf4, 0xc9, 0xc0, 0xcf, 0xa3,
-	0x88, 0xd0, 0x3d, 0x68, 0x8d, 0xe5, 0x32, 0xe3, 0x8a, 0x41, 0x9f, 0x0c, 0x3a, 0xc3, 0xce, 0x81,
-	0x51, 0xf1, 0x08, 0xa1, 0x28, 0x0f, 0x19, 0xd2, 0x04, 0x95, 0x61, 0x9d, 0x1a, 0xc9, 0x8a, 0x15,
-	0xe5, 0x21, 0xca, 0xa0, 0xad, 0xf4, 0x22, 0xc9, 0xa6, 0x8a, 0xed, 0xf4, 0xdd, 0x41, 0x10, 0x15,
-	0xc7, 0xf0, 0x5b, 0x02, 0x2d, 0x7b, 0xa3, 0x11, 0x24, 0x4d, 0xb2, 0xd1, 0x4c, 0x66, 0x8c, 0xf4,
-	0x9d, 0x01, 0xc5, 0x5a, 0x1a, 0x51, 0x2b, 0x4d, 0xb2, 0x67, 0x32, 0xc3, 0x70, 0x7c, 0x8d, 0x61,
-	0x67, 0x2d, 0x1c, 0x5f, 0x17, 0x61, 0x93, 0x1d, 0x6b, 0xe6, 0x6e, 0x66, 0xc7, 0xba, 0xcc, 0x8e,
-	0x35, 0xf3, 0x36, 0xb3, 0x63, 0x1d, 0xfe, 0x40, 0xa0, 0x65, 0x6b, 0xa6, 0x7d, 0x68, 0x59, 0xfd,
-	0xb0, 0xc3, 0x9d, 0xa1, 0x8f, 0x0f, 0x3a, 0x3b, 0x3f, 0x89, 0x72, 0xdc, 0x30, 0x52, 0xc9, 0x93,
-	0xab, 0x15, 0x73, 0x36, 0x19, 0x16, 0x37, 0x0c, 0x2e, 0x66, 0x42, 0x0b, 0xe6, 0x6e, 0x32, 0x2c,
-	0x4e, 0x43, 0x68, 0x4f, 0x64, 0xa6, 0xc5, 0xb5, 0xa9, 0x67, 0x9d, 0x52, 0x04, 0xde, 0xa2, 0xda,
-	0x1e, 0x78, 0x17, 0xf1, 0x54, 0xd1, 0xff, 0x43, 0x60, 0x3c, 0x36, 0x42, 0x83, 0x11, 0xe4, 0xf8,
-	0x06, 0xf8, 0x22, 0x9e, 0xa9, 0xf0, 0x86, 0x80, 0x7b, 0x76, 0x7e, 0x52, 0x6b, 0x23, 0x79, 0x73,
-	0x1b, 0x77, 0xa1, 0x99, 0x49, 0x2e, 0xac, 0x7d, 0x3b, 0xc3, 0x00, 0x39, 0xa7, 0x92, 0x8b, 0xc8,
-	0xe2, 0xf4, 0x53, 0xe8, 0x70, 0x91, 0x29, 0x31, 0xb2, 0x34, 0xfb, 0xae, 0xbb, 0x48, 0x7b, 0x62,
-	0x70, 0xc3, 0x55, 0x11, 0xf0, 0xf2, 0x37, 0x7d, 0x00, 0xde, 0x37, 0xf1, 0x4a, 0x31, 0x0f, 0x6f,
-	0xb4, 0xef, 0xbb, 0x8c, 0x57, 0x11, 0xa2, 0xf4, 0x11, 0x04, 0x0b, 0x31, 0x8b, 0x75, 0x22, 0x33,
-	0xc5, 0x9a, 0x48, 0xd9, 0x42, 0x4a, 0x94, 0xa3, 0x51, 0x15, 0xaf, 0x2b, 0x71, 0x77, 0x5d, 0x89,
-	0xef, 0x09, 0x78, 0xe6, 0x73, 0xe5, 0x50, 0x3a, 0xb9, 0xc5, 0x1b, 0xff, 0x71, 0x28, 0xbd, 0x24,
-	0xbb, 0x92, 0x79, 0x4f, 0xac, 0x0a, 0xc7, 0xd9, 0x95, 0x8c, 0x10, 0xa6, 0xf7, 0xc1, 0x9d, 0xe1,
-	0x20, 0x55, 0x0e, 0x32, 0x00, 0xe2, 0xd2, 0x8c, 0x50, 0x1d, 0x97, 0x59, 0xf8, 0x27, 0x01, 0xcf,
-	0xa4, 0xd3, 0x1e, 0xb4, 0x5f, 0x88, 0x85, 0x4a, 0xd0, 0xdb, 0xc5, 0xb0, 0x37, 0xa2, 0x02, 0xa4,
-	0x21, 0x04, 0x3a, 0x49, 0x85, 0xd2, 0x71, 0x3a, 0x47, 0x57, 0x15, 0x8f, 0xa8, 0x60, 0xfa, 0x10,
-	0xba, 0x93, 0x62, 0x07, 0x99, 0xad, 0xe1, 0xd6, 0x68, 0x9d, 0x32, 0x72, 0xcc, 0xeb, 0x9b, 0xc5,
-	0xab, 0x7d, 0xec, 0x75, 0x9b, 0xa5, 0x59, 0x6e, 0x96, 0x46, 0xb5, 0x59, 0x4c, 0xb1, 0x89, 0x4a,
-	0xc6, 0x33, 0x81, 0x9b, 0xa7, 0x58, 0x0a, 0x05, 0x68, 0x8a, 0x9d, 0xc8, 0x34, 0x4d, 0xb4, 0x16,
-	0x7c, 0x63, 0xf1, 0x14, 0x70, 0xf8, 0x23, 0x01, 0xa8, 0x7c, 0x41, 0x77, 0xc0, 0x4d, 0xb8, 0xb5,
-	0x28, 0x45, 0xb9, 0xcd, 0x91, 0xee, 0x83, 0xf5, 0xcb, 0x08, 0x35, 0x6f, 0xa2, 0xe6, 0x77, 0x2a,
-	0x4b, 0xa1, 0xf0, 0x01, 0x2f, 0x7e, 0x9a, 0xa6, 0xcd, 0x62, 0xad, 0x98, 0x5f, 0xde, 0x82, 0x67,
-	0xc4, 0x8d, 0x8b, 0x82, 0x1a, 0x6e, 0x5c, 0xb3, 0x5b, 0x9f, 0x0e, 0x28, 0x3b, 0x5d, 0x4e, 0xc8,
-	0x5b, 0x6c, 0xf5, 0x17, 0x81, 0xa0, 0xac, 0x81, 0xf6, 0xc0, 0xcf, 0x1b, 0x65, 0x9f, 0xd0, 0xb4,
-	0xf7, 0x14, 0x18, 0x0d, 0x01, 0xca, 0x36, 0x59, 0xaf, 0xd9, 0x32, 0x6a, 0x28, 0x7d, 0x08, 0x5b,
-	0xf5, 0xee, 0x59, 0xeb, 0x59, 0x5a, 0xb7, 0xd6, 0x3c, 0x63, 0x41, 0x3f, 0xef, 0x9e, 0x1d, 0x9d,
-	0x7b, 0xc8, 0x69, 0xdb, 0xe6, 0xe1, 0xa3, 0x8a, 0xee, 0xd9, 0xb9, 0xb1, 0x71, 0x3f, 0x6f, 0x9e,
-	0xc2, 0x62, 0x6d, 0xa3, 0x14, 0x6b, 0xf5, 0xdd, 0x81, 0x9f, 0x17, 0x9b, 0x63, 0xa6, 0xd8, 0xb2,
-	0x4d, 0x8a, 0xb5, 0xab, 0x62, 0x2b, 0x34, 0xfc, 0x8d, 0x80, 0x7b, 0x19, 0xaf, 0x3e, 0xd4, 0x50,
-	0x79, 0x0b, 0x71, 0xb5, 0xd6, 0x56, 0x73, 0xa6, 0x9f, 0xc1, 0x96, 0x75, 0x47, 0x2a, 0xd2, 0xb1,
-	0x58, 0x28, 0xfc, 0x87, 0xea, 0x0c, 0xef, 0x55, 0x06, 0x39, 0xb1, 0x81, 0xa8, 0xcb, 0x6b, 0x27,
-	0xfa, 0x08, 0xda, 0xcb, 0x39, 0x8f, 0xb5, 0x28, 0xfe, 0xb7, 0x5e, 0x93, 0x51, 0x30, 0xc2, 0xdf,
-	0x1d, 0xf0, 0x8b, 0x8d, 0xf3, 0x61, 0x9e, 0xcb, 0xa0, 0xb9, 0x90, 0xa6, 0x39, 0x7e, 0x99, 0x67,
-	0x81, 0x52, 0x88, 0x60, 0x43, 0x88, 0x21, 0x34, 0xf5, 0x6a, 0x2e, 0xac, 0x87, 0xef, 0x0c, 0xd9,
-	0xda, 0x9a, 0x3c, 0xb0, 0x4f, 0xba, 0x58, 0xcd, 0x85, 0xbd, 0x0b, 0xa9, 0xff, 0x14, 0xaf, 0xf3,
-	0xde, 0xe2, 0x75, 0xdf, 0x29, 0xde, 0x3e, 0x40, 0xf5, 0x75, 0xea, 0x83, 0x77, 0x7a, 0xf6, 0xe4,
-	0xe9, 0x76, 0x83, 0xb6, 0xc1, 0xbd, 0x3c, 0xfc, 0x72, 0x9b, 0xd0, 0x2e, 0xf8, 0xd1, 0xd3, 0x67,
-	0x87, 0x17, 0xc7, 0x67, 0xa7, 0xdb, 0x4e, 0xf8, 0x07, 0x81, 0x6e, 0xfd, 0x22, 0xfa, 0x00, 0xda,
-	0x49, 0xc6, 0xc5, 0xb5, 0xb0, 0x63, 0x95, 0x3b, 0x3d, 0x87, 0xd6, 0xa6, 0xce, 0x79, 0xe7, 0xd4,
-	0xb9, 0xff, 0x6e, 0xea, 0xbc, 0x37, 0x4c, 0xdd, 0x27, 0xd0, 0x91, 0x8b, 0x44, 0x64, 0x1a, 0x45,
-	0xad, 0x0d, 0x56, 0x1d, 0x7e, 0xdf, 0x0d, 0x74, 0xb4, 0xfb, 0xf2, 0xa6, 0x47, 0x5e, 0xdd, 0xf4,
-	0xc8, 0xaf, 0x37, 0x3d, 0xf2, 0xdd, 0x6d, 0xaf, 0xf1, 0xea, 0xb6, 0xd7, 0xf8, 0xf9, 0xb6, 0xd7,
-	0xf8, 0xaa, 0x29, 0x55, 0x3a, 0x1f, 0xff, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xbe, 0x9c, 0xb9, 0x8b,
-	0x98, 0x0a, 0x00, 0x00,
+func (m *Way) Reset()         { *m = Way{} }
+func (m *Way) String() string { return proto.CompactTextString(m) }
+func (*Way) ProtoMessage()    {}
+func (*Way) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{14}
 }
-
-func (m *Changeset) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Way) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Way) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Way.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return dAtA[:n], nil
 }
-
-func (m *Changeset) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Way) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Way.Merge(m, src)
+}
+func (m *Way) XXX_Size() int {
+	return m.Size()
+}
+func (m *Way) XXX_DiscardUnknown() {
+	xxx_messageInfo_Way.DiscardUnknown(m)
 }
 
-func (m *Changeset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Strings) > 0 {
-		for iNdEx := len(m.Strings) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Strings[iNdEx])
-			copy(dAtA[i:], m.Strings[iNdEx])
-			i = encodeVarintOsm(dAtA, i, uint64(len(m.Strings[iNdEx])))
-			i--
-			dAtA[i] = 0x1
-			i--
-			dAtA[i] = 0xa2
-		}
-	}
-	if m.Change != nil {
-		{
-			size, err := m.Change.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x5a
-	}
-	if m.Bounds != nil {
-		{
-			size, err := m.Bounds.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x52
+var xxx_messageInfo_Way proto.InternalMessageInfo
+
+func (m *Way) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	if m.Open != nil {
-		i--
-		if *m.Open {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x48
+	return 0
+}
+
+func (m *Way) GetKeys() []uint32 {
+	if m != nil {
+		return m.Keys
 	}
-	if m.ClosedAt != nil {
-		i = encodeVarintOsm(dAtA, i, uint64(*m.ClosedAt))
-		i--
-		dAtA[i] = 0x40
+	return nil
+}
+
+func (m *Way) GetVals() []uint32 {
+	if m != nil {
+		return m.Vals
 	}
-	if m.CreatedAt != nil {
-		i = encodeVarintOsm(dAtA, i, uint64(*m.CreatedAt))
-		i--
-		dAtA[i] = 0x38
+	return nil
+}
+
+func (m *Way) GetInfo() *Info {
+	if m != nil {
+		return m.Info
 	}
-	if m.UserSid != nil {
-		i = encodeVarintOsm(dAtA, i, uint64(*m.UserSid))
-		i--
-		dAtA[i] = 0x30
+	return nil
+}
+
+func (m *Way) GetRefs() []int64 {
+	if m != nil {
+		return m.Refs
 	}
-	if m.UserId != nil {
-		i = encodeVarintOsm(dAtA, i, uint64(*m.UserId))
-		i--
-		dAtA[i] = 0x28
+	return nil
+}
+
+func (m *Way) GetDenseMembers() *DenseMembers {
+	if m != nil {
+		return m.DenseMembers
 	}
-	if len(m.Vals) > 0 {
-		dAtA4 := make([]byte, len(m.Vals)*10)
-		var j3 int
-		for _, num := range m.Vals {
-			for num >= 1<<7 {
-				dAtA4[j3] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j3++
-			}
-			dAtA4[j3] = uint8(num)
-			j3++
-		}
-		i -= j3
-		copy(dAtA[i:], dAtA4[:j3])
-		i = encodeVarintOsm(dAtA, i, uint64(j3))
-		i--
-		dAtA[i] = 0x1a
+	return nil
+}
+
+func (m *Way) GetUpdates() *DenseMembers {
+	if m != nil {
+		return m.Updates
 	}
-	if len(m.Keys) > 0 {
-		dAtA6 := make([]byte, len(m.Keys)*10)
-		var j5 int
-		for _, num := range m.Keys {
-			for num >= 1<<7 {
-				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j5++
-			}
-			dAtA6[j5] = uint8(num)
-			j5++
+	return nil
+}
+
+type Relation struct {
+	Id int64 `protobuf:"varint,1,req,name=id" json:"id"`
+	// Parallel arrays.
+	Keys []uint32 `protobuf:"varint,2,rep,packed,name=keys" json:"keys,omitempty"`
+	Vals []uint32 `protobuf:"varint,3,rep,packed,name=vals" json:"vals,omitempty"`
+	Info *Info    `protobuf:"bytes,4,opt,name=info" json:"info,omitempty"`
+	// Parallel arrays
+	// Roles has been changed int32 -> uint32 form the osm proto,
+	// this is for consistency and backwards compatible.
+	Roles []uint32              `protobuf:"varint,8,rep,packed,name=roles" json:"roles,omitempty"`
+	Refs  []int64               `protobuf:"zigzag64,9,rep,packed,name=refs" json:"refs,omitempty"`
+	Types []Relation_MemberType `protobuf:"varint,10,rep,packed,name=types,enum=osm.Relation_MemberType" json:"types,omitempty"`
+	// DenseMembers includes annotated information about the members
+	DenseMembers *DenseMembers `protobuf:"bytes,11,opt,name=dense_members,json=denseMembers" json:"dense_members,omitempty"`
+	// updates are changes to members that did not happen
+	// at a similar time to a change in the parent.
+	Updates *DenseMembers `protobuf:"bytes,12,opt,name=updates" json:"updates,omitempty"`
+}
+
+func (m *Relation) Reset()         { *m = Relation{} }
+func (m *Relation) String() string { return proto.CompactTextString(m) }
+func (*Relation) ProtoMessage()    {}
+func (*Relation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{15}
+}
+func (m *Relation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Relation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Relation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i -= j5
-		copy(dAtA[i:], dAtA6[:j5])
-		i = encodeVarintOsm(dAtA, i, uint64(j5))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Id != nil {
-		i = encodeVarintOsm(dAtA, i, uint64(*m.Id))
-		i--
-		dAtA[i] = 0x8
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *Relation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Relation.Merge(m, src)
+}
+func (m *Relation) XXX_Size() int {
+	return m.Size()
+}
+func (m *Relation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Relation.DiscardUnknown(m)
 }
 
-func (m *Bounds) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Relation proto.InternalMessageInfo
+
+func (m *Relation) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Bounds) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Relation) GetKeys() []uint32 {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
 }
 
-func (m *Bounds) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MaxLat)<<1)^uint64((m.MaxLat>>63))))
-	i--
-	dAtA[i] = 0x20
-	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MinLat)<<1)^uint64((m.MinLat>>63))))
-	i--
-	dAtA[i] = 0x18
-	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MaxLon)<<1)^uint64((m.MaxLon>>63))))
-	i--
-	dAtA[i] = 0x10
-	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MinLon)<<1)^uint64((m.MinLon>>63))))
-	i--
-	dAtA[i] = 0x8
-	return len(dAtA) - i, nil
+func (m *Relation) GetVals() []uint32 {
+	if m != nil {
+		return m.Vals
+	}
+	return nil
 }
 
-func (m *Change) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Relation) GetInfo() *Info {
+	if m != nil {
+		return m.Info
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Change) MarshalTo(dAtA []byte) (int, error) {
+func (m *Relation) GetRoles() []uint32 {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *Relation) GetRefs() []int64 {
+	if m != nil {
+		return m.Refs
+	}
+	return nil
+}
+
+func (m *Relation) GetTypes() []Relation_MemberType {
+	if m != nil {
+		return m.Types
+	}
+	return nil
+}
+
+func (m *Relation) GetDenseMembers() *DenseMembers {
+	if m != nil {
+		return m.DenseMembers
+	}
+	return nil
+}
+
+func (m *Relation) GetUpdates() *DenseMembers {
+	if m != nil {
+		return m.Updates
+	}
+	return nil
+}
+
+type DenseMembers struct {
+	Indexes      []int32 `protobuf:"zigzag32,1,rep,packed,name=indexes" json:"indexes,omitempty"`
+	Versions     []int32 `protobuf:"varint,2,rep,packed,name=versions" json:"versions,omitempty"`
+	Timestamps   []int64 `protobuf:"zigzag64,3,rep,packed,name=timestamps" json:"timestamps,omitempty"`
+	ChangesetIds []int64 `protobuf:"zigzag64,4,rep,packed,name=changeset_ids,json=changesetIds" json:"changeset_ids,omitempty"`
+	Orientation  []int32 `protobuf:"zigzag32,5,rep,packed,name=orientation" json:"orientation,omitempty"`
+	// included if some of the members are nodes
+	Lats []int64 `protobuf:"zigzag64,8,rep,packed,name=lats" json:"lats,omitempty"`
+	Lons []int64 `protobuf:"zigzag64,9,rep,packed,name=lons" json:"lons,omitempty"`
+}
+
+func (m *DenseMembers) Reset()         { *m = DenseMembers{} }
+func (m *DenseMembers) String() string { return proto.CompactTextString(m) }
+func (*DenseMembers) ProtoMessage()    {}
+func (*DenseMembers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_537306c3a4d945df, []int{16}
+}
+func (m *DenseMembers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DenseMembers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DenseMembers.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DenseMembers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DenseMembers.Merge(m, src)
+}
+func (m *DenseMembers) XXX_Size() int {
+	return m.Size()
+}
+func (m *DenseMembers) XXX_DiscardUnknown() {
+	xxx_messageInfo_DenseMembers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DenseMembers proto.InternalMessageInfo
+
+func (m *DenseMembers) GetIndexes() []int32 {
+	if m != nil {
+		return m.Indexes
+	}
+	return nil
+}
+
+func (m *DenseMembers) GetVersions() []int32 {
+	if m != nil {
+		return m.Versions
+	}
+	return nil
+}
+
+func (m *DenseMembers) GetTimestamps() []int64 {
+	if m != nil {
+		return m.Timestamps
+	}
+	return nil
+}
+
+func (m *DenseMembers) GetChangesetIds() []int64 {
+	if m != nil {
+		return m.ChangesetIds
+	}
+	return nil
+}
+
+func (m *DenseMembers) GetOrientation() []int32 {
+	if m != nil {
+		return m.Orientation
+	}
+	return nil
+}
+
+func (m *DenseMembers) GetLats() []int64 {
+	if m != nil {
+		return m.Lats
+	}
+	return nil
+}
+
+func (m *DenseMembers) GetLons() []int64 {
+	if m != nil {
+		return m.Lons
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("osm.Relation_MemberType", Relation_MemberType_name, Relation_MemberType_value)
+	proto.RegisterType((*Changeset)(nil), "osm.Changeset")
+	proto.RegisterType((*ChangesetDiscussion)(nil), "osm.ChangesetDiscussion")
+	proto.RegisterType((*ChangesetComment)(nil), "osm.ChangesetComment")
+	proto.RegisterType((*Bounds)(nil), "osm.Bounds")
+	proto.RegisterType((*Change)(nil), "osm.Change")
+	proto.RegisterType((*Tags)(nil), "osm.Tags")
+	proto.RegisterType((*OSM)(nil), "osm.OSM")
+	proto.RegisterType((*Note)(nil), "osm.Note")
+	proto.RegisterType((*NoteComment)(nil), "osm.NoteComment")
+	proto.RegisterType((*User)(nil), "osm.User")
+	proto.RegisterType((*Node)(nil), "osm.Node")
+	proto.RegisterType((*Info)(nil), "osm.Info")
+	proto.RegisterType((*DenseNodes)(nil), "osm.DenseNodes")
+	proto.RegisterType((*DenseInfo)(nil), "osm.DenseInfo")
+	proto.RegisterType((*Way)(nil), "osm.Way")
+	proto.RegisterType((*Relation)(nil), "osm.Relation")
+	proto.RegisterType((*DenseMembers)(nil), "osm.DenseMembers")
+}
+
+func init() { proto.RegisterFile("osm.proto", fileDescriptor_537306c3a4d945df) }
+
+var fileDescriptor_537306c3a4d945df = []byte{
+	// 1704 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x57, 0x4f, 0x8f, 0x1b, 0x49,
+	0x15, 0x77, 0xff, 0xb1, 0xdd, 0xfd, 0xda, 0x9e, 0x38, 0x95, 0x3f, 0x94, 0x60, 0xf1, 0x98, 0x0e,
+	0x10, 0xa3, 0x90, 0x09, 0x8c, 0x10, 0x5a, 0x21, 0x84, 0x34, 0x99, 0xac, 0xb4, 0x23, 0x25, 0x19,
+	0xa9, 0x27, 0x21, 0x62, 0x2f, 0x56, 0xbb, 0xbb, 0xc6, 0xd3, 0x5a, 0x77, 0x97, 0xd5, 0x55, 0x1e,
+	0xc6, 0x9c, 0xf7, 0x03, 0xec, 0x9d, 0xaf, 0x80, 0xc4, 0x17, 0xe0, 0x03, 0xec, 0x71, 0x8f, 0x9c,
+	0x10, 0x24, 0x07, 0xb4, 0x27, 0x6e, 0x70, 0xe1, 0x80, 0x5e, 0x55, 0x77, 0xbb, 0xec, 0x99, 0xd9,
+	0x21, 0x97, 0xdc, 0x5c, 0xbf, 0xf7, 0x7b, 0x5d, 0xef, 0x7f, 0x3d, 0x83, 0xcf, 0x45, 0xbe, 0xb7,
+	0x28, 0xb9, 0xe4, 0xc4, 0xe1, 0x22, 0xff, 0xee, 0xe3, 0x59, 0x26, 0xcf, 0x96, 0xd3, 0xbd, 0x84,
+	0xe7, 0x4f, 0x66, 0x7c, 0xc6, 0x9f, 0x28, 0xd9, 0x74, 0x79, 0xaa, 0x4e, 0xea, 0xa0, 0x7e, 0x69,
+	0x9d, 0xf0, 0x1b, 0x07, 0xfc, 0xc3, 0xb3, 0xb8, 0x98, 0x31, 0xc1, 0x24, 0xb9, 0x0b, 0x76, 0x96,
+	0x52, 0x6b, 0x64, 0x8d, 0x9d, 0xa7, 0xee, 0x57, 0x7f, 0xdb, 0xb5, 0x22, 0x3b, 0x4b, 0xc9, 0x7d,
+	0x70, 0x3f, 0x67, 0x2b, 0x41, 0xed, 0x91, 0x33, 0xee, 0x3f, 0xb5, 0x07, 0x56, 0xa4, 0xce, 0x88,
+	0x9f, 0xc7, 0x73, 0x41, 0x9d, 0x35, 0x8e, 0x67, 0xf2, 0x7d, 0xe8, 0x2e, 0x05, 0x2b, 0x27, 0x59,
+	0x4a, 0xdb, 0x23, 0x6b, 0xdc, 0xae, 0x3e, 0xd5, 0x41, 0xf0, 0x28, 0x25, 0xbb, 0xe0, 0x29, 0xb1,
+	0xc8, 0x52, 0xda, 0x19, 0x59, 0xe3, 0x7e, 0x25, 0x57, 0x4a, 0x27, 0x59, 0x4a, 0x1e, 0x00, 0x24,
+	0x25, 0x8b, 0x25, 0x4b, 0x27, 0xb1, 0xa4, 0x5d, 0xc3, 0x1a, 0xbf, 0xc2, 0x0f, 0x24, 0xf9, 0x01,
+	0xf8, 0xc9, 0x9c, 0x0b, 0xcd, 0xf1, 0x0c, 0x8e, 0xa7, 0xe1, 0x03, 0x49, 0x28, 0xb8, 0x7c, 0xc1,
+	0x0a, 0xea, 0x8f, 0xac, 0xb1, 0x57, 0x49, 0x15, 0x42, 0x1e, 0x40, 0x67, 0xca, 0x97, 0x45, 0x2a,
+	0x28, 0x8c, 0xac, 0x71, 0xb0, 0x1f, 0xec, 0x61, 0x14, 0x9f, 0x2a, 0x28, 0xaa, 0x44, 0x48, 0x4a,
+	0x54, 0x64, 0x68, 0x60, 0x90, 0x74, 0xb0, 0xa2, 0x4a, 0x44, 0x7e, 0x02, 0x7d, 0xfd, 0x4b, 0x4c,
+	0x12, 0xbe, 0x2c, 0x24, 0xed, 0x19, 0x1e, 0xf7, 0x2a, 0xd1, 0x21, 0x4a, 0xc8, 0x23, 0xd8, 0x49,
+	0x78, 0x9e, 0xb3, 0x42, 0xd6, 0xdc, 0xbe, 0xc1, 0xed, 0xd7, 0x32, 0x4d, 0xfe, 0x18, 0x20, 0xcd,
+	0x44, 0xb2, 0x14, 0x22, 0xe3, 0x05, 0xdd, 0x51, 0x06, 0x50, 0xc3, 0x00, 0xc1, 0xe4, 0xb3, 0x46,
+	0x1e, 0x19, 0x5c, 0x42, 0xa1, 0x2b, 0x64, 0x99, 0x15, 0x33, 0x41, 0xef, 0x8e, 0x9c, 0xb1, 0x1f,
+	0xd5, 0xc7, 0xf0, 0x53, 0xb8, 0x73, 0x85, 0x32, 0xf9, 0x39, 0x78, 0xf5, 0xdd, 0xd4, 0x1a, 0x39,
+	0xe3, 0x60, 0xff, 0xde, 0xe6, 0x45, 0x87, 0x5a, 0x1a, 0x35, 0xb4, 0xf0, 0x4b, 0x0b, 0x06, 0xdb,
+	0x62, 0x33, 0xed, 0x56, 0xe3, 0x58, 0xeb, 0xca, 0xb4, 0xdb, 0x4d, 0xda, 0x5b, 0xeb, 0xb4, 0x87,
+	0xe0, 0xcb, 0x2c, 0x67, 0x42, 0xc6, 0xf9, 0x82, 0x3a, 0x4d, 0x46, 0x5b, 0xd1, 0x1a, 0xc6, 0x94,
+	0x4a, 0x76, 0x21, 0xa9, 0x3b, 0xb2, 0xc6, 0x7e, 0x25, 0x56, 0x48, 0xf8, 0x85, 0x05, 0x1d, 0x9d,
+	0x40, 0x34, 0x24, 0xcf, 0x8a, 0xc9, 0x9c, 0x17, 0xd4, 0x1a, 0xd9, 0x63, 0x52, 0x1b, 0x92, 0x67,
+	0xc5, 0x73, 0x5e, 0x28, 0x71, 0x7c, 0xa1, 0xc4, 0xf6, 0x86, 0x38, 0xbe, 0xa8, 0xc5, 0xa8, 0x1d,
+	0x4b, 0xea, 0x6c, 0x6b, 0xc7, 0xb2, 0xd1, 0x8e, 0xd1, 0x88, 0x2d, 0xed, 0x58, 0x86, 0x7f, 0xb2,
+	0xa0, 0xa3, 0x23, 0x43, 0x46, 0xd0, 0xd1, 0xe5, 0xaa, 0xc2, 0x11, 0xec, 0x7b, 0x2a, 0xaa, 0xc7,
+	0x27, 0x2f, 0xa2, 0x0a, 0x47, 0x46, 0xce, 0xd3, 0xec, 0x74, 0x45, 0xed, 0x6d, 0x86, 0xc6, 0x91,
+	0x91, 0xb2, 0x39, 0x93, 0x8c, 0x3a, 0xdb, 0x0c, 0x8d, 0x93, 0x10, 0xba, 0x09, 0x2f, 0x9a, 0xa0,
+	0x98, 0x94, 0x5a, 0xf0, 0x2d, 0x25, 0xf1, 0x00, 0xdc, 0x57, 0xf1, 0x4c, 0x90, 0xef, 0x81, 0x8f,
+	0x2d, 0x3d, 0x51, 0xfd, 0x6c, 0x29, 0x8e, 0x87, 0xc0, 0x6f, 0xe3, 0xb9, 0x08, 0xbf, 0x70, 0xc1,
+	0x39, 0x3e, 0x79, 0x61, 0x74, 0x8d, 0x75, 0x7d, 0xd7, 0xec, 0x42, 0xbb, 0xe0, 0x29, 0xd3, 0xd3,
+	0x22, 0xd8, 0xf7, 0x15, 0xe7, 0x25, 0x4f, 0x59, 0xa4, 0x71, 0xf2, 0x33, 0x08, 0x52, 0x56, 0x08,
+	0x36, 0xd1, 0x34, 0xed, 0xd7, 0x2d, 0x45, 0x7b, 0x86, 0x38, 0x72, 0x45, 0x04, 0x69, 0xf3, 0x9b,
+	0x7c, 0x04, 0xee, 0xef, 0xe3, 0x95, 0xa0, 0xee, 0xc8, 0x69, 0xfc, 0x7b, 0x13, 0xaf, 0x22, 0x85,
+	0x92, 0x47, 0xe0, 0x97, 0x6c, 0x1e, 0xcb, 0x8c, 0x17, 0x82, 0xb6, 0x15, 0xa5, 0xaf, 0x28, 0x51,
+	0x85, 0x46, 0x6b, 0x39, 0xd9, 0x03, 0x48, 0xea, 0xba, 0x15, 0xb4, 0xa3, 0xd8, 0x3b, 0x9b, 0xd5,
+	0x1e, 0x19, 0x0c, 0xed, 0x8d, 0x64, 0x82, 0x76, 0x37, 0xbc, 0x91, 0xca, 0x1b, 0xc9, 0x14, 0x01,
+	0xeb, 0x57, 0x50, 0xcf, 0x20, 0xbc, 0x16, 0xac, 0x8c, 0x34, 0x4e, 0x86, 0xd0, 0x3d, 0x67, 0xa5,
+	0xea, 0x62, 0x9c, 0x43, 0x56, 0x3d, 0xec, 0x2a, 0x10, 0xab, 0x7e, 0xc6, 0x0a, 0x56, 0xc6, 0x92,
+	0x97, 0x14, 0x9a, 0xb2, 0xb6, 0xa2, 0x35, 0x8c, 0x9c, 0x84, 0x2f, 0x56, 0x65, 0x36, 0x3b, 0x93,
+	0x34, 0x30, 0x39, 0x0d, 0x4c, 0x7e, 0x0c, 0x41, 0x2c, 0x65, 0x99, 0x4d, 0x97, 0xe8, 0x29, 0xed,
+	0x19, 0x2c, 0x53, 0x80, 0xf6, 0xcc, 0xb3, 0x04, 0x83, 0x4b, 0xfb, 0x06, 0xa7, 0x06, 0xcd, 0x5a,
+	0xb9, 0xb5, 0x59, 0x2b, 0xff, 0xb6, 0xc1, 0x45, 0xd7, 0x9b, 0x57, 0xc2, 0x6e, 0x3a, 0x54, 0xbf,
+	0x12, 0x0e, 0x36, 0x85, 0xd9, 0x52, 0x08, 0x28, 0x9c, 0x17, 0x1b, 0xbd, 0x84, 0x00, 0xe2, 0xcb,
+	0x72, 0xbe, 0xd1, 0xc9, 0x08, 0x90, 0x1f, 0x41, 0x50, 0xcd, 0x99, 0x09, 0xca, 0xdb, 0x86, 0x1c,
+	0x2a, 0xc1, 0xeb, 0x72, 0xde, 0xcc, 0x7f, 0x45, 0xea, 0x18, 0x24, 0x3d, 0xff, 0x91, 0xf2, 0x00,
+	0xa0, 0x64, 0x38, 0xef, 0x15, 0xa7, 0x6b, 0x70, 0x7c, 0x8d, 0x23, 0xe9, 0x21, 0xf4, 0xd2, 0x58,
+	0xb2, 0x49, 0xf5, 0xb2, 0x18, 0x4f, 0x49, 0x2b, 0x0a, 0x50, 0x72, 0xa8, 0x05, 0x68, 0x97, 0x26,
+	0xaa, 0xe7, 0x85, 0xfa, 0x06, 0x0f, 0x14, 0x4f, 0xe1, 0x78, 0xa9, 0x90, 0xb1, 0x5c, 0x0a, 0x35,
+	0xe8, 0xc0, 0x18, 0x74, 0xbe, 0xc6, 0x71, 0xd4, 0xfd, 0xd4, 0x18, 0xb9, 0x81, 0x2a, 0x9c, 0x41,
+	0x53, 0x59, 0x97, 0xa7, 0xed, 0x3f, 0x2c, 0x08, 0x0c, 0x09, 0x0e, 0xc1, 0xb4, 0x1e, 0x2b, 0xb5,
+	0x09, 0x0a, 0x31, 0x47, 0xb0, 0x7d, 0xc3, 0x08, 0x76, 0xae, 0x1a, 0xc1, 0x35, 0x61, 0x3b, 0x31,
+	0x8a, 0x50, 0x85, 0x34, 0x4e, 0xb0, 0x8e, 0xd4, 0x37, 0xda, 0xa6, 0x77, 0x1a, 0xc7, 0xaf, 0xd4,
+	0x43, 0xba, 0xb3, 0x3d, 0xa4, 0x51, 0x72, 0x26, 0xf3, 0xcd, 0x5c, 0x28, 0x24, 0xfc, 0x73, 0x1b,
+	0x5c, 0x6c, 0x9b, 0x6b, 0x8a, 0x8b, 0x82, 0x5b, 0xc4, 0x39, 0xa3, 0xb6, 0xa9, 0x88, 0x08, 0xd6,
+	0x7d, 0xca, 0x44, 0x52, 0x66, 0x0b, 0x55, 0xf7, 0x8e, 0x41, 0x30, 0x05, 0xe8, 0x5a, 0x96, 0xcf,
+	0x26, 0x67, 0x25, 0x3b, 0xdd, 0x74, 0x2d, 0xcb, 0x67, 0x9f, 0x96, 0xec, 0x74, 0x6b, 0xeb, 0x68,
+	0x9b, 0xef, 0xcf, 0x7a, 0xeb, 0x78, 0x02, 0x83, 0xf5, 0x74, 0xa8, 0x5e, 0xf1, 0x8e, 0x11, 0xe9,
+	0x5b, 0x6b, 0xa9, 0x7e, 0xc7, 0x1f, 0x42, 0x4f, 0x96, 0x71, 0xd2, 0xac, 0x07, 0x5d, 0x83, 0x1c,
+	0x68, 0x89, 0x26, 0xee, 0x82, 0x77, 0xc6, 0x73, 0xa6, 0x1e, 0x16, 0xac, 0xc1, 0xba, 0x57, 0xba,
+	0x88, 0x3e, 0x8f, 0x0d, 0x42, 0x35, 0x49, 0x36, 0x09, 0xbc, 0xc0, 0x8e, 0x50, 0x84, 0x3f, 0x70,
+	0x9e, 0x53, 0x30, 0xee, 0x51, 0x7a, 0x9f, 0x71, 0x9e, 0x93, 0x11, 0xf8, 0xf3, 0xb8, 0x98, 0x2d,
+	0xe3, 0x19, 0xd3, 0x85, 0xa7, 0xd7, 0xb6, 0x35, 0x48, 0x3e, 0x86, 0x7b, 0xd3, 0x39, 0x4f, 0x3e,
+	0x17, 0x93, 0x92, 0x25, 0x2c, 0x3b, 0x67, 0xe9, 0xa5, 0xbd, 0xa6, 0x15, 0xdd, 0xd1, 0x94, 0xa8,
+	0x62, 0x68, 0x07, 0x7e, 0x05, 0xf7, 0xb7, 0x35, 0xb1, 0x24, 0xce, 0x99, 0xb1, 0xe6, 0xb4, 0xa2,
+	0xbb, 0x9b, 0xaa, 0x07, 0x8a, 0x41, 0x7e, 0x0d, 0xdf, 0xc9, 0x99, 0x10, 0x68, 0xc1, 0xf6, 0xbd,
+	0x3b, 0x86, 0xf2, 0xbd, 0x9a, 0xb4, 0x79, 0xf3, 0x6f, 0x80, 0x5e, 0xd6, 0x5e, 0x16, 0x25, 0x8b,
+	0x53, 0x7a, 0xcb, 0x50, 0xbf, 0xbf, 0xad, 0xfe, 0x5a, 0x71, 0xc8, 0x2f, 0xe0, 0x4e, 0xa3, 0x2f,
+	0x70, 0xee, 0xe8, 0x9b, 0x07, 0x86, 0xea, 0xed, 0x9a, 0x70, 0xc2, 0x0a, 0xa9, 0x6e, 0x0d, 0xff,
+	0x68, 0xe1, 0x38, 0x4c, 0xaf, 0x1f, 0x87, 0xef, 0xbb, 0x34, 0xbb, 0x59, 0x71, 0xca, 0xab, 0x47,
+	0x5c, 0xbf, 0x23, 0x47, 0xc5, 0x29, 0x8f, 0x14, 0x5c, 0x4f, 0x57, 0xef, 0x9a, 0xe9, 0xea, 0x6f,
+	0x4d, 0xd7, 0xf0, 0x3f, 0x16, 0xb8, 0xa8, 0x6e, 0xbe, 0x3f, 0xe6, 0x56, 0x66, 0xbe, 0x3f, 0xeb,
+	0xad, 0xcb, 0xbe, 0x7a, 0xeb, 0x7a, 0x08, 0xbd, 0xa6, 0xae, 0x27, 0xd5, 0xec, 0x68, 0x66, 0x64,
+	0x23, 0x39, 0x4a, 0xcd, 0xf9, 0xe3, 0xde, 0x30, 0x7f, 0xda, 0x57, 0xcd, 0x1f, 0x34, 0x36, 0x13,
+	0xd9, 0x74, 0xce, 0x68, 0xc7, 0x58, 0xda, 0x6b, 0x50, 0x3f, 0x84, 0x79, 0x9e, 0x49, 0x9c, 0xd4,
+	0x9b, 0x7f, 0x0c, 0x6a, 0x38, 0xfc, 0x8b, 0x05, 0xb0, 0x5e, 0x24, 0xc8, 0x5d, 0x70, 0xb2, 0x54,
+	0xef, 0x34, 0x44, 0x85, 0x1b, 0x8f, 0xe4, 0x31, 0xe8, 0x05, 0x63, 0xa2, 0x62, 0xde, 0x1e, 0x59,
+	0xcd, 0x1e, 0xa0, 0x54, 0x55, 0xe0, 0xfd, 0xb4, 0xfe, 0x89, 0x49, 0x9b, 0xc7, 0x52, 0x3f, 0xf2,
+	0xfa, 0x2b, 0xea, 0xac, 0x70, 0x5c, 0x3b, 0x7c, 0x03, 0xc7, 0x35, 0x63, 0xd7, 0x5c, 0xa7, 0xa0,
+	0xc9, 0x74, 0xb3, 0x52, 0x7d, 0xcb, 0x2b, 0xfb, 0x5f, 0x0b, 0xfc, 0xc6, 0x06, 0x32, 0x04, 0xaf,
+	0x4a, 0x94, 0x76, 0xa1, 0xad, 0xbf, 0x53, 0x63, 0x24, 0x04, 0x68, 0xd2, 0xa4, 0x6b, 0x4d, 0x9b,
+	0x61, 0xa0, 0xe4, 0x21, 0xf4, 0xcd, 0xec, 0xe9, 0xd2, 0xd3, 0xb4, 0x9e, 0x91, 0x3c, 0x2c, 0x41,
+	0xaf, 0xca, 0x9e, 0xde, 0xb5, 0x6e, 0x2b, 0x4e, 0x57, 0x27, 0x4f, 0x39, 0x55, 0x67, 0x4f, 0x2f,
+	0x5a, 0x5a, 0xee, 0x55, 0xc9, 0x13, 0xca, 0x58, 0x9d, 0x28, 0xbd, 0x5a, 0x79, 0x95, 0xb1, 0x15,
+	0x86, 0xc6, 0x36, 0x69, 0xd2, 0x1b, 0x55, 0x65, 0xec, 0x1a, 0x0d, 0xff, 0x69, 0x81, 0xf3, 0x26,
+	0x5e, 0x7d, 0xa8, 0xa6, 0x72, 0x4b, 0x76, 0xba, 0x91, 0x56, 0x3c, 0x93, 0x5f, 0x42, 0x5f, 0x57,
+	0x47, 0xce, 0xf2, 0x29, 0x2e, 0x77, 0xbe, 0xd2, 0xbf, 0xbd, 0x2e, 0x90, 0x17, 0x5a, 0x10, 0xf5,
+	0x52, 0xe3, 0x44, 0x1e, 0x41, 0x77, 0xb9, 0xc0, 0x87, 0xb8, 0xfe, 0x5f, 0x79, 0x85, 0x46, 0xcd,
+	0x08, 0xbf, 0xb1, 0xc1, 0xab, 0x57, 0xd4, 0x0f, 0xe3, 0x2e, 0x85, 0x76, 0xc9, 0xe7, 0x4c, 0xfb,
+	0xab, 0xf5, 0x34, 0xd0, 0x04, 0xc2, 0xdf, 0x0a, 0xc4, 0x3e, 0xb4, 0xe5, 0x6a, 0xc1, 0x74, 0x0d,
+	0xef, 0x54, 0x7f, 0x40, 0x6b, 0xa3, 0xf7, 0xb4, 0x4b, 0xaf, 0x56, 0x0b, 0xa6, 0xbf, 0xa5, 0xa8,
+	0x97, 0x83, 0x17, 0xbc, 0x77, 0xf0, 0x7a, 0x37, 0x06, 0xef, 0x31, 0xc0, 0xfa, 0x76, 0xe2, 0x81,
+	0xfb, 0xf2, 0xf8, 0xd9, 0x27, 0x83, 0x16, 0xe9, 0x82, 0xf3, 0xe6, 0xe0, 0x77, 0x03, 0x8b, 0xf4,
+	0xc0, 0x8b, 0x3e, 0x79, 0x7e, 0xf0, 0xea, 0xe8, 0xf8, 0xe5, 0xc0, 0x0e, 0xff, 0x65, 0x41, 0xcf,
+	0xfc, 0x10, 0xf9, 0x08, 0xba, 0x59, 0x91, 0xb2, 0x0b, 0xa6, 0xdb, 0xaa, 0xaa, 0xf4, 0x0a, 0xda,
+	0xe8, 0x3a, 0xfb, 0xc6, 0xae, 0x73, 0xfe, 0xbf, 0xae, 0x73, 0xaf, 0xe9, 0xba, 0x1f, 0x42, 0xc0,
+	0xcb, 0x8c, 0x15, 0x52, 0x05, 0xd5, 0x68, 0x2c, 0x13, 0x7e, 0xdf, 0x09, 0xf4, 0x74, 0xf7, 0xab,
+	0xb7, 0x43, 0xeb, 0xeb, 0xb7, 0x43, 0xeb, 0xef, 0x6f, 0x87, 0xd6, 0x97, 0xef, 0x86, 0xad, 0xaf,
+	0xdf, 0x0d, 0x5b, 0x7f, 0x7d, 0x37, 0x6c, 0x7d, 0xd6, 0xe6, 0x22, 0x5f, 0x4c, 0xff, 0x37, 0x00,
+	0x11, 0xb2, 0xfc, 0x6b, 0x38, 0x12, 0x00, 0x00,
+}
+
+func (m *Changeset) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Changeset) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Change) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Changeset) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1438,9 +1901,9 @@ func (m *Change) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			dAtA[i] = 0xa2
 		}
 	}
-	if m.Context != nil {
+	if m.Discussion != nil {
 		{
-			size, err := m.Context.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Discussion.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1448,11 +1911,21 @@ func (m *Change) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x72
 	}
-	if m.Delete != nil {
+	if m.CommentsCount != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.CommentsCount))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.ChangesCount != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.ChangesCount))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Change != nil {
 		{
-			size, err := m.Delete.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Change.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1460,11 +1933,11 @@ func (m *Change) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x5a
 	}
-	if m.Modify != nil {
+	if m.Bounds != nil {
 		{
-			size, err := m.Modify.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Bounds.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1472,24 +1945,83 @@ func (m *Change) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x52
 	}
-	if m.Create != nil {
-		{
-			size, err := m.Create.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if m.Open != nil {
+		i--
+		if *m.Open {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.ClosedAt != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.ClosedAt))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.CreatedAt != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.CreatedAt))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.UserSid != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.UserSid))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.UserId != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.UserId))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.Vals) > 0 {
+		dAtA5 := make([]byte, len(m.Vals)*10)
+		var j4 int
+		for _, num := range m.Vals {
+			for num >= 1<<7 {
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j4++
 			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
+			dAtA5[j4] = uint8(num)
+			j4++
 		}
+		i -= j4
+		copy(dAtA[i:], dAtA5[:j4])
+		i = encodeVarintOsm(dAtA, i, uint64(j4))
 		i--
-		dAtA[i] = 0xa
+		dAtA[i] = 0x1a
+	}
+	if len(m.Keys) > 0 {
+		dAtA7 := make([]byte, len(m.Keys)*10)
+		var j6 int
+		for _, num := range m.Keys {
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		i -= j6
+		copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintOsm(dAtA, i, uint64(j6))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Id != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.Id))
+		i--
+		dAtA[i] = 0x8
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Tags) Marshal() (dAtA []byte, err error) {
+func (m *ChangesetDiscussion) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1499,21 +2031,26 @@ func (m *Tags) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Tags) MarshalTo(dAtA []byte) (int, error) {
+func (m *ChangesetDiscussion) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Tags) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ChangesetDiscussion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.KeysVals) > 0 {
-		for iNdEx := len(m.KeysVals) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.KeysVals[iNdEx])
-			copy(dAtA[i:], m.KeysVals[iNdEx])
-			i = encodeVarintOsm(dAtA, i, uint64(len(m.KeysVals[iNdEx])))
+	if len(m.Comments) > 0 {
+		for iNdEx := len(m.Comments) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Comments[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
+			}
 			i--
 			dAtA[i] = 0xa
 		}
@@ -1521,7 +2058,7 @@ func (m *Tags) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *OSM) Marshal() (dAtA []byte, err error) {
+func (m *ChangesetComment) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1531,175 +2068,34 @@ func (m *OSM) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *OSM) MarshalTo(dAtA []byte) (int, error) {
+func (m *ChangesetComment) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *OSM) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *ChangesetComment) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Strings) > 0 {
-		for iNdEx := len(m.Strings) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Strings[iNdEx])
-			copy(dAtA[i:], m.Strings[iNdEx])
-			i = encodeVarintOsm(dAtA, i, uint64(len(m.Strings[iNdEx])))
-			i--
-			dAtA[i] = 0x7a
-		}
-	}
-	if len(m.Relations) > 0 {
-		for iNdEx := len(m.Relations) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Relations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintOsm(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x2a
-		}
-	}
-	if len(m.Ways) > 0 {
-		for iNdEx := len(m.Ways) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Ways[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintOsm(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if m.DenseNodes != nil {
-		{
-			size, err := m.DenseNodes.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Nodes) > 0 {
-		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintOsm(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
-	}
-	if m.Bounds != nil {
-		{
-			size, err := m.Bounds.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
-}
-
-func (m *Node) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
-	}
-	return dAtA[:n], nil
-}
-
-func (m *Node) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.Lon)<<1)^uint64((m.Lon>>63))))
+	i -= len(m.Text)
+	copy(dAtA[i:], m.Text)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.Text)))
 	i--
-	dAtA[i] = 0x48
-	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.Lat)<<1)^uint64((m.Lat>>63))))
+	dAtA[i] = 0x22
+	i = encodeVarintOsm(dAtA, i, uint64(m.Timestamp))
 	i--
-	dAtA[i] = 0x40
-	if m.Info != nil {
-		{
-			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.Vals) > 0 {
-		dAtA15 := make([]byte, len(m.Vals)*10)
-		var j14 int
-		for _, num := range m.Vals {
-			for num >= 1<<7 {
-				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j14++
-			}
-			dAtA15[j14] = uint8(num)
-			j14++
-		}
-		i -= j14
-		copy(dAtA[i:], dAtA15[:j14])
-		i = encodeVarintOsm(dAtA, i, uint64(j14))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Keys) > 0 {
-		dAtA17 := make([]byte, len(m.Keys)*10)
-		var j16 int
-		for _, num := range m.Keys {
-			for num >= 1<<7 {
-				dAtA17[j16] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j16++
-			}
-			dAtA17[j16] = uint8(num)
-			j16++
-		}
-		i -= j16
-		copy(dAtA[i:], dAtA17[:j16])
-		i = encodeVarintOsm(dAtA, i, uint64(j16))
-		i--
-		dAtA[i] = 0x12
-	}
-	i = encodeVarintOsm(dAtA, i, uint64(m.Id))
+	dAtA[i] = 0x18
+	i = encodeVarintOsm(dAtA, i, uint64(m.UserSid))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintOsm(dAtA, i, uint64(m.UserId))
 	i--
 	dAtA[i] = 0x8
 	return len(dAtA) - i, nil
 }
 
-func (m *Info) Marshal() (dAtA []byte, err error) {
+func (m *Bounds) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1709,50 +2105,32 @@ func (m *Info) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Info) MarshalTo(dAtA []byte) (int, error) {
+func (m *Bounds) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Info) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Bounds) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Committed != nil {
-		i = encodeVarintOsm(dAtA, i, uint64(*m.Committed))
-		i--
-		dAtA[i] = 0x38
-	}
-	if m.Visible != nil {
-		i--
-		if *m.Visible {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
-		i--
-		dAtA[i] = 0x30
-	}
-	i = encodeVarintOsm(dAtA, i, uint64(m.UserSid))
-	i--
-	dAtA[i] = 0x28
-	i = encodeVarintOsm(dAtA, i, uint64(m.UserId))
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MaxLat)<<1)^uint64((m.MaxLat>>63))))
 	i--
 	dAtA[i] = 0x20
-	i = encodeVarintOsm(dAtA, i, uint64(m.ChangesetId))
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MinLat)<<1)^uint64((m.MinLat>>63))))
 	i--
 	dAtA[i] = 0x18
-	i = encodeVarintOsm(dAtA, i, uint64(m.Timestamp))
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MaxLon)<<1)^uint64((m.MaxLon>>63))))
 	i--
 	dAtA[i] = 0x10
-	i = encodeVarintOsm(dAtA, i, uint64(m.Version))
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.MinLon)<<1)^uint64((m.MinLon>>63))))
 	i--
 	dAtA[i] = 0x8
 	return len(dAtA) - i, nil
 }
 
-func (m *DenseNodes) Marshal() (dAtA []byte, err error) {
+func (m *Change) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1762,12 +2140,12 @@ func (m *DenseNodes) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DenseNodes) MarshalTo(dAtA []byte) (int, error) {
+func (m *Change) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DenseNodes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Change) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -1778,68 +2156,38 @@ func (m *DenseNodes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			copy(dAtA[i:], m.Strings[iNdEx])
 			i = encodeVarintOsm(dAtA, i, uint64(len(m.Strings[iNdEx])))
 			i--
-			dAtA[i] = 0x7a
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0xa2
 		}
 	}
-	if len(m.KeysVals) > 0 {
-		dAtA19 := make([]byte, len(m.KeysVals)*10)
-		var j18 int
-		for _, num := range m.KeysVals {
-			for num >= 1<<7 {
-				dAtA19[j18] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j18++
+	if m.Context != nil {
+		{
+			size, err := m.Context.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			dAtA19[j18] = uint8(num)
-			j18++
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
-		i -= j18
-		copy(dAtA[i:], dAtA19[:j18])
-		i = encodeVarintOsm(dAtA, i, uint64(j18))
-		i--
-		dAtA[i] = 0x52
-	}
-	if len(m.Lons) > 0 {
-		var j20 int
-		dAtA22 := make([]byte, len(m.Lons)*10)
-		for _, num := range m.Lons {
-			x21 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x21 >= 1<<7 {
-				dAtA22[j20] = uint8(uint64(x21)&0x7f | 0x80)
-				j20++
-				x21 >>= 7
-			}
-			dAtA22[j20] = uint8(x21)
-			j20++
-		}
-		i -= j20
-		copy(dAtA[i:], dAtA22[:j20])
-		i = encodeVarintOsm(dAtA, i, uint64(j20))
 		i--
-		dAtA[i] = 0x4a
+		dAtA[i] = 0x22
 	}
-	if len(m.Lats) > 0 {
-		var j23 int
-		dAtA25 := make([]byte, len(m.Lats)*10)
-		for _, num := range m.Lats {
-			x24 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x24 >= 1<<7 {
-				dAtA25[j23] = uint8(uint64(x24)&0x7f | 0x80)
-				j23++
-				x24 >>= 7
+	if m.Delete != nil {
+		{
+			size, err := m.Delete.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			dAtA25[j23] = uint8(x24)
-			j23++
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
-		i -= j23
-		copy(dAtA[i:], dAtA25[:j23])
-		i = encodeVarintOsm(dAtA, i, uint64(j23))
 		i--
-		dAtA[i] = 0x42
+		dAtA[i] = 0x1a
 	}
-	if m.DenseInfo != nil {
+	if m.Modify != nil {
 		{
-			size, err := m.DenseInfo.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.Modify.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -1847,31 +2195,24 @@ func (m *DenseNodes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x2a
+		dAtA[i] = 0x12
 	}
-	if len(m.Ids) > 0 {
-		var j27 int
-		dAtA29 := make([]byte, len(m.Ids)*10)
-		for _, num := range m.Ids {
-			x28 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x28 >= 1<<7 {
-				dAtA29[j27] = uint8(uint64(x28)&0x7f | 0x80)
-				j27++
-				x28 >>= 7
-			}
-			dAtA29[j27] = uint8(x28)
-			j27++
-		}
-		i -= j27
-		copy(dAtA[i:], dAtA29[:j27])
-		i = encodeVarintOsm(dAtA, i, uint64(j27))
+	if m.Create != nil {
+		{
+			size, err := m.Create.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
+		}
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *DenseInfo) Marshal() (dAtA []byte, err error) {
+func (m *Tags) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -1881,147 +2222,29 @@ func (m *DenseInfo) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DenseInfo) MarshalTo(dAtA []byte) (int, error) {
+func (m *Tags) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DenseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Tags) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Committeds) > 0 {
-		var j30 int
-		dAtA32 := make([]byte, len(m.Committeds)*10)
-		for _, num := range m.Committeds {
-			x31 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x31 >= 1<<7 {
-				dAtA32[j30] = uint8(uint64(x31)&0x7f | 0x80)
-				j30++
-				x31 >>= 7
-			}
-			dAtA32[j30] = uint8(x31)
-			j30++
-		}
-		i -= j30
-		copy(dAtA[i:], dAtA32[:j30])
-		i = encodeVarintOsm(dAtA, i, uint64(j30))
-		i--
-		dAtA[i] = 0x3a
-	}
-	if len(m.Visibles) > 0 {
-		for iNdEx := len(m.Visibles) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.KeysVals) > 0 {
+		for iNdEx := len(m.KeysVals) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.KeysVals[iNdEx])
+			copy(dAtA[i:], m.KeysVals[iNdEx])
+			i = encodeVarintOsm(dAtA, i, uint64(len(m.KeysVals[iNdEx])))
 			i--
-			if m.Visibles[iNdEx] {
-				dAtA[i] = 1
-			} else {
-				dAtA[i] = 0
-			}
-		}
-		i = encodeVarintOsm(dAtA, i, uint64(len(m.Visibles)))
-		i--
-		dAtA[i] = 0x32
-	}
-	if len(m.UserSids) > 0 {
-		dAtA33 := make([]byte, len(m.UserSids)*5)
-		var j34 int
-		for _, num := range m.UserSids {
-			x35 := (uint32(num) << 1) ^ uint32((num >> 31))
-			for x35 >= 1<<7 {
-				dAtA33[j34] = uint8(uint64(x35)&0x7f | 0x80)
-				j34++
-				x35 >>= 7
-			}
-			dAtA33[j34] = uint8(x35)
-			j34++
-		}
-		i -= j34
-		copy(dAtA[i:], dAtA33[:j34])
-		i = encodeVarintOsm(dAtA, i, uint64(j34))
-		i--
-		dAtA[i] = 0x2a
-	}
-	if len(m.UserIds) > 0 {
-		dAtA36 := make([]byte, len(m.UserIds)*5)
-		var j37 int
-		for _, num := range m.UserIds {
-			x38 := (uint32(num) << 1) ^ uint32((num >> 31))
-			for x38 >= 1<<7 {
-				dAtA36[j37] = uint8(uint64(x38)&0x7f | 0x80)
-				j37++
-				x38 >>= 7
-			}
-			dAtA36[j37] = uint8(x38)
-			j37++
-		}
-		i -= j37
-		copy(dAtA[i:], dAtA36[:j37])
-		i = encodeVarintOsm(dAtA, i, uint64(j37))
-		i--
-		dAtA[i] = 0x22
-	}
-	if len(m.ChangesetIds) > 0 {
-		var j39 int
-		dAtA41 := make([]byte, len(m.ChangesetIds)*10)
-		for _, num := range m.ChangesetIds {
-			x40 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x40 >= 1<<7 {
-				dAtA41[j39] = uint8(uint64(x40)&0x7f | 0x80)
-				j39++
-				x40 >>= 7
-			}
-			dAtA41[j39] = uint8(x40)
-			j39++
-		}
-		i -= j39
-		copy(dAtA[i:], dAtA41[:j39])
-		i = encodeVarintOsm(dAtA, i, uint64(j39))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Timestamps) > 0 {
-		var j42 int
-		dAtA44 := make([]byte, len(m.Timestamps)*10)
-		for _, num := range m.Timestamps {
-			x43 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x43 >= 1<<7 {
-				dAtA44[j42] = uint8(uint64(x43)&0x7f | 0x80)
-				j42++
-				x43 >>= 7
-			}
-			dAtA44[j42] = uint8(x43)
-			j42++
-		}
-		i -= j42
-		copy(dAtA[i:], dAtA44[:j42])
-		i = encodeVarintOsm(dAtA, i, uint64(j42))
-		i--
-		dAtA[i] = 0x12
-	}
-	if len(m.Versions) > 0 {
-		dAtA46 := make([]byte, len(m.Versions)*10)
-		var j45 int
-		for _, num1 := range m.Versions {
-			num := uint64(num1)
-			for num >= 1<<7 {
-				dAtA46[j45] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j45++
-			}
-			dAtA46[j45] = uint8(num)
-			j45++
+			dAtA[i] = 0xa
 		}
-		i -= j45
-		copy(dAtA[i:], dAtA46[:j45])
-		i = encodeVarintOsm(dAtA, i, uint64(j45))
-		i--
-		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *Way) Marshal() (dAtA []byte, err error) {
+func (m *OSM) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2031,62 +2254,132 @@ func (m *Way) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Way) MarshalTo(dAtA []byte) (int, error) {
+func (m *OSM) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Way) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *OSM) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Updates != nil {
-		{
-			size, err := m.Updates.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
+	if len(m.Strings) > 0 {
+		for iNdEx := len(m.Strings) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Strings[iNdEx])
+			copy(dAtA[i:], m.Strings[iNdEx])
+			i = encodeVarintOsm(dAtA, i, uint64(len(m.Strings[iNdEx])))
+			i--
+			dAtA[i] = 0x7a
 		}
+	}
+	if m.License != nil {
+		i -= len(*m.License)
+		copy(dAtA[i:], *m.License)
+		i = encodeVarintOsm(dAtA, i, uint64(len(*m.License)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Attribution != nil {
+		i -= len(*m.Attribution)
+		copy(dAtA[i:], *m.Attribution)
+		i = encodeVarintOsm(dAtA, i, uint64(len(*m.Attribution)))
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.Copyright != nil {
+		i -= len(*m.Copyright)
+		copy(dAtA[i:], *m.Copyright)
+		i = encodeVarintOsm(dAtA, i, uint64(len(*m.Copyright)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if m.Generator != nil {
+		i -= len(*m.Generator)
+		copy(dAtA[i:], *m.Generator)
+		i = encodeVarintOsm(dAtA, i, uint64(len(*m.Generator)))
 		i--
 		dAtA[i] = 0x52
 	}
-	if m.DenseMembers != nil {
-		{
-			size, err := m.DenseMembers.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if m.Version != nil {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(*m.Version))))
+		i--
+		dAtA[i] = 0x49
+	}
+	if len(m.Users) > 0 {
+		for iNdEx := len(m.Users) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Users[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0x42
 		}
-		i--
-		dAtA[i] = 0x4a
 	}
-	if len(m.Refs) > 0 {
-		var j49 int
-		dAtA51 := make([]byte, len(m.Refs)*10)
-		for _, num := range m.Refs {
-			x50 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x50 >= 1<<7 {
-				dAtA51[j49] = uint8(uint64(x50)&0x7f | 0x80)
-				j49++
-				x50 >>= 7
-			}
-			dAtA51[j49] = uint8(x50)
-			j49++
-		}
-		i -= j49
-		copy(dAtA[i:], dAtA51[:j49])
-		i = encodeVarintOsm(dAtA, i, uint64(j49))
-		i--
-		dAtA[i] = 0x42
+	if len(m.Notes) > 0 {
+		for iNdEx := len(m.Notes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Notes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
 	}
-	if m.Info != nil {
+	if len(m.Changesets) > 0 {
+		for iNdEx := len(m.Changesets) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Changesets[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Relations) > 0 {
+		for iNdEx := len(m.Relations) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Relations[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Ways) > 0 {
+		for iNdEx := len(m.Ways) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ways[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if m.DenseNodes != nil {
 		{
-			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			size, err := m.DenseNodes.MarshalToSizedBuffer(dAtA[:i])
 			if err != nil {
 				return 0, err
 			}
@@ -2094,51 +2387,38 @@ func (m *Way) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x1a
 	}
-	if len(m.Vals) > 0 {
-		dAtA54 := make([]byte, len(m.Vals)*10)
-		var j53 int
-		for _, num := range m.Vals {
-			for num >= 1<<7 {
-				dAtA54[j53] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j53++
+	if len(m.Nodes) > 0 {
+		for iNdEx := len(m.Nodes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Nodes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
 			}
-			dAtA54[j53] = uint8(num)
-			j53++
+			i--
+			dAtA[i] = 0x12
 		}
-		i -= j53
-		copy(dAtA[i:], dAtA54[:j53])
-		i = encodeVarintOsm(dAtA, i, uint64(j53))
-		i--
-		dAtA[i] = 0x1a
 	}
-	if len(m.Keys) > 0 {
-		dAtA56 := make([]byte, len(m.Keys)*10)
-		var j55 int
-		for _, num := range m.Keys {
-			for num >= 1<<7 {
-				dAtA56[j55] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j55++
+	if m.Bounds != nil {
+		{
+			size, err := m.Bounds.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			dAtA56[j55] = uint8(num)
-			j55++
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
-		i -= j55
-		copy(dAtA[i:], dAtA56[:j55])
-		i = encodeVarintOsm(dAtA, i, uint64(j55))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0xa
 	}
-	i = encodeVarintOsm(dAtA, i, uint64(m.Id))
-	i--
-	dAtA[i] = 0x8
 	return len(dAtA) - i, nil
 }
 
-func (m *Relation) Marshal() (dAtA []byte, err error) {
+func (m *Note) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2148,95 +2428,241 @@ func (m *Relation) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *Relation) MarshalTo(dAtA []byte) (int, error) {
+func (m *Note) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Relation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Note) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.Updates != nil {
-		{
-			size, err := m.Updates.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
+	if len(m.Comments) > 0 {
+		for iNdEx := len(m.Comments) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Comments[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintOsm(dAtA, i, uint64(size))
 			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
+			i--
+			dAtA[i] = 0x5a
 		}
-		i--
-		dAtA[i] = 0x62
 	}
-	if m.DenseMembers != nil {
-		{
-			size, err := m.DenseMembers.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintOsm(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x5a
-	}
-	if len(m.Types) > 0 {
-		dAtA60 := make([]byte, len(m.Types)*10)
-		var j59 int
-		for _, num := range m.Types {
-			for num >= 1<<7 {
-				dAtA60[j59] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j59++
-			}
-			dAtA60[j59] = uint8(num)
-			j59++
-		}
-		i -= j59
-		copy(dAtA[i:], dAtA60[:j59])
-		i = encodeVarintOsm(dAtA, i, uint64(j59))
-		i--
-		dAtA[i] = 0x52
+	i = encodeVarintOsm(dAtA, i, uint64(m.StatusSid))
+	i--
+	dAtA[i] = 0x50
+	i = encodeVarintOsm(dAtA, i, uint64(m.DateClosed))
+	i--
+	dAtA[i] = 0x48
+	i = encodeVarintOsm(dAtA, i, uint64(m.DateCreated))
+	i--
+	dAtA[i] = 0x40
+	i -= len(m.ReopenUrl)
+	copy(dAtA[i:], m.ReopenUrl)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.ReopenUrl)))
+	i--
+	dAtA[i] = 0x3a
+	i -= len(m.CloseUrl)
+	copy(dAtA[i:], m.CloseUrl)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.CloseUrl)))
+	i--
+	dAtA[i] = 0x32
+	i -= len(m.CommentUrl)
+	copy(dAtA[i:], m.CommentUrl)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.CommentUrl)))
+	i--
+	dAtA[i] = 0x2a
+	i -= len(m.Url)
+	copy(dAtA[i:], m.Url)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.Url)))
+	i--
+	dAtA[i] = 0x22
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.Lon)<<1)^uint64((m.Lon>>63))))
+	i--
+	dAtA[i] = 0x18
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.Lat)<<1)^uint64((m.Lat>>63))))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintOsm(dAtA, i, uint64(m.Id))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
+func (m *NoteComment) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Refs) > 0 {
-		var j61 int
-		dAtA63 := make([]byte, len(m.Refs)*10)
-		for _, num := range m.Refs {
-			x62 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x62 >= 1<<7 {
-				dAtA63[j61] = uint8(uint64(x62)&0x7f | 0x80)
-				j61++
-				x62 >>= 7
-			}
-			dAtA63[j61] = uint8(x62)
-			j61++
-		}
-		i -= j61
-		copy(dAtA[i:], dAtA63[:j61])
-		i = encodeVarintOsm(dAtA, i, uint64(j61))
-		i--
-		dAtA[i] = 0x4a
+	return dAtA[:n], nil
+}
+
+func (m *NoteComment) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NoteComment) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i -= len(m.Html)
+	copy(dAtA[i:], m.Html)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.Html)))
+	i--
+	dAtA[i] = 0x3a
+	i -= len(m.Text)
+	copy(dAtA[i:], m.Text)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.Text)))
+	i--
+	dAtA[i] = 0x32
+	i = encodeVarintOsm(dAtA, i, uint64(m.ActionSid))
+	i--
+	dAtA[i] = 0x28
+	i -= len(m.UserUrl)
+	copy(dAtA[i:], m.UserUrl)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.UserUrl)))
+	i--
+	dAtA[i] = 0x22
+	i = encodeVarintOsm(dAtA, i, uint64(m.UserSid))
+	i--
+	dAtA[i] = 0x18
+	i = encodeVarintOsm(dAtA, i, uint64(m.UserId))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintOsm(dAtA, i, uint64(m.Date))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
+func (m *User) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if len(m.Roles) > 0 {
-		dAtA65 := make([]byte, len(m.Roles)*10)
-		var j64 int
-		for _, num := range m.Roles {
+	return dAtA[:n], nil
+}
+
+func (m *User) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *User) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintOsm(dAtA, i, uint64(m.MessagesSentCount))
+	i--
+	dAtA[i] = 0x1
+	i--
+	dAtA[i] = 0x80
+	i = encodeVarintOsm(dAtA, i, uint64(m.MessagesReceivedUnread))
+	i--
+	dAtA[i] = 0x78
+	i = encodeVarintOsm(dAtA, i, uint64(m.MessagesReceivedCount))
+	i--
+	dAtA[i] = 0x70
+	i = encodeVarintOsm(dAtA, i, uint64(m.BlocksReceivedActive))
+	i--
+	dAtA[i] = 0x68
+	i = encodeVarintOsm(dAtA, i, uint64(m.BlocksReceivedCount))
+	i--
+	dAtA[i] = 0x60
+	if len(m.Languages) > 0 {
+		dAtA15 := make([]byte, len(m.Languages)*10)
+		var j14 int
+		for _, num := range m.Languages {
 			for num >= 1<<7 {
-				dAtA65[j64] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j64++
+				j14++
 			}
-			dAtA65[j64] = uint8(num)
-			j64++
+			dAtA15[j14] = uint8(num)
+			j14++
 		}
-		i -= j64
-		copy(dAtA[i:], dAtA65[:j64])
-		i = encodeVarintOsm(dAtA, i, uint64(j64))
+		i -= j14
+		copy(dAtA[i:], dAtA15[:j14])
+		i = encodeVarintOsm(dAtA, i, uint64(j14))
 		i--
-		dAtA[i] = 0x42
+		dAtA[i] = 0x5a
+	}
+	i = encodeVarintOsm(dAtA, i, uint64(m.HomeZoom))
+	i--
+	dAtA[i] = 0x50
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.HomeLon)<<1)^uint64((m.HomeLon>>63))))
+	i--
+	dAtA[i] = 0x48
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.HomeLat)<<1)^uint64((m.HomeLat>>63))))
+	i--
+	dAtA[i] = 0x40
+	i = encodeVarintOsm(dAtA, i, uint64(m.TracesCount))
+	i--
+	dAtA[i] = 0x38
+	i = encodeVarintOsm(dAtA, i, uint64(m.ChangesetsCount))
+	i--
+	dAtA[i] = 0x30
+	i = encodeVarintOsm(dAtA, i, uint64(m.CreatedAt))
+	i--
+	dAtA[i] = 0x28
+	i -= len(m.ImgHref)
+	copy(dAtA[i:], m.ImgHref)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.ImgHref)))
+	i--
+	dAtA[i] = 0x22
+	i -= len(m.Description)
+	copy(dAtA[i:], m.Description)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.Description)))
+	i--
+	dAtA[i] = 0x1a
+	i -= len(m.Name)
+	copy(dAtA[i:], m.Name)
+	i = encodeVarintOsm(dAtA, i, uint64(len(m.Name)))
+	i--
+	dAtA[i] = 0x12
+	i = encodeVarintOsm(dAtA, i, uint64(m.Id))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
+func (m *Node) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Node) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Node) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.Lon)<<1)^uint64((m.Lon>>63))))
+	i--
+	dAtA[i] = 0x48
+	i = encodeVarintOsm(dAtA, i, uint64((uint64(m.Lat)<<1)^uint64((m.Lat>>63))))
+	i--
+	dAtA[i] = 0x40
 	if m.Info != nil {
 		{
 			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
@@ -2250,38 +2676,38 @@ func (m *Relation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		dAtA[i] = 0x22
 	}
 	if len(m.Vals) > 0 {
-		dAtA68 := make([]byte, len(m.Vals)*10)
-		var j67 int
+		dAtA18 := make([]byte, len(m.Vals)*10)
+		var j17 int
 		for _, num := range m.Vals {
 			for num >= 1<<7 {
-				dAtA68[j67] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA18[j17] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j67++
+				j17++
 			}
-			dAtA68[j67] = uint8(num)
-			j67++
+			dAtA18[j17] = uint8(num)
+			j17++
 		}
-		i -= j67
-		copy(dAtA[i:], dAtA68[:j67])
-		i = encodeVarintOsm(dAtA, i, uint64(j67))
+		i -= j17
+		copy(dAtA[i:], dAtA18[:j17])
+		i = encodeVarintOsm(dAtA, i, uint64(j17))
 		i--
 		dAtA[i] = 0x1a
 	}
 	if len(m.Keys) > 0 {
-		dAtA70 := make([]byte, len(m.Keys)*10)
-		var j69 int
+		dAtA20 := make([]byte, len(m.Keys)*10)
+		var j19 int
 		for _, num := range m.Keys {
 			for num >= 1<<7 {
-				dAtA70[j69] = uint8(uint64(num)&0x7f | 0x80)
+				dAtA20[j19] = uint8(uint64(num)&0x7f | 0x80)
 				num >>= 7
-				j69++
+				j19++
 			}
-			dAtA70[j69] = uint8(num)
-			j69++
+			dAtA20[j19] = uint8(num)
+			j19++
 		}
-		i -= j69
-		copy(dAtA[i:], dAtA70[:j69])
-		i = encodeVarintOsm(dAtA, i, uint64(j69))
+		i -= j19
+		copy(dAtA[i:], dAtA20[:j19])
+		i = encodeVarintOsm(dAtA, i, uint64(j19))
 		i--
 		dAtA[i] = 0x12
 	}
@@ -2291,7 +2717,7 @@ func (m *Relation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *DenseMembers) Marshal() (dAtA []byte, err error) {
+func (m *Info) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -2301,476 +2727,764 @@ func (m *DenseMembers) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *DenseMembers) MarshalTo(dAtA []byte) (int, error) {
+func (m *Info) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *DenseMembers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *Info) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Lons) > 0 {
-		var j71 int
-		dAtA73 := make([]byte, len(m.Lons)*10)
-		for _, num := range m.Lons {
-			x72 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x72 >= 1<<7 {
-				dAtA73[j71] = uint8(uint64(x72)&0x7f | 0x80)
-				j71++
-				x72 >>= 7
-			}
-			dAtA73[j71] = uint8(x72)
-			j71++
-		}
-		i -= j71
-		copy(dAtA[i:], dAtA73[:j71])
-		i = encodeVarintOsm(dAtA, i, uint64(j71))
+	if m.Committed != nil {
+		i = encodeVarintOsm(dAtA, i, uint64(*m.Committed))
 		i--
-		dAtA[i] = 0x4a
+		dAtA[i] = 0x38
 	}
-	if len(m.Lats) > 0 {
-		var j74 int
-		dAtA76 := make([]byte, len(m.Lats)*10)
-		for _, num := range m.Lats {
-			x75 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x75 >= 1<<7 {
-				dAtA76[j74] = uint8(uint64(x75)&0x7f | 0x80)
-				j74++
-				x75 >>= 7
-			}
-			dAtA76[j74] = uint8(x75)
-			j74++
+	if m.Visible != nil {
+		i--
+		if *m.Visible {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		i -= j74
-		copy(dAtA[i:], dAtA76[:j74])
-		i = encodeVarintOsm(dAtA, i, uint64(j74))
 		i--
-		dAtA[i] = 0x42
+		dAtA[i] = 0x30
 	}
-	if len(m.Orientation) > 0 {
-		dAtA77 := make([]byte, len(m.Orientation)*5)
-		var j78 int
-		for _, num := range m.Orientation {
-			x79 := (uint32(num) << 1) ^ uint32((num >> 31))
-			for x79 >= 1<<7 {
-				dAtA77[j78] = uint8(uint64(x79)&0x7f | 0x80)
-				j78++
-				x79 >>= 7
-			}
-			dAtA77[j78] = uint8(x79)
-			j78++
-		}
-		i -= j78
-		copy(dAtA[i:], dAtA77[:j78])
-		i = encodeVarintOsm(dAtA, i, uint64(j78))
-		i--
-		dAtA[i] = 0x2a
+	i = encodeVarintOsm(dAtA, i, uint64(m.UserSid))
+	i--
+	dAtA[i] = 0x28
+	i = encodeVarintOsm(dAtA, i, uint64(m.UserId))
+	i--
+	dAtA[i] = 0x20
+	i = encodeVarintOsm(dAtA, i, uint64(m.ChangesetId))
+	i--
+	dAtA[i] = 0x18
+	i = encodeVarintOsm(dAtA, i, uint64(m.Timestamp))
+	i--
+	dAtA[i] = 0x10
+	i = encodeVarintOsm(dAtA, i, uint64(m.Version))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
+}
+
+func (m *DenseNodes) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	if len(m.ChangesetIds) > 0 {
-		var j80 int
-		dAtA82 := make([]byte, len(m.ChangesetIds)*10)
-		for _, num := range m.ChangesetIds {
-			x81 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x81 >= 1<<7 {
-				dAtA82[j80] = uint8(uint64(x81)&0x7f | 0x80)
-				j80++
-				x81 >>= 7
-			}
-			dAtA82[j80] = uint8(x81)
-			j80++
-		}
-		i -= j80
-		copy(dAtA[i:], dAtA82[:j80])
-		i = encodeVarintOsm(dAtA, i, uint64(j80))
+	return dAtA[:n], nil
+}
+
+func (m *DenseNodes) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DenseNodes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Strings) > 0 {
+		for iNdEx := len(m.Strings) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Strings[iNdEx])
+			copy(dAtA[i:], m.Strings[iNdEx])
+			i = encodeVarintOsm(dAtA, i, uint64(len(m.Strings[iNdEx])))
+			i--
+			dAtA[i] = 0x7a
+		}
+	}
+	if len(m.KeysVals) > 0 {
+		dAtA22 := make([]byte, len(m.KeysVals)*10)
+		var j21 int
+		for _, num := range m.KeysVals {
+			for num >= 1<<7 {
+				dAtA22[j21] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j21++
+			}
+			dAtA22[j21] = uint8(num)
+			j21++
+		}
+		i -= j21
+		copy(dAtA[i:], dAtA22[:j21])
+		i = encodeVarintOsm(dAtA, i, uint64(j21))
 		i--
-		dAtA[i] = 0x22
+		dAtA[i] = 0x52
 	}
-	if len(m.Timestamps) > 0 {
-		var j83 int
-		dAtA85 := make([]byte, len(m.Timestamps)*10)
-		for _, num := range m.Timestamps {
-			x84 := (uint64(num) << 1) ^ uint64((num >> 63))
-			for x84 >= 1<<7 {
-				dAtA85[j83] = uint8(uint64(x84)&0x7f | 0x80)
-				j83++
-				x84 >>= 7
+	if len(m.Lons) > 0 {
+		var j23 int
+		dAtA25 := make([]byte, len(m.Lons)*10)
+		for _, num := range m.Lons {
+			x24 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x24 >= 1<<7 {
+				dAtA25[j23] = uint8(uint64(x24)&0x7f | 0x80)
+				j23++
+				x24 >>= 7
 			}
-			dAtA85[j83] = uint8(x84)
-			j83++
+			dAtA25[j23] = uint8(x24)
+			j23++
 		}
-		i -= j83
-		copy(dAtA[i:], dAtA85[:j83])
-		i = encodeVarintOsm(dAtA, i, uint64(j83))
+		i -= j23
+		copy(dAtA[i:], dAtA25[:j23])
+		i = encodeVarintOsm(dAtA, i, uint64(j23))
 		i--
-		dAtA[i] = 0x1a
+		dAtA[i] = 0x4a
 	}
-	if len(m.Versions) > 0 {
-		dAtA87 := make([]byte, len(m.Versions)*10)
-		var j86 int
-		for _, num1 := range m.Versions {
-			num := uint64(num1)
-			for num >= 1<<7 {
-				dAtA87[j86] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j86++
+	if len(m.Lats) > 0 {
+		var j26 int
+		dAtA28 := make([]byte, len(m.Lats)*10)
+		for _, num := range m.Lats {
+			x27 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x27 >= 1<<7 {
+				dAtA28[j26] = uint8(uint64(x27)&0x7f | 0x80)
+				j26++
+				x27 >>= 7
+			}
+			dAtA28[j26] = uint8(x27)
+			j26++
+		}
+		i -= j26
+		copy(dAtA[i:], dAtA28[:j26])
+		i = encodeVarintOsm(dAtA, i, uint64(j26))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.DenseInfo != nil {
+		{
+			size, err := m.DenseInfo.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			dAtA87[j86] = uint8(num)
-			j86++
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
-		i -= j86
-		copy(dAtA[i:], dAtA87[:j86])
-		i = encodeVarintOsm(dAtA, i, uint64(j86))
 		i--
-		dAtA[i] = 0x12
+		dAtA[i] = 0x2a
 	}
-	if len(m.Indexes) > 0 {
-		dAtA88 := make([]byte, len(m.Indexes)*5)
-		var j89 int
-		for _, num := range m.Indexes {
-			x90 := (uint32(num) << 1) ^ uint32((num >> 31))
-			for x90 >= 1<<7 {
-				dAtA88[j89] = uint8(uint64(x90)&0x7f | 0x80)
-				j89++
-				x90 >>= 7
+	if len(m.Ids) > 0 {
+		var j30 int
+		dAtA32 := make([]byte, len(m.Ids)*10)
+		for _, num := range m.Ids {
+			x31 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x31 >= 1<<7 {
+				dAtA32[j30] = uint8(uint64(x31)&0x7f | 0x80)
+				j30++
+				x31 >>= 7
 			}
-			dAtA88[j89] = uint8(x90)
-			j89++
+			dAtA32[j30] = uint8(x31)
+			j30++
 		}
-		i -= j89
-		copy(dAtA[i:], dAtA88[:j89])
-		i = encodeVarintOsm(dAtA, i, uint64(j89))
+		i -= j30
+		copy(dAtA[i:], dAtA32[:j30])
+		i = encodeVarintOsm(dAtA, i, uint64(j30))
 		i--
 		dAtA[i] = 0xa
 	}
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintOsm(dAtA []byte, offset int, v uint64) int {
-	offset -= sovOsm(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *DenseInfo) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Changeset) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *DenseInfo) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DenseInfo) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Id != nil {
-		n += 1 + sovOsm(uint64(*m.Id))
+	if len(m.Committeds) > 0 {
+		var j33 int
+		dAtA35 := make([]byte, len(m.Committeds)*10)
+		for _, num := range m.Committeds {
+			x34 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x34 >= 1<<7 {
+				dAtA35[j33] = uint8(uint64(x34)&0x7f | 0x80)
+				j33++
+				x34 >>= 7
+			}
+			dAtA35[j33] = uint8(x34)
+			j33++
+		}
+		i -= j33
+		copy(dAtA[i:], dAtA35[:j33])
+		i = encodeVarintOsm(dAtA, i, uint64(j33))
+		i--
+		dAtA[i] = 0x3a
 	}
-	if len(m.Keys) > 0 {
-		l = 0
-		for _, e := range m.Keys {
-			l += sovOsm(uint64(e))
+	if len(m.Visibles) > 0 {
+		for iNdEx := len(m.Visibles) - 1; iNdEx >= 0; iNdEx-- {
+			i--
+			if m.Visibles[iNdEx] {
+				dAtA[i] = 1
+			} else {
+				dAtA[i] = 0
+			}
 		}
-		n += 1 + sovOsm(uint64(l)) + l
+		i = encodeVarintOsm(dAtA, i, uint64(len(m.Visibles)))
+		i--
+		dAtA[i] = 0x32
 	}
-	if len(m.Vals) > 0 {
-		l = 0
-		for _, e := range m.Vals {
-			l += sovOsm(uint64(e))
+	if len(m.UserSids) > 0 {
+		dAtA36 := make([]byte, len(m.UserSids)*5)
+		var j37 int
+		for _, num := range m.UserSids {
+			x38 := (uint32(num) << 1) ^ uint32((num >> 31))
+			for x38 >= 1<<7 {
+				dAtA36[j37] = uint8(uint64(x38)&0x7f | 0x80)
+				j37++
+				x38 >>= 7
+			}
+			dAtA36[j37] = uint8(x38)
+			j37++
 		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if m.UserId != nil {
-		n += 1 + sovOsm(uint64(*m.UserId))
-	}
-	if m.UserSid != nil {
-		n += 1 + sovOsm(uint64(*m.UserSid))
-	}
-	if m.CreatedAt != nil {
-		n += 1 + sovOsm(uint64(*m.CreatedAt))
-	}
-	if m.ClosedAt != nil {
-		n += 1 + sovOsm(uint64(*m.ClosedAt))
+		i -= j37
+		copy(dAtA[i:], dAtA36[:j37])
+		i = encodeVarintOsm(dAtA, i, uint64(j37))
+		i--
+		dAtA[i] = 0x2a
 	}
-	if m.Open != nil {
-		n += 2
+	if len(m.UserIds) > 0 {
+		dAtA39 := make([]byte, len(m.UserIds)*5)
+		var j40 int
+		for _, num := range m.UserIds {
+			x41 := (uint32(num) << 1) ^ uint32((num >> 31))
+			for x41 >= 1<<7 {
+				dAtA39[j40] = uint8(uint64(x41)&0x7f | 0x80)
+				j40++
+				x41 >>= 7
+			}
+			dAtA39[j40] = uint8(x41)
+			j40++
+		}
+		i -= j40
+		copy(dAtA[i:], dAtA39[:j40])
+		i = encodeVarintOsm(dAtA, i, uint64(j40))
+		i--
+		dAtA[i] = 0x22
 	}
-	if m.Bounds != nil {
-		l = m.Bounds.Size()
-		n += 1 + l + sovOsm(uint64(l))
+	if len(m.ChangesetIds) > 0 {
+		var j42 int
+		dAtA44 := make([]byte, len(m.ChangesetIds)*10)
+		for _, num := range m.ChangesetIds {
+			x43 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x43 >= 1<<7 {
+				dAtA44[j42] = uint8(uint64(x43)&0x7f | 0x80)
+				j42++
+				x43 >>= 7
+			}
+			dAtA44[j42] = uint8(x43)
+			j42++
+		}
+		i -= j42
+		copy(dAtA[i:], dAtA44[:j42])
+		i = encodeVarintOsm(dAtA, i, uint64(j42))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Change != nil {
-		l = m.Change.Size()
-		n += 1 + l + sovOsm(uint64(l))
+	if len(m.Timestamps) > 0 {
+		var j45 int
+		dAtA47 := make([]byte, len(m.Timestamps)*10)
+		for _, num := range m.Timestamps {
+			x46 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x46 >= 1<<7 {
+				dAtA47[j45] = uint8(uint64(x46)&0x7f | 0x80)
+				j45++
+				x46 >>= 7
+			}
+			dAtA47[j45] = uint8(x46)
+			j45++
+		}
+		i -= j45
+		copy(dAtA[i:], dAtA47[:j45])
+		i = encodeVarintOsm(dAtA, i, uint64(j45))
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Strings) > 0 {
-		for _, s := range m.Strings {
-			l = len(s)
-			n += 2 + l + sovOsm(uint64(l))
+	if len(m.Versions) > 0 {
+		dAtA49 := make([]byte, len(m.Versions)*10)
+		var j48 int
+		for _, num1 := range m.Versions {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA49[j48] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j48++
+			}
+			dAtA49[j48] = uint8(num)
+			j48++
 		}
+		i -= j48
+		copy(dAtA[i:], dAtA49[:j48])
+		i = encodeVarintOsm(dAtA, i, uint64(j48))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Bounds) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Way) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	n += 1 + sozOsm(uint64(m.MinLon))
-	n += 1 + sozOsm(uint64(m.MaxLon))
-	n += 1 + sozOsm(uint64(m.MinLat))
-	n += 1 + sozOsm(uint64(m.MaxLat))
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *Change) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *Way) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Way) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Create != nil {
-		l = m.Create.Size()
-		n += 1 + l + sovOsm(uint64(l))
-	}
-	if m.Modify != nil {
-		l = m.Modify.Size()
-		n += 1 + l + sovOsm(uint64(l))
+	if m.Updates != nil {
+		{
+			size, err := m.Updates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
 	}
-	if m.Delete != nil {
-		l = m.Delete.Size()
-		n += 1 + l + sovOsm(uint64(l))
+	if m.DenseMembers != nil {
+		{
+			size, err := m.DenseMembers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
 	}
-	if m.Context != nil {
-		l = m.Context.Size()
-		n += 1 + l + sovOsm(uint64(l))
+	if len(m.Refs) > 0 {
+		var j52 int
+		dAtA54 := make([]byte, len(m.Refs)*10)
+		for _, num := range m.Refs {
+			x53 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x53 >= 1<<7 {
+				dAtA54[j52] = uint8(uint64(x53)&0x7f | 0x80)
+				j52++
+				x53 >>= 7
+			}
+			dAtA54[j52] = uint8(x53)
+			j52++
+		}
+		i -= j52
+		copy(dAtA[i:], dAtA54[:j52])
+		i = encodeVarintOsm(dAtA, i, uint64(j52))
+		i--
+		dAtA[i] = 0x42
 	}
-	if len(m.Strings) > 0 {
-		for _, s := range m.Strings {
-			l = len(s)
-			n += 2 + l + sovOsm(uint64(l))
+	if m.Info != nil {
+		{
+			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x22
 	}
-	return n
-}
-
-func (m *Tags) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Vals) > 0 {
+		dAtA57 := make([]byte, len(m.Vals)*10)
+		var j56 int
+		for _, num := range m.Vals {
+			for num >= 1<<7 {
+				dAtA57[j56] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j56++
+			}
+			dAtA57[j56] = uint8(num)
+			j56++
+		}
+		i -= j56
+		copy(dAtA[i:], dAtA57[:j56])
+		i = encodeVarintOsm(dAtA, i, uint64(j56))
+		i--
+		dAtA[i] = 0x1a
 	}
-	var l int
-	_ = l
-	if len(m.KeysVals) > 0 {
-		for _, s := range m.KeysVals {
-			l = len(s)
-			n += 1 + l + sovOsm(uint64(l))
+	if len(m.Keys) > 0 {
+		dAtA59 := make([]byte, len(m.Keys)*10)
+		var j58 int
+		for _, num := range m.Keys {
+			for num >= 1<<7 {
+				dAtA59[j58] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j58++
+			}
+			dAtA59[j58] = uint8(num)
+			j58++
 		}
+		i -= j58
+		copy(dAtA[i:], dAtA59[:j58])
+		i = encodeVarintOsm(dAtA, i, uint64(j58))
+		i--
+		dAtA[i] = 0x12
 	}
-	return n
+	i = encodeVarintOsm(dAtA, i, uint64(m.Id))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
 }
 
-func (m *OSM) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *Relation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *Relation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Relation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Bounds != nil {
-		l = m.Bounds.Size()
-		n += 1 + l + sovOsm(uint64(l))
-	}
-	if len(m.Nodes) > 0 {
-		for _, e := range m.Nodes {
-			l = e.Size()
-			n += 1 + l + sovOsm(uint64(l))
+	if m.Updates != nil {
+		{
+			size, err := m.Updates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x62
 	}
-	if m.DenseNodes != nil {
-		l = m.DenseNodes.Size()
-		n += 1 + l + sovOsm(uint64(l))
-	}
-	if len(m.Ways) > 0 {
-		for _, e := range m.Ways {
-			l = e.Size()
-			n += 1 + l + sovOsm(uint64(l))
+	if m.DenseMembers != nil {
+		{
+			size, err := m.DenseMembers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
+		i--
+		dAtA[i] = 0x5a
 	}
-	if len(m.Relations) > 0 {
-		for _, e := range m.Relations {
-			l = e.Size()
-			n += 1 + l + sovOsm(uint64(l))
+	if len(m.Types) > 0 {
+		dAtA63 := make([]byte, len(m.Types)*10)
+		var j62 int
+		for _, num := range m.Types {
+			for num >= 1<<7 {
+				dAtA63[j62] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j62++
+			}
+			dAtA63[j62] = uint8(num)
+			j62++
 		}
+		i -= j62
+		copy(dAtA[i:], dAtA63[:j62])
+		i = encodeVarintOsm(dAtA, i, uint64(j62))
+		i--
+		dAtA[i] = 0x52
 	}
-	if len(m.Strings) > 0 {
-		for _, s := range m.Strings {
-			l = len(s)
-			n += 1 + l + sovOsm(uint64(l))
+	if len(m.Refs) > 0 {
+		var j64 int
+		dAtA66 := make([]byte, len(m.Refs)*10)
+		for _, num := range m.Refs {
+			x65 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x65 >= 1<<7 {
+				dAtA66[j64] = uint8(uint64(x65)&0x7f | 0x80)
+				j64++
+				x65 >>= 7
+			}
+			dAtA66[j64] = uint8(x65)
+			j64++
 		}
+		i -= j64
+		copy(dAtA[i:], dAtA66[:j64])
+		i = encodeVarintOsm(dAtA, i, uint64(j64))
+		i--
+		dAtA[i] = 0x4a
 	}
-	return n
-}
-
-func (m *Node) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.Roles) > 0 {
+		dAtA68 := make([]byte, len(m.Roles)*10)
+		var j67 int
+		for _, num := range m.Roles {
+			for num >= 1<<7 {
+				dAtA68[j67] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j67++
+			}
+			dAtA68[j67] = uint8(num)
+			j67++
+		}
+		i -= j67
+		copy(dAtA[i:], dAtA68[:j67])
+		i = encodeVarintOsm(dAtA, i, uint64(j67))
+		i--
+		dAtA[i] = 0x42
 	}
-	var l int
-	_ = l
-	n += 1 + sovOsm(uint64(m.Id))
-	if len(m.Keys) > 0 {
-		l = 0
-		for _, e := range m.Keys {
-			l += sovOsm(uint64(e))
+	if m.Info != nil {
+		{
+			size, err := m.Info.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintOsm(dAtA, i, uint64(size))
 		}
-		n += 1 + sovOsm(uint64(l)) + l
+		i--
+		dAtA[i] = 0x22
 	}
 	if len(m.Vals) > 0 {
-		l = 0
-		for _, e := range m.Vals {
-			l += sovOsm(uint64(e))
+		dAtA71 := make([]byte, len(m.Vals)*10)
+		var j70 int
+		for _, num := range m.Vals {
+			for num >= 1<<7 {
+				dAtA71[j70] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j70++
+			}
+			dAtA71[j70] = uint8(num)
+			j70++
 		}
-		n += 1 + sovOsm(uint64(l)) + l
+		i -= j70
+		copy(dAtA[i:], dAtA71[:j70])
+		i = encodeVarintOsm(dAtA, i, uint64(j70))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovOsm(uint64(l))
+	if len(m.Keys) > 0 {
+		dAtA73 := make([]byte, len(m.Keys)*10)
+		var j72 int
+		for _, num := range m.Keys {
+			for num >= 1<<7 {
+				dAtA73[j72] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j72++
+			}
+			dAtA73[j72] = uint8(num)
+			j72++
+		}
+		i -= j72
+		copy(dAtA[i:], dAtA73[:j72])
+		i = encodeVarintOsm(dAtA, i, uint64(j72))
+		i--
+		dAtA[i] = 0x12
 	}
-	n += 1 + sozOsm(uint64(m.Lat))
-	n += 1 + sozOsm(uint64(m.Lon))
-	return n
+	i = encodeVarintOsm(dAtA, i, uint64(m.Id))
+	i--
+	dAtA[i] = 0x8
+	return len(dAtA) - i, nil
 }
 
-func (m *Info) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	n += 1 + sovOsm(uint64(m.Version))
-	n += 1 + sovOsm(uint64(m.Timestamp))
-	n += 1 + sovOsm(uint64(m.ChangesetId))
-	n += 1 + sovOsm(uint64(m.UserId))
-	n += 1 + sovOsm(uint64(m.UserSid))
-	if m.Visible != nil {
-		n += 2
-	}
-	if m.Committed != nil {
-		n += 1 + sovOsm(uint64(*m.Committed))
+func (m *DenseMembers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *DenseNodes) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *DenseMembers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DenseMembers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Ids) > 0 {
-		l = 0
-		for _, e := range m.Ids {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if m.DenseInfo != nil {
-		l = m.DenseInfo.Size()
-		n += 1 + l + sovOsm(uint64(l))
-	}
-	if len(m.Lats) > 0 {
-		l = 0
-		for _, e := range m.Lats {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
 	if len(m.Lons) > 0 {
-		l = 0
-		for _, e := range m.Lons {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if len(m.KeysVals) > 0 {
-		l = 0
-		for _, e := range m.KeysVals {
-			l += sovOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if len(m.Strings) > 0 {
-		for _, s := range m.Strings {
-			l = len(s)
-			n += 1 + l + sovOsm(uint64(l))
+		var j74 int
+		dAtA76 := make([]byte, len(m.Lons)*10)
+		for _, num := range m.Lons {
+			x75 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x75 >= 1<<7 {
+				dAtA76[j74] = uint8(uint64(x75)&0x7f | 0x80)
+				j74++
+				x75 >>= 7
+			}
+			dAtA76[j74] = uint8(x75)
+			j74++
 		}
+		i -= j74
+		copy(dAtA[i:], dAtA76[:j74])
+		i = encodeVarintOsm(dAtA, i, uint64(j74))
+		i--
+		dAtA[i] = 0x4a
 	}
-	return n
-}
-
-func (m *DenseInfo) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Versions) > 0 {
-		l = 0
-		for _, e := range m.Versions {
-			l += sovOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
+	if len(m.Lats) > 0 {
+		var j77 int
+		dAtA79 := make([]byte, len(m.Lats)*10)
+		for _, num := range m.Lats {
+			x78 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x78 >= 1<<7 {
+				dAtA79[j77] = uint8(uint64(x78)&0x7f | 0x80)
+				j77++
+				x78 >>= 7
+			}
+			dAtA79[j77] = uint8(x78)
+			j77++
+		}
+		i -= j77
+		copy(dAtA[i:], dAtA79[:j77])
+		i = encodeVarintOsm(dAtA, i, uint64(j77))
+		i--
+		dAtA[i] = 0x42
 	}
-	if len(m.Timestamps) > 0 {
-		l = 0
-		for _, e := range m.Timestamps {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
+	if len(m.Orientation) > 0 {
+		dAtA80 := make([]byte, len(m.Orientation)*5)
+		var j81 int
+		for _, num := range m.Orientation {
+			x82 := (uint32(num) << 1) ^ uint32((num >> 31))
+			for x82 >= 1<<7 {
+				dAtA80[j81] = uint8(uint64(x82)&0x7f | 0x80)
+				j81++
+				x82 >>= 7
+			}
+			dAtA80[j81] = uint8(x82)
+			j81++
+		}
+		i -= j81
+		copy(dAtA[i:], dAtA80[:j81])
+		i = encodeVarintOsm(dAtA, i, uint64(j81))
+		i--
+		dAtA[i] = 0x2a
 	}
 	if len(m.ChangesetIds) > 0 {
-		l = 0
-		for _, e := range m.ChangesetIds {
-			l += sozOsm(uint64(e))
+		var j83 int
+		dAtA85 := make([]byte, len(m.ChangesetIds)*10)
+		for _, num := range m.ChangesetIds {
+			x84 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x84 >= 1<<7 {
+				dAtA85[j83] = uint8(uint64(x84)&0x7f | 0x80)
+				j83++
+				x84 >>= 7
+			}
+			dAtA85[j83] = uint8(x84)
+			j83++
 		}
-		n += 1 + sovOsm(uint64(l)) + l
+		i -= j83
+		copy(dAtA[i:], dAtA85[:j83])
+		i = encodeVarintOsm(dAtA, i, uint64(j83))
+		i--
+		dAtA[i] = 0x22
 	}
-	if len(m.UserIds) > 0 {
-		l = 0
-		for _, e := range m.UserIds {
-			l += sozOsm(uint64(e))
+	if len(m.Timestamps) > 0 {
+		var j86 int
+		dAtA88 := make([]byte, len(m.Timestamps)*10)
+		for _, num := range m.Timestamps {
+			x87 := (uint64(num) << 1) ^ uint64((num >> 63))
+			for x87 >= 1<<7 {
+				dAtA88[j86] = uint8(uint64(x87)&0x7f | 0x80)
+				j86++
+				x87 >>= 7
+			}
+			dAtA88[j86] = uint8(x87)
+			j86++
 		}
-		n += 1 + sovOsm(uint64(l)) + l
+		i -= j86
+		copy(dAtA[i:], dAtA88[:j86])
+		i = encodeVarintOsm(dAtA, i, uint64(j86))
+		i--
+		dAtA[i] = 0x1a
 	}
-	if len(m.UserSids) > 0 {
-		l = 0
-		for _, e := range m.UserSids {
-			l += sozOsm(uint64(e))
+	if len(m.Versions) > 0 {
+		dAtA90 := make([]byte, len(m.Versions)*10)
+		var j89 int
+		for _, num1 := range m.Versions {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA90[j89] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j89++
+			}
+			dAtA90[j89] = uint8(num)
+			j89++
 		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if len(m.Visibles) > 0 {
-		n += 1 + sovOsm(uint64(len(m.Visibles))) + len(m.Visibles)*1
+		i -= j89
+		copy(dAtA[i:], dAtA90[:j89])
+		i = encodeVarintOsm(dAtA, i, uint64(j89))
+		i--
+		dAtA[i] = 0x12
 	}
-	if len(m.Committeds) > 0 {
-		l = 0
-		for _, e := range m.Committeds {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
+	if len(m.Indexes) > 0 {
+		dAtA91 := make([]byte, len(m.Indexes)*5)
+		var j92 int
+		for _, num := range m.Indexes {
+			x93 := (uint32(num) << 1) ^ uint32((num >> 31))
+			for x93 >= 1<<7 {
+				dAtA91[j92] = uint8(uint64(x93)&0x7f | 0x80)
+				j92++
+				x93 >>= 7
+			}
+			dAtA91[j92] = uint8(x93)
+			j92++
+		}
+		i -= j92
+		copy(dAtA[i:], dAtA91[:j92])
+		i = encodeVarintOsm(dAtA, i, uint64(j92))
+		i--
+		dAtA[i] = 0xa
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Way) Size() (n int) {
+func encodeVarintOsm(dAtA []byte, offset int, v uint64) int {
+	offset -= sovOsm(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Changeset) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	n += 1 + sovOsm(uint64(m.Id))
+	if m.Id != nil {
+		n += 1 + sovOsm(uint64(*m.Id))
+	}
 	if len(m.Keys) > 0 {
 		l = 0
 		for _, e := range m.Keys {
@@ -2785,126 +3499,363 @@ func (m *Way) Size() (n int) {
 		}
 		n += 1 + sovOsm(uint64(l)) + l
 	}
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovOsm(uint64(l))
+	if m.UserId != nil {
+		n += 1 + sovOsm(uint64(*m.UserId))
 	}
-	if len(m.Refs) > 0 {
-		l = 0
-		for _, e := range m.Refs {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
+	if m.UserSid != nil {
+		n += 1 + sovOsm(uint64(*m.UserSid))
 	}
-	if m.DenseMembers != nil {
-		l = m.DenseMembers.Size()
+	if m.CreatedAt != nil {
+		n += 1 + sovOsm(uint64(*m.CreatedAt))
+	}
+	if m.ClosedAt != nil {
+		n += 1 + sovOsm(uint64(*m.ClosedAt))
+	}
+	if m.Open != nil {
+		n += 2
+	}
+	if m.Bounds != nil {
+		l = m.Bounds.Size()
 		n += 1 + l + sovOsm(uint64(l))
 	}
-	if m.Updates != nil {
-		l = m.Updates.Size()
+	if m.Change != nil {
+		l = m.Change.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.ChangesCount != nil {
+		n += 1 + sovOsm(uint64(*m.ChangesCount))
+	}
+	if m.CommentsCount != nil {
+		n += 1 + sovOsm(uint64(*m.CommentsCount))
+	}
+	if m.Discussion != nil {
+		l = m.Discussion.Size()
 		n += 1 + l + sovOsm(uint64(l))
 	}
+	if len(m.Strings) > 0 {
+		for _, s := range m.Strings {
+			l = len(s)
+			n += 2 + l + sovOsm(uint64(l))
+		}
+	}
 	return n
 }
 
-func (m *Relation) Size() (n int) {
+func (m *ChangesetDiscussion) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	n += 1 + sovOsm(uint64(m.Id))
-	if len(m.Keys) > 0 {
-		l = 0
-		for _, e := range m.Keys {
-			l += sovOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if len(m.Vals) > 0 {
-		l = 0
-		for _, e := range m.Vals {
-			l += sovOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if m.Info != nil {
-		l = m.Info.Size()
-		n += 1 + l + sovOsm(uint64(l))
-	}
-	if len(m.Roles) > 0 {
-		l = 0
-		for _, e := range m.Roles {
-			l += sovOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if len(m.Refs) > 0 {
-		l = 0
-		for _, e := range m.Refs {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if len(m.Types) > 0 {
-		l = 0
-		for _, e := range m.Types {
-			l += sovOsm(uint64(e))
+	if len(m.Comments) > 0 {
+		for _, e := range m.Comments {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
 		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if m.DenseMembers != nil {
-		l = m.DenseMembers.Size()
-		n += 1 + l + sovOsm(uint64(l))
-	}
-	if m.Updates != nil {
-		l = m.Updates.Size()
-		n += 1 + l + sovOsm(uint64(l))
 	}
 	return n
 }
 
-func (m *DenseMembers) Size() (n int) {
+func (m *ChangesetComment) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Indexes) > 0 {
-		l = 0
-		for _, e := range m.Indexes {
-			l += sozOsm(uint64(e))
-		}
-		n += 1 + sovOsm(uint64(l)) + l
-	}
-	if len(m.Versions) > 0 {
-		l = 0
-		for _, e := range m.Versions {
+	n += 1 + sovOsm(uint64(m.UserId))
+	n += 1 + sovOsm(uint64(m.UserSid))
+	n += 1 + sovOsm(uint64(m.Timestamp))
+	l = len(m.Text)
+	n += 1 + l + sovOsm(uint64(l))
+	return n
+}
+
+func (m *Bounds) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sozOsm(uint64(m.MinLon))
+	n += 1 + sozOsm(uint64(m.MaxLon))
+	n += 1 + sozOsm(uint64(m.MinLat))
+	n += 1 + sozOsm(uint64(m.MaxLat))
+	return n
+}
+
+func (m *Change) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Create != nil {
+		l = m.Create.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.Modify != nil {
+		l = m.Modify.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.Delete != nil {
+		l = m.Delete.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.Context != nil {
+		l = m.Context.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if len(m.Strings) > 0 {
+		for _, s := range m.Strings {
+			l = len(s)
+			n += 2 + l + sovOsm(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Tags) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.KeysVals) > 0 {
+		for _, s := range m.KeysVals {
+			l = len(s)
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *OSM) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Bounds != nil {
+		l = m.Bounds.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if len(m.Nodes) > 0 {
+		for _, e := range m.Nodes {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	if m.DenseNodes != nil {
+		l = m.DenseNodes.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if len(m.Ways) > 0 {
+		for _, e := range m.Ways {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	if len(m.Relations) > 0 {
+		for _, e := range m.Relations {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	if len(m.Changesets) > 0 {
+		for _, e := range m.Changesets {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	if len(m.Notes) > 0 {
+		for _, e := range m.Notes {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	if len(m.Users) > 0 {
+		for _, e := range m.Users {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	if m.Version != nil {
+		n += 9
+	}
+	if m.Generator != nil {
+		l = len(*m.Generator)
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.Copyright != nil {
+		l = len(*m.Copyright)
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.Attribution != nil {
+		l = len(*m.Attribution)
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.License != nil {
+		l = len(*m.License)
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if len(m.Strings) > 0 {
+		for _, s := range m.Strings {
+			l = len(s)
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Note) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOsm(uint64(m.Id))
+	n += 1 + sozOsm(uint64(m.Lat))
+	n += 1 + sozOsm(uint64(m.Lon))
+	l = len(m.Url)
+	n += 1 + l + sovOsm(uint64(l))
+	l = len(m.CommentUrl)
+	n += 1 + l + sovOsm(uint64(l))
+	l = len(m.CloseUrl)
+	n += 1 + l + sovOsm(uint64(l))
+	l = len(m.ReopenUrl)
+	n += 1 + l + sovOsm(uint64(l))
+	n += 1 + sovOsm(uint64(m.DateCreated))
+	n += 1 + sovOsm(uint64(m.DateClosed))
+	n += 1 + sovOsm(uint64(m.StatusSid))
+	if len(m.Comments) > 0 {
+		for _, e := range m.Comments {
+			l = e.Size()
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *NoteComment) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOsm(uint64(m.Date))
+	n += 1 + sovOsm(uint64(m.UserId))
+	n += 1 + sovOsm(uint64(m.UserSid))
+	l = len(m.UserUrl)
+	n += 1 + l + sovOsm(uint64(l))
+	n += 1 + sovOsm(uint64(m.ActionSid))
+	l = len(m.Text)
+	n += 1 + l + sovOsm(uint64(l))
+	l = len(m.Html)
+	n += 1 + l + sovOsm(uint64(l))
+	return n
+}
+
+func (m *User) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOsm(uint64(m.Id))
+	l = len(m.Name)
+	n += 1 + l + sovOsm(uint64(l))
+	l = len(m.Description)
+	n += 1 + l + sovOsm(uint64(l))
+	l = len(m.ImgHref)
+	n += 1 + l + sovOsm(uint64(l))
+	n += 1 + sovOsm(uint64(m.CreatedAt))
+	n += 1 + sovOsm(uint64(m.ChangesetsCount))
+	n += 1 + sovOsm(uint64(m.TracesCount))
+	n += 1 + sozOsm(uint64(m.HomeLat))
+	n += 1 + sozOsm(uint64(m.HomeLon))
+	n += 1 + sovOsm(uint64(m.HomeZoom))
+	if len(m.Languages) > 0 {
+		l = 0
+		for _, e := range m.Languages {
 			l += sovOsm(uint64(e))
 		}
 		n += 1 + sovOsm(uint64(l)) + l
 	}
-	if len(m.Timestamps) > 0 {
+	n += 1 + sovOsm(uint64(m.BlocksReceivedCount))
+	n += 1 + sovOsm(uint64(m.BlocksReceivedActive))
+	n += 1 + sovOsm(uint64(m.MessagesReceivedCount))
+	n += 1 + sovOsm(uint64(m.MessagesReceivedUnread))
+	n += 2 + sovOsm(uint64(m.MessagesSentCount))
+	return n
+}
+
+func (m *Node) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOsm(uint64(m.Id))
+	if len(m.Keys) > 0 {
 		l = 0
-		for _, e := range m.Timestamps {
-			l += sozOsm(uint64(e))
+		for _, e := range m.Keys {
+			l += sovOsm(uint64(e))
 		}
 		n += 1 + sovOsm(uint64(l)) + l
 	}
-	if len(m.ChangesetIds) > 0 {
+	if len(m.Vals) > 0 {
 		l = 0
-		for _, e := range m.ChangesetIds {
-			l += sozOsm(uint64(e))
+		for _, e := range m.Vals {
+			l += sovOsm(uint64(e))
 		}
 		n += 1 + sovOsm(uint64(l)) + l
 	}
-	if len(m.Orientation) > 0 {
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	n += 1 + sozOsm(uint64(m.Lat))
+	n += 1 + sozOsm(uint64(m.Lon))
+	return n
+}
+
+func (m *Info) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOsm(uint64(m.Version))
+	n += 1 + sovOsm(uint64(m.Timestamp))
+	n += 1 + sovOsm(uint64(m.ChangesetId))
+	n += 1 + sovOsm(uint64(m.UserId))
+	n += 1 + sovOsm(uint64(m.UserSid))
+	if m.Visible != nil {
+		n += 2
+	}
+	if m.Committed != nil {
+		n += 1 + sovOsm(uint64(*m.Committed))
+	}
+	return n
+}
+
+func (m *DenseNodes) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ids) > 0 {
 		l = 0
-		for _, e := range m.Orientation {
+		for _, e := range m.Ids {
 			l += sozOsm(uint64(e))
 		}
 		n += 1 + sovOsm(uint64(l)) + l
 	}
+	if m.DenseInfo != nil {
+		l = m.DenseInfo.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
 	if len(m.Lats) > 0 {
 		l = 0
 		for _, e := range m.Lats {
@@ -2917,18 +3868,1361 @@ func (m *DenseMembers) Size() (n int) {
 		for _, e := range m.Lons {
 			l += sozOsm(uint64(e))
 		}
-		n += 1 + sovOsm(uint64(l)) + l
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.KeysVals) > 0 {
+		l = 0
+		for _, e := range m.KeysVals {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Strings) > 0 {
+		for _, s := range m.Strings {
+			l = len(s)
+			n += 1 + l + sovOsm(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DenseInfo) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Versions) > 0 {
+		l = 0
+		for _, e := range m.Versions {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Timestamps) > 0 {
+		l = 0
+		for _, e := range m.Timestamps {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.ChangesetIds) > 0 {
+		l = 0
+		for _, e := range m.ChangesetIds {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.UserIds) > 0 {
+		l = 0
+		for _, e := range m.UserIds {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.UserSids) > 0 {
+		l = 0
+		for _, e := range m.UserSids {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Visibles) > 0 {
+		n += 1 + sovOsm(uint64(len(m.Visibles))) + len(m.Visibles)*1
+	}
+	if len(m.Committeds) > 0 {
+		l = 0
+		for _, e := range m.Committeds {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *Way) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOsm(uint64(m.Id))
+	if len(m.Keys) > 0 {
+		l = 0
+		for _, e := range m.Keys {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Vals) > 0 {
+		l = 0
+		for _, e := range m.Vals {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if len(m.Refs) > 0 {
+		l = 0
+		for _, e := range m.Refs {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if m.DenseMembers != nil {
+		l = m.DenseMembers.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.Updates != nil {
+		l = m.Updates.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	return n
+}
+
+func (m *Relation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovOsm(uint64(m.Id))
+	if len(m.Keys) > 0 {
+		l = 0
+		for _, e := range m.Keys {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Vals) > 0 {
+		l = 0
+		for _, e := range m.Vals {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if m.Info != nil {
+		l = m.Info.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if len(m.Roles) > 0 {
+		l = 0
+		for _, e := range m.Roles {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Refs) > 0 {
+		l = 0
+		for _, e := range m.Refs {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Types) > 0 {
+		l = 0
+		for _, e := range m.Types {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if m.DenseMembers != nil {
+		l = m.DenseMembers.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	if m.Updates != nil {
+		l = m.Updates.Size()
+		n += 1 + l + sovOsm(uint64(l))
+	}
+	return n
+}
+
+func (m *DenseMembers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Indexes) > 0 {
+		l = 0
+		for _, e := range m.Indexes {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Versions) > 0 {
+		l = 0
+		for _, e := range m.Versions {
+			l += sovOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Timestamps) > 0 {
+		l = 0
+		for _, e := range m.Timestamps {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.ChangesetIds) > 0 {
+		l = 0
+		for _, e := range m.ChangesetIds {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Orientation) > 0 {
+		l = 0
+		for _, e := range m.Orientation {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Lats) > 0 {
+		l = 0
+		for _, e := range m.Lats {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	if len(m.Lons) > 0 {
+		l = 0
+		for _, e := range m.Lons {
+			l += sozOsm(uint64(e))
+		}
+		n += 1 + sovOsm(uint64(l)) + l
+	}
+	return n
+}
+
+func sovOsm(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozOsm(x uint64) (n int) {
+	return sovOsm(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Changeset) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOsm
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Changeset: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Changeset: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Id = &v
+		case 2:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOsm
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Keys = append(m.Keys, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOsm
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOsm
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOsm
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Keys) == 0 {
+					m.Keys = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOsm
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Keys = append(m.Keys, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
+			}
+		case 3:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOsm
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Vals = append(m.Vals, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOsm
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOsm
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOsm
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Vals) == 0 {
+					m.Vals = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOsm
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Vals = append(m.Vals, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vals", wireType)
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UserId = &v
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserSid", wireType)
+			}
+			var v uint32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UserSid = &v
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CreatedAt = &v
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ClosedAt", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ClosedAt = &v
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Open", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			b := bool(v != 0)
+			m.Open = &b
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bounds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Bounds == nil {
+				m.Bounds = &Bounds{}
+			}
+			if err := m.Bounds.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Change", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Change == nil {
+				m.Change = &Change{}
+			}
+			if err := m.Change.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangesCount", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ChangesCount = &v
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommentsCount", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CommentsCount = &v
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Discussion", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Discussion == nil {
+				m.Discussion = &ChangesetDiscussion{}
+			}
+			if err := m.Discussion.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Strings = append(m.Strings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOsm(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangesetDiscussion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOsm
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangesetDiscussion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangesetDiscussion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Comments", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Comments = append(m.Comments, &ChangesetComment{})
+			if err := m.Comments[len(m.Comments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOsm(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChangesetComment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOsm
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChangesetComment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChangesetComment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			}
+			m.UserId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserId |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserSid", wireType)
+			}
+			m.UserSid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserSid |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Text", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Text = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOsm(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Bounds) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOsm
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Bounds: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Bounds: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLon", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+			m.MinLon = int64(v)
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLon", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+			m.MaxLon = int64(v)
+			hasFields[0] |= uint64(0x00000002)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLat", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+			m.MinLat = int64(v)
+			hasFields[0] |= uint64(0x00000004)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLat", wireType)
+			}
+			var v uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+			m.MaxLat = int64(v)
+			hasFields[0] |= uint64(0x00000008)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOsm(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("min_lon")
+	}
+	if hasFields[0]&uint64(0x00000002) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("max_lon")
+	}
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("min_lat")
+	}
+	if hasFields[0]&uint64(0x00000008) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("max_lat")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Change) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOsm
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Change: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Change: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Create", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Create == nil {
+				m.Create = &OSM{}
+			}
+			if err := m.Create.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Modify", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Modify == nil {
+				m.Modify = &OSM{}
+			}
+			if err := m.Modify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Delete == nil {
+				m.Delete = &OSM{}
+			}
+			if err := m.Delete.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Context == nil {
+				m.Context = &OSM{}
+			}
+			if err := m.Context.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strings", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Strings = append(m.Strings, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOsm(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovOsm(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozOsm(x uint64) (n int) {
-	return sovOsm(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *Changeset) Unmarshal(dAtA []byte) error {
+func (m *Tags) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2951,17 +5245,17 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Changeset: wiretype end group for non-group")
+			return fmt.Errorf("proto: Tags: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Changeset: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Tags: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeysVals", wireType)
 			}
-			var v int64
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -2971,169 +5265,222 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.Id = &v
-		case 2:
-			if wireType == 0 {
-				var v uint32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowOsm
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint32(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.Keys = append(m.Keys, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowOsm
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthOsm
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthOsm
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KeysVals = append(m.KeysVals, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipOsm(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *OSM) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowOsm
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: OSM: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: OSM: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bounds", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.Keys) == 0 {
-					m.Keys = make([]uint32, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowOsm
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Keys = append(m.Keys, v)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Keys", wireType)
 			}
-		case 3:
-			if wireType == 0 {
-				var v uint32
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowOsm
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint32(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Bounds == nil {
+				m.Bounds = &Bounds{}
+			}
+			if err := m.Bounds.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
 				}
-				m.Vals = append(m.Vals, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowOsm
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-				if packedLen < 0 {
-					return ErrInvalidLengthOsm
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthOsm
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Nodes = append(m.Nodes, &Node{})
+			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DenseNodes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
 				}
-				if postIndex > l {
+				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
 				}
-				elementCount = count
-				if elementCount != 0 && len(m.Vals) == 0 {
-					m.Vals = make([]uint32, 0, elementCount)
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DenseNodes == nil {
+				m.DenseNodes = &DenseNodes{}
+			}
+			if err := m.DenseNodes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ways", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
 				}
-				for iNdEx < postIndex {
-					var v uint32
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowOsm
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint32(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.Vals = append(m.Vals, v)
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
 				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vals", wireType)
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ways = append(m.Ways, &Way{})
+			if err := m.Ways[len(m.Ways)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		case 5:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Relations", wireType)
 			}
-			var v int32
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3143,17 +5490,31 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.UserId = &v
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Relations = append(m.Relations, &Relation{})
+			if err := m.Relations[len(m.Relations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field UserSid", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changesets", wireType)
 			}
-			var v uint32
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3163,17 +5524,31 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= uint32(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.UserSid = &v
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Changesets = append(m.Changesets, &Changeset{})
+			if err := m.Changesets[len(m.Changesets)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 7:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Notes", wireType)
 			}
-			var v int64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3183,17 +5558,31 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.CreatedAt = &v
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Notes = append(m.Notes, &Note{})
+			if err := m.Notes[len(m.Notes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 8:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ClosedAt", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Users", wireType)
 			}
-			var v int64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3203,17 +5592,43 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.ClosedAt = &v
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Users = append(m.Users, &User{})
+			if err := m.Users[len(m.Users)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 9:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Open", wireType)
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Version", wireType)
 			}
-			var v int
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			v2 := float64(math.Float64frombits(v))
+			m.Version = &v2
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Generator", wireType)
+			}
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3223,18 +5638,63 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Generator = &s
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Copyright", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			b := bool(v != 0)
-			m.Open = &b
-		case 10:
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Copyright = &s
+			iNdEx = postIndex
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bounds", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Attribution", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3244,33 +5704,30 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOsm
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthOsm
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Bounds == nil {
-				m.Bounds = &Bounds{}
-			}
-			if err := m.Bounds.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.Attribution = &s
 			iNdEx = postIndex
-		case 11:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Change", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field License", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3280,29 +5737,26 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOsm
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthOsm
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Change == nil {
-				m.Change = &Change{}
-			}
-			if err := m.Change.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			s := string(dAtA[iNdEx:postIndex])
+			m.License = &s
 			iNdEx = postIndex
-		case 20:
+		case 15:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Strings", wireType)
 			}
@@ -3358,7 +5812,7 @@ func (m *Changeset) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Bounds) Unmarshal(dAtA []byte) error {
+func (m *Note) Unmarshal(dAtA []byte) error {
 	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
@@ -3382,17 +5836,17 @@ func (m *Bounds) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Bounds: wiretype end group for non-group")
+			return fmt.Errorf("proto: Note: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Bounds: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Note: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MinLon", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var v uint64
+			m.Id = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3402,17 +5856,15 @@ func (m *Bounds) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= uint64(b&0x7F) << shift
+				m.Id |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
-			m.MinLon = int64(v)
 			hasFields[0] |= uint64(0x00000001)
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxLon", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lat", wireType)
 			}
 			var v uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3430,11 +5882,11 @@ func (m *Bounds) Unmarshal(dAtA []byte) error {
 				}
 			}
 			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
-			m.MaxLon = int64(v)
+			m.Lat = int64(v)
 			hasFields[0] |= uint64(0x00000002)
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MinLat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lon", wireType)
 			}
 			var v uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3452,13 +5904,141 @@ func (m *Bounds) Unmarshal(dAtA []byte) error {
 				}
 			}
 			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
-			m.MinLat = int64(v)
+			m.Lon = int64(v)
 			hasFields[0] |= uint64(0x00000004)
 		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Url", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Url = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CommentUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CommentUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CloseUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CloseUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReopenUrl", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ReopenUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 8:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field MaxLat", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DateCreated", wireType)
 			}
-			var v uint64
+			m.DateCreated = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3468,14 +6048,83 @@ func (m *Bounds) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= uint64(b&0x7F) << shift
+				m.DateCreated |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
-			m.MaxLat = int64(v)
-			hasFields[0] |= uint64(0x00000008)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DateClosed", wireType)
+			}
+			m.DateClosed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DateClosed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StatusSid", wireType)
+			}
+			m.StatusSid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StatusSid |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Comments", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Comments = append(m.Comments, &NoteComment{})
+			if err := m.Comments[len(m.Comments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOsm(dAtA[iNdEx:])
@@ -3495,16 +6144,13 @@ func (m *Bounds) Unmarshal(dAtA []byte) error {
 		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("min_lon")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
 	}
 	if hasFields[0]&uint64(0x00000002) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("max_lon")
-	}
-	if hasFields[0]&uint64(0x00000004) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("min_lat")
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("lat")
 	}
-	if hasFields[0]&uint64(0x00000008) == 0 {
-		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("max_lat")
+	if hasFields[0]&uint64(0x00000004) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("lon")
 	}
 
 	if iNdEx > l {
@@ -3512,7 +6158,7 @@ func (m *Bounds) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Change) Unmarshal(dAtA []byte) error {
+func (m *NoteComment) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3535,17 +6181,17 @@ func (m *Change) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Change: wiretype end group for non-group")
+			return fmt.Errorf("proto: NoteComment: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Change: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: NoteComment: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Create", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Date", wireType)
 			}
-			var msglen int
+			m.Date = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3555,33 +6201,16 @@ func (m *Change) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Date |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOsm
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Create == nil {
-				m.Create = &OSM{}
-			}
-			if err := m.Create.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Modify", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserId", wireType)
 			}
-			var msglen int
+			m.UserId = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3591,33 +6220,35 @@ func (m *Change) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.UserId |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOsm
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Modify == nil {
-				m.Modify = &OSM{}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UserSid", wireType)
 			}
-			if err := m.Modify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.UserSid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.UserSid |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field UserUrl", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3627,33 +6258,48 @@ func (m *Change) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOsm
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthOsm
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Delete == nil {
-				m.Delete = &OSM{}
+			m.UserUrl = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActionSid", wireType)
 			}
-			if err := m.Delete.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.ActionSid = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ActionSid |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 4:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Context", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Text", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3663,31 +6309,27 @@ func (m *Change) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOsm
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthOsm
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Context == nil {
-				m.Context = &OSM{}
-			}
-			if err := m.Context.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Text = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 20:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Strings", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Html", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3715,7 +6357,7 @@ func (m *Change) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Strings = append(m.Strings, string(dAtA[iNdEx:postIndex]))
+			m.Html = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -3741,7 +6383,8 @@ func (m *Change) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Tags) Unmarshal(dAtA []byte) error {
+func (m *User) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3764,15 +6407,35 @@ func (m *Tags) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Tags: wiretype end group for non-group")
+			return fmt.Errorf("proto: User: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Tags: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: User: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			m.Id = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Id |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			hasFields[0] |= uint64(0x00000001)
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KeysVals", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -3800,66 +6463,96 @@ func (m *Tags) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.KeysVals = append(m.KeysVals, string(dAtA[iNdEx:postIndex]))
+			m.Name = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipOsm(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Description", wireType)
 			}
-			if skippy < 0 {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthOsm
 			}
-			if (iNdEx + skippy) < 0 {
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
 				return ErrInvalidLengthOsm
 			}
-			if (iNdEx + skippy) > l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *OSM) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowOsm
+			m.Description = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ImgHref", wireType)
 			}
-			if iNdEx >= l {
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthOsm
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthOsm
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			m.ImgHref = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CreatedAt", wireType)
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: OSM: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: OSM: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Bounds", wireType)
+			m.CreatedAt = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CreatedAt |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			var msglen int
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangesetsCount", wireType)
+			}
+			m.ChangesetsCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3869,33 +6562,16 @@ func (m *OSM) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.ChangesetsCount |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOsm
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Bounds == nil {
-				m.Bounds = &Bounds{}
-			}
-			if err := m.Bounds.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Nodes", wireType)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TracesCount", wireType)
 			}
-			var msglen int
+			m.TracesCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3905,31 +6581,16 @@ func (m *OSM) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.TracesCount |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOsm
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Nodes = append(m.Nodes, &Node{})
-			if err := m.Nodes[len(m.Nodes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DenseNodes", wireType)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HomeLat", wireType)
 			}
-			var msglen int
+			var v uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3939,33 +6600,18 @@ func (m *OSM) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOsm
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.DenseNodes == nil {
-				m.DenseNodes = &DenseNodes{}
-			}
-			if err := m.DenseNodes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Ways", wireType)
+			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+			m.HomeLat = int64(v)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HomeLon", wireType)
 			}
-			var msglen int
+			var v uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -3975,31 +6621,113 @@ func (m *OSM) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				v |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOsm
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
+			v = (v >> 1) ^ uint64((int64(v&1)<<63)>>63)
+			m.HomeLon = int64(v)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HomeZoom", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.HomeZoom = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HomeZoom |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Ways = append(m.Ways, &Way{})
-			if err := m.Ways[len(m.Ways)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+		case 11:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOsm
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Languages = append(m.Languages, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowOsm
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthOsm
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthOsm
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Languages) == 0 {
+					m.Languages = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowOsm
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Languages = append(m.Languages, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Languages", wireType)
 			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Relations", wireType)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlocksReceivedCount", wireType)
 			}
-			var msglen int
+			m.BlocksReceivedCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -4009,31 +6737,54 @@ func (m *OSM) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.BlocksReceivedCount |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthOsm
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlocksReceivedActive", wireType)
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
+			m.BlocksReceivedActive = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BlocksReceivedActive |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessagesReceivedCount", wireType)
 			}
-			m.Relations = append(m.Relations, &Relation{})
-			if err := m.Relations[len(m.Relations)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.MessagesReceivedCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessagesReceivedCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
 		case 15:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Strings", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessagesReceivedUnread", wireType)
 			}
-			var stringLen uint64
+			m.MessagesReceivedUnread = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowOsm
@@ -4043,24 +6794,30 @@ func (m *OSM) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.MessagesReceivedUnread |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthOsm
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthOsm
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MessagesSentCount", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.MessagesSentCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowOsm
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MessagesSentCount |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Strings = append(m.Strings, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipOsm(dAtA[iNdEx:])
@@ -4079,6 +6836,9 @@ func (m *OSM) Unmarshal(dAtA []byte) error {
 			iNdEx += skippy
 		}
 	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("id")
+	}
 
 	if iNdEx > l {
 		return io.ErrUnexpectedEOF
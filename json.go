@@ -1,6 +1,11 @@
 package osm
 
-import "encoding/xml"
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
 
 // xmlNameJSONTypeNode is kind of a hack to encode the proper json
 // object type attribute for this struct type.
@@ -10,6 +15,10 @@ func (x xmlNameJSONTypeNode) MarshalJSON() ([]byte, error) {
 	return []byte(`"node"`), nil
 }
 
+func (x *xmlNameJSONTypeNode) UnmarshalJSON(data []byte) error {
+	return checkJSONType(data, "node")
+}
+
 // xmlNameJSONTypeWay is kind of a hack to encode the proper json
 // object type attribute for this struct type.
 type xmlNameJSONTypeWay xml.Name
@@ -18,6 +27,10 @@ func (x xmlNameJSONTypeWay) MarshalJSON() ([]byte, error) {
 	return []byte(`"way"`), nil
 }
 
+func (x *xmlNameJSONTypeWay) UnmarshalJSON(data []byte) error {
+	return checkJSONType(data, "way")
+}
+
 // xmlNameJSONTypeRel is kind of a hack to encode the proper json
 // object type attribute for this struct type.
 type xmlNameJSONTypeRel xml.Name
@@ -26,6 +39,10 @@ func (x xmlNameJSONTypeRel) MarshalJSON() ([]byte, error) {
 	return []byte(`"relation"`), nil
 }
 
+func (x *xmlNameJSONTypeRel) UnmarshalJSON(data []byte) error {
+	return checkJSONType(data, "relation")
+}
+
 // xmlNameJSONTypeCS is kind of a hack to encode the proper json
 // object type attribute for this struct type.
 type xmlNameJSONTypeCS xml.Name
@@ -34,6 +51,10 @@ func (x xmlNameJSONTypeCS) MarshalJSON() ([]byte, error) {
 	return []byte(`"changeset"`), nil
 }
 
+func (x *xmlNameJSONTypeCS) UnmarshalJSON(data []byte) error {
+	return checkJSONType(data, "changeset")
+}
+
 // xmlNameJSONTypeUser is kind of a hack to encode the proper json
 // object type attribute for this struct type.
 type xmlNameJSONTypeUser xml.Name
@@ -42,6 +63,10 @@ func (x xmlNameJSONTypeUser) MarshalJSON() ([]byte, error) {
 	return []byte(`"user"`), nil
 }
 
+func (x *xmlNameJSONTypeUser) UnmarshalJSON(data []byte) error {
+	return checkJSONType(data, "user")
+}
+
 // xmlNameJSONTypeNote is kind of a hack to encode the proper json
 // object type attribute for this struct type.
 type xmlNameJSONTypeNote xml.Name
@@ -49,3 +74,65 @@ type xmlNameJSONTypeNote xml.Name
 func (x xmlNameJSONTypeNote) MarshalJSON() ([]byte, error) {
 	return []byte(`"note"`), nil
 }
+
+func (x *xmlNameJSONTypeNote) UnmarshalJSON(data []byte) error {
+	return checkJSONType(data, "note")
+}
+
+// checkJSONType returns an error if the json object type
+// attribute is not the expected type.
+func checkJSONType(data []byte, expected string) error {
+	var t string
+	if err := json.Unmarshal(data, &t); err != nil {
+		return err
+	}
+
+	if t != expected {
+		return fmt.Errorf("osm: json type %q is not a %s", t, expected)
+	}
+
+	return nil
+}
+
+// jsonObject is used to find the type of an osmjson element.
+type jsonObject struct {
+	Type Type `json:"type"`
+}
+
+// UnmarshalJSONObject unmarshals an osmjson element into the object
+// for its type attribute, e.g. {"type":"node",...} into an *osm.Node.
+// Nodes, ways, relations, changesets, notes and users are supported.
+// A nil object is returned for other types, e.g. the area and count
+// elements in Overpass results, so they can be skipped.
+func UnmarshalJSONObject(data []byte) (Object, error) {
+	t := jsonObject{}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, err
+	}
+
+	var o Object
+	switch t.Type {
+	case TypeNode:
+		o = &Node{}
+	case TypeWay:
+		o = &Way{}
+	case TypeRelation:
+		o = &Relation{}
+	case TypeChangeset:
+		o = &Changeset{}
+	case TypeNote:
+		o = &Note{}
+	case TypeUser:
+		o = &User{}
+	case "":
+		return nil, errors.New("osm: json element without a type")
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(data, o); err != nil {
+		return nil, err
+	}
+
+	return o, nil
+}
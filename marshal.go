@@ -31,13 +31,24 @@ func unmarshalNode(encoded *osmpb.Node, ss []string, cs *Changeset) (*Node, erro
 
 	info := encoded.GetInfo()
 	n := &Node{
-		ID: NodeID(encoded.GetId()),
+		ID:          NodeID(encoded.GetId()),
+		User:        ss[info.GetUserSid()],
+		UserID:      UserID(info.GetUserId()),
+		Visible:     info.GetVisible(),
+		Version:     int(info.GetVersion()),
+		ChangesetID: ChangesetID(info.GetChangesetId()),
+		Timestamp:   unixToTime(info.GetTimestamp()),
+		Tags:        tags,
+		Lat:         float64(encoded.GetLat()) / locMultiple,
+		Lon:         float64(encoded.GetLon()) / locMultiple,
 
-		Version: int(info.GetVersion()),
+		Committed: unixToTimePointer(info.GetCommitted()),
+	}
 
-		Tags: tags,
-		Lat:  float64(encoded.GetLat()) / locMultiple,
-		Lon:  float64(encoded.GetLon()) / locMultiple,
+	if cs != nil {
+		n.ChangesetID = cs.ID
+		n.UserID = cs.UserID
+		n.User = cs.User
 	}
 
 	return n, nil
@@ -85,13 +96,34 @@ func unmarshalNodes(encoded *osmpb.DenseNodes, ss []string, cs *Changeset) (Node
 	nodes := make(Nodes, len(encoded.Ids))
 	for i := range encoded.Ids {
 		n := &Node{
-			ID:      NodeID(encoded.Ids[i]),
-			Lat:     float64(encoded.Lats[i]) / locMultiple,
-			Lon:     float64(encoded.Lons[i]) / locMultiple,
-			Version: int(encoded.DenseInfo.Versions[i]),
+			ID:        NodeID(encoded.Ids[i]),
+			Lat:       float64(encoded.Lats[i]) / locMultiple,
+			Lon:       float64(encoded.Lons[i]) / locMultiple,
+			Visible:   encoded.DenseInfo.Visibles[i],
+			Version:   int(encoded.DenseInfo.Versions[i]),
+			Timestamp: unixToTime(encoded.DenseInfo.Timestamps[i]),
 		}
 
 		if i < len(encoded.DenseInfo.Committeds) {
+			n.Committed = unixToTimePointer(encoded.DenseInfo.Committeds[i])
+		}
+
+		if cs != nil {
+			n.ChangesetID = cs.ID
+			n.UserID = cs.UserID
+			n.User = cs.User
+		} else {
+			if len(encoded.DenseInfo.ChangesetIds) > 0 {
+				n.ChangesetID = ChangesetID(encoded.DenseInfo.ChangesetIds[i])
+			}
+
+			if len(encoded.DenseInfo.UserIds) > 0 {
+				n.UserID = UserID(encoded.DenseInfo.UserIds[i])
+			}
+
+			if len(encoded.DenseInfo.UserSids) > 0 {
+				n.User = ss[encoded.DenseInfo.UserSids[i]]
+			}
 		}
 
 		if encoded.KeysVals != nil {
@@ -123,8 +155,15 @@ func marshalWay(way *Way, ss *stringSet, includeChangeset bool) *osmpb.Way {
 		Keys: keys,
 		Vals: vals,
 		Info: &osmpb.Info{
-			Version: int32(way.Version),
+			Version:   int32(way.Version),
+			Timestamp: timeToUnix(way.Timestamp),
+			Visible:   proto.Bool(way.Visible),
 		},
+		Updates: marshalUpdates(way.Updates),
+	}
+
+	if way.Committed != nil {
+		encoded.Info.Committed = timeToUnixPointer(*way.Committed)
 	}
 
 	if len(way.Nodes) > 0 {
@@ -135,6 +174,12 @@ func marshalWay(way *Way, ss *stringSet, includeChangeset bool) *osmpb.Way {
 		}
 	}
 
+	if includeChangeset {
+		encoded.Info.ChangesetId = int64(way.ChangesetID)
+		encoded.Info.UserId = int32(way.UserID)
+		encoded.Info.UserSid = ss.Add(way.User)
+	}
+
 	return encoded
 }
 
@@ -146,16 +191,28 @@ func unmarshalWay(encoded *osmpb.Way, ss []string, cs *Changeset) (*Way, error)
 
 	info := encoded.GetInfo()
 	w := &Way{
-		ID: WayID(encoded.GetId()),
-
-		Version: int(info.GetVersion()),
-
-		Tags: tags,
+		ID:          WayID(encoded.GetId()),
+		User:        ss[info.GetUserSid()],
+		UserID:      UserID(info.GetUserId()),
+		Visible:     info.GetVisible(),
+		Version:     int(info.GetVersion()),
+		ChangesetID: ChangesetID(info.GetChangesetId()),
+		Timestamp:   unixToTime(info.GetTimestamp()),
+		Committed:   unixToTimePointer(info.GetCommitted()),
+		Tags:        tags,
 	}
 
 	w.Nodes = decodeWayNodeIDs(encoded.GetRefs())
 	decodeDenseWayNodes(w.Nodes, encoded.GetDenseMembers())
 
+	w.Updates = unmarshalUpdates(encoded.GetUpdates())
+
+	if cs != nil {
+		w.ChangesetID = cs.ID
+		w.UserID = cs.UserID
+		w.User = cs.User
+	}
+
 	return w, nil
 }
 
@@ -243,6 +300,129 @@ func unmarshalRelation(encoded *osmpb.Relation, ss []string, cs *Changeset) (*Re
 	return r, nil
 }
 
+func marshalNote(note *Note, ss *stringSet) *osmpb.Note {
+	encoded := &osmpb.Note{
+		Id:          int64(note.ID),
+		Lat:         geoToInt64(note.Lat),
+		Lon:         geoToInt64(note.Lon),
+		Url:         note.URL,
+		CommentUrl:  note.CommentURL,
+		CloseUrl:    note.CloseURL,
+		ReopenUrl:   note.ReopenURL,
+		DateCreated: timeToUnix(note.DateCreated.Time),
+		DateClosed:  timeToUnix(note.DateClosed.Time),
+		StatusSid:   ss.Add(string(note.Status)),
+	}
+
+	if len(note.Comments) > 0 {
+		encoded.Comments = make([]*osmpb.NoteComment, len(note.Comments))
+		for i, c := range note.Comments {
+			encoded.Comments[i] = &osmpb.NoteComment{
+				Date:      timeToUnix(c.Date.Time),
+				UserId:    int32(c.UserID),
+				UserSid:   ss.Add(c.User),
+				UserUrl:   c.UserURL,
+				ActionSid: ss.Add(string(c.Action)),
+				Text:      c.Text,
+				Html:      c.HTML,
+			}
+		}
+	}
+
+	return encoded
+}
+
+func unmarshalNote(encoded *osmpb.Note, ss []string) *Note {
+	n := &Note{
+		ID:          NoteID(encoded.GetId()),
+		Lat:         float64(encoded.GetLat()) / locMultiple,
+		Lon:         float64(encoded.GetLon()) / locMultiple,
+		URL:         encoded.GetUrl(),
+		CommentURL:  encoded.GetCommentUrl(),
+		CloseURL:    encoded.GetCloseUrl(),
+		ReopenURL:   encoded.GetReopenUrl(),
+		DateCreated: Date{unixToTime(encoded.GetDateCreated())},
+		DateClosed:  Date{unixToTime(encoded.GetDateClosed())},
+		Status:      NoteStatus(ss[encoded.GetStatusSid()]),
+	}
+
+	if len(encoded.Comments) > 0 {
+		n.Comments = make([]*NoteComment, len(encoded.Comments))
+		for i, c := range encoded.Comments {
+			n.Comments[i] = &NoteComment{
+				Date:    Date{unixToTime(c.GetDate())},
+				UserID:  UserID(c.GetUserId()),
+				User:    ss[c.GetUserSid()],
+				UserURL: c.GetUserUrl(),
+				Action:  NoteCommentAction(ss[c.GetActionSid()]),
+				Text:    c.GetText(),
+				HTML:    c.GetHtml(),
+			}
+		}
+	}
+
+	return n
+}
+
+func marshalUser(user *User, ss *stringSet) *osmpb.User {
+	encoded := &osmpb.User{
+		Id:                     int64(user.ID),
+		Name:                   user.Name,
+		Description:            user.Description,
+		ImgHref:                user.Img.Href,
+		CreatedAt:              timeToUnix(user.CreatedAt),
+		ChangesetsCount:        int32(user.Changesets.Count),
+		TracesCount:            int32(user.Traces.Count),
+		HomeLat:                geoToInt64(user.Home.Lat),
+		HomeLon:                geoToInt64(user.Home.Lon),
+		HomeZoom:               int32(user.Home.Zoom),
+		BlocksReceivedCount:    int32(user.Blocks.Received.Count),
+		BlocksReceivedActive:   int32(user.Blocks.Received.Active),
+		MessagesReceivedCount:  int32(user.Messages.Received.Count),
+		MessagesReceivedUnread: int32(user.Messages.Received.Unread),
+		MessagesSentCount:      int32(user.Messages.Sent.Count),
+	}
+
+	if len(user.Languages) > 0 {
+		encoded.Languages = make([]uint32, len(user.Languages))
+		for i, l := range user.Languages {
+			encoded.Languages[i] = ss.Add(l)
+		}
+	}
+
+	return encoded
+}
+
+func unmarshalUser(encoded *osmpb.User, ss []string) *User {
+	u := &User{
+		ID:          UserID(encoded.GetId()),
+		Name:        encoded.GetName(),
+		Description: encoded.GetDescription(),
+		CreatedAt:   unixToTime(encoded.GetCreatedAt()),
+	}
+
+	u.Img.Href = encoded.GetImgHref()
+	u.Changesets.Count = int(encoded.GetChangesetsCount())
+	u.Traces.Count = int(encoded.GetTracesCount())
+	u.Home.Lat = float64(encoded.GetHomeLat()) / locMultiple
+	u.Home.Lon = float64(encoded.GetHomeLon()) / locMultiple
+	u.Home.Zoom = int(encoded.GetHomeZoom())
+	u.Blocks.Received.Count = int(encoded.GetBlocksReceivedCount())
+	u.Blocks.Received.Active = int(encoded.GetBlocksReceivedActive())
+	u.Messages.Received.Count = int(encoded.GetMessagesReceivedCount())
+	u.Messages.Received.Unread = int(encoded.GetMessagesReceivedUnread())
+	u.Messages.Sent.Count = int(encoded.GetMessagesSentCount())
+
+	if len(encoded.Languages) > 0 {
+		u.Languages = make([]string, len(encoded.Languages))
+		for i, sid := range encoded.Languages {
+			u.Languages[i] = ss[sid]
+		}
+	}
+
+	return u
+}
+
 type denseNodesResult struct {
 	IDs        []int64
 	Lats       []int64
@@ -271,8 +451,15 @@ func denseNodesValues(ns Nodes) denseNodesResult {
 		ds.IDs[i] = int64(n.ID)
 		ds.Lats[i] = geoToInt64(n.Lat)
 		ds.Lons[i] = geoToInt64(n.Lon)
+		ds.Timestamps[i] = n.Timestamp.Unix()
 		ds.Versions[i] = int32(n.Version)
+		ds.Visibles[i] = n.Visible
 		ds.TagCount += len(n.Tags)
+
+		if n.Committed != nil {
+			ds.Committeds[i] = timeToUnix(*n.Committed)
+			cc++
+		}
 	}
 
 	if cc == 0 {
@@ -309,6 +496,12 @@ func nodesChangesetInfo(ns Nodes, ss *stringSet) changesetInfoResult {
 		UserSids:   make([]int32, l),
 	}
 
+	for i, n := range ns {
+		cs.Changesets[i] = int64(n.ChangesetID)
+		cs.UserIDs[i] = int32(n.UserID)
+		cs.UserSids[i] = int32(ss.Add(n.User))
+	}
+
 	return cs
 }
 
@@ -351,6 +544,7 @@ func encodeDenseWayNodes(waynodes WayNodes) *osmpb.DenseMembers {
 		lats[i] = geoToInt64(n.Lat)
 		lons[i] = geoToInt64(n.Lon)
 		versions[i] = int32(n.Version)
+		changesetIDs[i] = int64(n.ChangesetID)
 	}
 
 	return &osmpb.DenseMembers{
@@ -372,6 +566,7 @@ func decodeDenseWayNodes(waynodes WayNodes, encoded *osmpb.DenseMembers) {
 
 	for i := range encoded.Versions {
 		waynodes[i].Version = int(encoded.Versions[i])
+		waynodes[i].ChangesetID = ChangesetID(encoded.ChangesetIds[i])
 		waynodes[i].Lat = float64(encoded.Lats[i]) / locMultiple
 		waynodes[i].Lon = float64(encoded.Lons[i]) / locMultiple
 	}
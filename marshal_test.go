@@ -140,6 +140,82 @@ func TestRelation_Marshal_protobufOrientation(t *testing.T) {
 	checkMarshal(t, o)
 }
 
+func TestOSM_Marshal_notesAndUsers(t *testing.T) {
+	user := &User{
+		ID:          1,
+		Name:        "user",
+		Description: "a description",
+		CreatedAt:   time.Date(2012, 1, 2, 3, 4, 5, 0, time.UTC),
+		Languages:   []string{"en-US", "de"},
+	}
+	user.Img.Href = "https://example.com/img.png"
+	user.Changesets.Count = 10
+	user.Traces.Count = 2
+	user.Home.Lat = 51.5
+	user.Home.Lon = -0.1
+	user.Home.Zoom = 3
+	user.Blocks.Received.Count = 4
+	user.Blocks.Received.Active = 1
+	user.Messages.Received.Count = 5
+	user.Messages.Received.Unread = 2
+	user.Messages.Sent.Count = 6
+
+	o := &OSM{
+		Version:     0.6,
+		Generator:   "osm-go",
+		Copyright:   "copyright",
+		Attribution: "attribution",
+		License:     "license",
+		Nodes: Nodes{
+			{ID: 1, Lat: 1, Lon: 2, Tags: Tags{{Key: "a", Value: "b"}}},
+		},
+		Changesets: Changesets{
+			{
+				ID:        123,
+				User:      "user",
+				UserID:    1,
+				CreatedAt: time.Date(2016, 6, 26, 21, 26, 41, 0, time.UTC),
+				Tags:      Tags{{Key: "comment", Value: "fix"}},
+				Discussion: &ChangesetDiscussion{
+					Comments: []*ChangesetComment{
+						{User: "commenter", UserID: 2, Text: "nice"},
+					},
+				},
+			},
+		},
+		Notes: Notes{
+			{
+				ID:          1302953,
+				Lat:         51.5,
+				Lon:         -0.1,
+				URL:         "https://example.com/notes/1302953",
+				CommentURL:  "https://example.com/notes/1302953/comment",
+				CloseURL:    "https://example.com/notes/1302953/close",
+				DateCreated: Date{time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)},
+				Status:      NoteOpen,
+				Comments: []*NoteComment{
+					{
+						Date:    Date{time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)},
+						UserID:  1,
+						User:    "user",
+						UserURL: "https://example.com/user/user",
+						Action:  NoteCommentOpened,
+						Text:    "a note",
+						HTML:    "<p>a note</p>",
+					},
+				},
+			},
+		},
+		Users: Users{user},
+	}
+
+	checkMarshal(t, o)
+
+	// no header attributes
+	o = &OSM{Notes: Notes{{ID: 1}}, Users: Users{{ID: 2}}}
+	checkMarshal(t, o)
+}
+
 func BenchmarkChange_MarshalXML(b *testing.B) {
 	filename := "testdata/changeset_38162206.osc"
 	data := readFile(b, filename)
@@ -0,0 +1,28 @@
+package osm
+
+// MetadataMask is a set of element metadata fields. Scanners use it to
+// skip decoding the metadata of nodes, ways and relations that is not needed.
+// The id, version, tags and geometry are always decoded.
+type MetadataMask uint8
+
+// These are the metadata fields that can be skipped when scanning.
+const (
+	MetadataTimestamp MetadataMask = 1 << iota
+	MetadataChangeset
+	MetadataUserID
+	MetadataUser
+	MetadataVisible
+
+	// MetadataNone is the empty set.
+	MetadataNone MetadataMask = 0
+
+	// MetadataAll includes all the metadata fields. Skipping it will
+	// result in elements with only ids, versions, tags and geometry.
+	MetadataAll = MetadataTimestamp | MetadataChangeset |
+		MetadataUserID | MetadataUser | MetadataVisible
+)
+
+// Has returns true if the mask includes all the given fields.
+func (m MetadataMask) Has(fields MetadataMask) bool {
+	return m&fields == fields
+}
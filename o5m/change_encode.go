@@ -0,0 +1,110 @@
+package o5m
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ich5003/small-osm"
+)
+
+// ChangeEncoder writes a stream of changes in the o5c format. Deleted
+// elements are written with only their id and version information,
+// created and modified elements are written in full. The format does not
+// distinguish between create and modify, see ChangeScanner.
+//
+// The ChangeEncoder is not safe for parallel use.
+type ChangeEncoder struct {
+	// Timestamp is the file timestamp, it is not written if zero.
+	// It can be modified until the first object is encoded.
+	Timestamp time.Time
+
+	e      *encoder
+	closed bool
+	err    error
+}
+
+// NewChangeEncoder returns a new change encoder that writes to w.
+func NewChangeEncoder(w io.Writer) *ChangeEncoder {
+	return &ChangeEncoder{e: newEncoder(w, headerO5C)}
+}
+
+// Encode writes the node, way or relation with the action.
+func (e *ChangeEncoder) Encode(action osm.ActionType, o osm.Object) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	switch action {
+	case osm.ActionCreate, osm.ActionModify, osm.ActionDelete:
+	default:
+		return fmt.Errorf("o5m: unsupported action: %q", action)
+	}
+
+	switch o.(type) {
+	case *osm.Node, *osm.Way, *osm.Relation:
+	default:
+		return fmt.Errorf("o5m: unsupported object type: %T", o)
+	}
+
+	e.err = e.e.encode(o, action == osm.ActionDelete, e.Timestamp)
+	return e.err
+}
+
+// EncodeChange writes all the elements of the change. The elements are
+// written in create, modify, delete order.
+func (e *ChangeEncoder) EncodeChange(c *osm.Change) error {
+	actions := []struct {
+		action osm.ActionType
+		osm    *osm.OSM
+	}{
+		{osm.ActionCreate, c.Create},
+		{osm.ActionModify, c.Modify},
+		{osm.ActionDelete, c.Delete},
+	}
+
+	for _, a := range actions {
+		if a.osm == nil {
+			continue
+		}
+
+		for _, o := range a.osm.Elements() {
+			if err := e.Encode(a.action, o); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// EncodeScanner encodes all the elements returned by the change scanner
+// with their action. The scanner is not closed.
+func (e *ChangeEncoder) EncodeScanner(s *ChangeScanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Action(), s.Object()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close writes the end of file marker and flushes any buffered data.
+// It does not close the underlying writer.
+func (e *ChangeEncoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+	e.closed = true
+
+	if e.err != nil {
+		return e.err
+	}
+
+	return e.e.close(e.Timestamp)
+}
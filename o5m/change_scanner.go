@@ -0,0 +1,158 @@
+package o5m
+
+import (
+	"context"
+	"io"
+
+	"github.com/ich5003/small-osm"
+)
+
+var _ osm.Scanner = &ChangeScanner{}
+
+// ChangeScanner reads a stream of o5c change data. Successive calls to the
+// Scan method will step through the elements and their action. The o5c format
+// only records if an element was deleted, so the action of the remaining
+// elements is create for version 1 and modify otherwise.
+//
+// Scanning stops unrecoverably at EOF, the first I/O error, the first
+// decoding error or the context being cancelled.
+type ChangeScanner struct {
+	ctx    context.Context
+	done   context.CancelFunc
+	closed bool
+
+	decoder *decoder
+	action  osm.ActionType
+	next    osm.Object
+	err     error
+}
+
+// NewChangeScanner returns a new ChangeScanner to read from r.
+func NewChangeScanner(ctx context.Context, r io.Reader) *ChangeScanner {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s := &ChangeScanner{
+		decoder: newDecoder(r),
+	}
+
+	s.ctx, s.done = context.WithCancel(ctx)
+	return s
+}
+
+// Close causes all future calls to Scan to return false.
+// Does not close the underlying reader.
+func (s *ChangeScanner) Close() error {
+	s.closed = true
+	s.done()
+
+	return nil
+}
+
+// Scan advances the ChangeScanner to the next element, which will then be available
+// through the Object and Action methods. Bounds are skipped. It returns false
+// when the scan stops, either by reaching the end of the input, an io error,
+// a decoding error or the context being cancelled. After Scan returns false,
+// the Err method will return any error that occurred during scanning,
+// except if it was io.EOF, Err will return nil.
+func (s *ChangeScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		if s.ctx.Err() != nil {
+			return false
+		}
+
+		var deleted bool
+		s.next, deleted, s.err = s.decoder.next()
+		if s.err != nil {
+			return false
+		}
+
+		if _, ok := s.next.(*osm.Bounds); ok {
+			continue
+		}
+
+		s.action = elementAction(s.next, deleted)
+		return true
+	}
+}
+
+// elementAction returns the change action of a decoded element.
+func elementAction(o osm.Object, deleted bool) osm.ActionType {
+	if deleted {
+		return osm.ActionDelete
+	}
+
+	var version int
+	switch o := o.(type) {
+	case *osm.Node:
+		version = o.Version
+	case *osm.Way:
+		version = o.Version
+	case *osm.Relation:
+		version = o.Version
+	}
+
+	if version == 1 {
+		return osm.ActionCreate
+	}
+
+	return osm.ActionModify
+}
+
+// Object returns the most recent element generated by a call to Scan.
+// This interface is implemented by *osm.Node, *osm.Way and *osm.Relation.
+func (s *ChangeScanner) Object() osm.Object {
+	return s.next
+}
+
+// Action returns the action of the most recent element generated
+// by a call to Scan.
+func (s *ChangeScanner) Action() osm.ActionType {
+	return s.action
+}
+
+// Err returns the first non-EOF error that was encountered by the ChangeScanner.
+func (s *ChangeScanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.closed {
+		return osm.ErrScannerClosed
+	}
+
+	return s.ctx.Err()
+}
+
+// ReadChange reads all of the o5c data into an osm.Change.
+func ReadChange(ctx context.Context, r io.Reader) (*osm.Change, error) {
+	s := NewChangeScanner(ctx, r)
+	defer s.Close()
+
+	c := &osm.Change{Version: 0.6}
+	for s.Scan() {
+		switch s.Action() {
+		case osm.ActionCreate:
+			c.AppendCreate(s.Object())
+		case osm.ActionModify:
+			c.AppendModify(s.Object())
+		case osm.ActionDelete:
+			c.AppendDelete(s.Object())
+		}
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
@@ -0,0 +1,152 @@
+package o5m
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestReadChange(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/minute_871.osc")
+	if err != nil {
+		t.Fatalf("unable to read file: %v", err)
+	}
+
+	change := &osm.Change{}
+	if err := xml.Unmarshal(data, change); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewChangeEncoder(buf)
+	if err := enc.EncodeChange(change); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	c, err := ReadChange(context.Background(), buf)
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+
+	// create and modify are distinguished by the version
+	expected := &osm.Change{Version: 0.6}
+	for _, o := range append(change.Create.Elements(), change.Modify.Elements()...) {
+		var version int
+		switch o := o.(type) {
+		case *osm.Node:
+			o.Visible = true
+			version = o.Version
+		case *osm.Way:
+			o.Visible = true
+			version = o.Version
+		case *osm.Relation:
+			o.Visible = true
+			version = o.Version
+		}
+
+		if version == 1 {
+			expected.AppendCreate(o)
+		} else {
+			expected.AppendModify(o)
+		}
+	}
+
+	// deleted elements only have their id and version information
+	for _, o := range change.Delete.Elements() {
+		switch o := o.(type) {
+		case *osm.Node:
+			o.Lat, o.Lon, o.Tags = 0, 0, nil
+		case *osm.Way:
+			o.Nodes, o.Tags = nil, nil
+		case *osm.Relation:
+			o.Members, o.Tags = nil, nil
+		}
+
+		expected.AppendDelete(o)
+	}
+
+	actions := []struct {
+		name           string
+		result, expect *osm.OSM
+	}{
+		{"create", c.Create, expected.Create},
+		{"modify", c.Modify, expected.Modify},
+		{"delete", c.Delete, expected.Delete},
+	}
+
+	for _, a := range actions {
+		if len(a.result.Elements()) != len(a.expect.Elements()) {
+			t.Fatalf("incorrect number of %s elements: %v != %v",
+				a.name, len(a.result.Elements()), len(a.expect.Elements()))
+		}
+
+		if !reflect.DeepEqual(a.result, a.expect) {
+			t.Errorf("incorrect %s elements", a.name)
+		}
+	}
+}
+
+func TestChangeScanner(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := NewChangeEncoder(buf)
+
+	changes := []struct {
+		action osm.ActionType
+		object osm.Object
+	}{
+		{osm.ActionCreate, &osm.Node{ID: -1, Version: 1, Visible: true, Lat: 1, Lon: 2}},
+		{osm.ActionModify, &osm.Node{ID: 2, Version: 4, Visible: true, Lat: 3, Lon: 4}},
+		{osm.ActionDelete, &osm.Way{ID: 3, Version: 2}},
+		{osm.ActionModify, &osm.Relation{ID: 4, Version: 2, Visible: true}},
+	}
+
+	for _, c := range changes {
+		if err := enc.Encode(c.action, c.object); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Encode("unknown", &osm.Node{}); err == nil {
+		t.Errorf("should error for unsupported action")
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	scanner := NewChangeScanner(context.Background(), buf)
+	defer scanner.Close()
+
+	i := 0
+	for scanner.Scan() {
+		if i >= len(changes) {
+			t.Fatalf("too many objects")
+		}
+
+		if a := scanner.Action(); a != changes[i].action {
+			t.Errorf("incorrect action: %v != %v", a, changes[i].action)
+		}
+
+		if o := scanner.Object(); !reflect.DeepEqual(o, changes[i].object) {
+			t.Errorf("incorrect object: %+v", o)
+		}
+		i++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	if i != len(changes) {
+		t.Errorf("incorrect number of objects: %v != %v", i, len(changes))
+	}
+}
@@ -0,0 +1,390 @@
+package o5m
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ich5003/small-osm"
+)
+
+// decoder reads the datasets of an o5m or o5c stream and keeps
+// the delta coding and string table state between them.
+type decoder struct {
+	r *bufio.Reader
+
+	started       bool
+	change        bool
+	fileTimestamp time.Time
+
+	table readTable
+	data  []byte
+
+	ids       [3]int64
+	timestamp int64
+	changeset int64
+	lon, lat  int64
+	wayNode   int64
+	members   [3]int64
+}
+
+func newDecoder(r io.Reader) *decoder {
+	return &decoder{r: bufio.NewReaderSize(r, 64*1024)}
+}
+
+// reset clears the delta coding and string table state.
+func (d *decoder) reset() {
+	d.table.reset()
+	d.ids = [3]int64{}
+	d.timestamp = 0
+	d.changeset = 0
+	d.lon, d.lat = 0, 0
+	d.wayNode = 0
+	d.members = [3]int64{}
+}
+
+// next returns the next object and if it was deleted, i.e. only the id
+// and version information is present. Returns io.EOF at the end of the stream.
+func (d *decoder) next() (osm.Object, bool, error) {
+	if !d.started {
+		d.started = true
+
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return nil, false, err
+		}
+
+		if b != datasetReset {
+			return nil, false, fmt.Errorf("o5m: invalid start of file: 0x%02x", b)
+		}
+		d.reset()
+	}
+
+	for {
+		t, err := d.r.ReadByte()
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch {
+		case t == datasetReset:
+			d.reset()
+			continue
+		case t == datasetEOF:
+			return nil, false, io.EOF
+		case t > 0xef:
+			// other single byte datasets have no length
+			continue
+		}
+
+		l, err := binary.ReadUvarint(d.r)
+		if err != nil {
+			return nil, false, unexpectedEOF(err)
+		}
+
+		if l > maxDatasetSize {
+			return nil, false, fmt.Errorf("o5m: dataset too large: %d", l)
+		}
+
+		if cap(d.data) < int(l) {
+			d.data = make([]byte, l)
+		}
+		d.data = d.data[:l]
+
+		if _, err := io.ReadFull(d.r, d.data); err != nil {
+			return nil, false, unexpectedEOF(err)
+		}
+
+		b := &buffer{data: d.data}
+		switch t {
+		case datasetNode:
+			return d.decodeNode(b)
+		case datasetWay:
+			return d.decodeWay(b)
+		case datasetRelation:
+			return d.decodeRelation(b)
+		case datasetBounds:
+			bounds, err := decodeBounds(b)
+			return bounds, false, err
+		case datasetTimestamp:
+			ts, err := b.svarint()
+			if err != nil {
+				return nil, false, err
+			}
+			d.fileTimestamp = time.Unix(ts, 0).UTC()
+		case datasetHeader:
+			switch string(d.data) {
+			case headerO5M:
+				d.change = false
+			case headerO5C:
+				d.change = true
+			default:
+				return nil, false, fmt.Errorf("o5m: unsupported file type: %q", d.data)
+			}
+		}
+
+		// other datasets, e.g. sync and jump, are skipped
+	}
+}
+
+// info is the version information of an element.
+type info struct {
+	Version     int
+	Timestamp   time.Time
+	ChangesetID osm.ChangesetID
+	UserID      osm.UserID
+	User        string
+}
+
+// decodeID reads the delta coded id and the version information.
+func (d *decoder) decodeID(b *buffer, t int) (int64, info, error) {
+	delta, err := b.svarint()
+	if err != nil {
+		return 0, info{}, err
+	}
+	d.ids[t] += delta
+
+	i := info{}
+	version, err := b.uvarint()
+	if err != nil || version == 0 {
+		return d.ids[t], i, err
+	}
+	i.Version = int(version)
+
+	delta, err = b.svarint()
+	if err != nil {
+		return 0, i, err
+	}
+
+	d.timestamp += delta
+	if d.timestamp == 0 {
+		return d.ids[t], i, nil
+	}
+	i.Timestamp = time.Unix(d.timestamp, 0).UTC()
+
+	delta, err = b.svarint()
+	if err != nil {
+		return 0, i, err
+	}
+	d.changeset += delta
+	i.ChangesetID = osm.ChangesetID(d.changeset)
+
+	uid, user, err := b.pair(&d.table)
+	if err != nil {
+		return 0, i, err
+	}
+
+	if uid != "" {
+		v, n := binary.Uvarint([]byte(uid))
+		if n <= 0 {
+			return 0, i, fmt.Errorf("o5m: invalid user id: %q", uid)
+		}
+		i.UserID = osm.UserID(v)
+	}
+	i.User = user
+
+	return d.ids[t], i, nil
+}
+
+func (d *decoder) decodeNode(b *buffer) (osm.Object, bool, error) {
+	id, i, err := d.decodeID(b, 0)
+	if err != nil {
+		return nil, false, err
+	}
+
+	n := &osm.Node{
+		ID:          osm.NodeID(id),
+		Version:     i.Version,
+		Timestamp:   i.Timestamp,
+		ChangesetID: i.ChangesetID,
+		UserID:      i.UserID,
+		User:        i.User,
+	}
+
+	if b.done() {
+		return n, true, nil
+	}
+	n.Visible = true
+
+	delta, err := b.svarint()
+	if err != nil {
+		return nil, false, err
+	}
+	d.lon += delta
+
+	delta, err = b.svarint()
+	if err != nil {
+		return nil, false, err
+	}
+	d.lat += delta
+
+	n.Lon = float64(d.lon) / coordinateFactor
+	n.Lat = float64(d.lat) / coordinateFactor
+
+	n.Tags, err = d.decodeTags(b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return n, false, nil
+}
+
+func (d *decoder) decodeWay(b *buffer) (osm.Object, bool, error) {
+	id, i, err := d.decodeID(b, 1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	w := &osm.Way{
+		ID:          osm.WayID(id),
+		Version:     i.Version,
+		Timestamp:   i.Timestamp,
+		ChangesetID: i.ChangesetID,
+		UserID:      i.UserID,
+		User:        i.User,
+	}
+
+	if b.done() {
+		return w, true, nil
+	}
+	w.Visible = true
+
+	end, err := refsEnd(b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for b.pos < end {
+		delta, err := b.svarint()
+		if err != nil {
+			return nil, false, err
+		}
+		d.wayNode += delta
+
+		w.Nodes = append(w.Nodes, osm.WayNode{ID: osm.NodeID(d.wayNode)})
+	}
+
+	w.Tags, err = d.decodeTags(b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return w, false, nil
+}
+
+func (d *decoder) decodeRelation(b *buffer) (osm.Object, bool, error) {
+	id, i, err := d.decodeID(b, 2)
+	if err != nil {
+		return nil, false, err
+	}
+
+	r := &osm.Relation{
+		ID:          osm.RelationID(id),
+		Version:     i.Version,
+		Timestamp:   i.Timestamp,
+		ChangesetID: i.ChangesetID,
+		UserID:      i.UserID,
+		User:        i.User,
+	}
+
+	if b.done() {
+		return r, true, nil
+	}
+	r.Visible = true
+
+	end, err := refsEnd(b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for b.pos < end {
+		// the ref is delta coded per member type which comes after it
+		delta, err := b.svarint()
+		if err != nil {
+			return nil, false, err
+		}
+
+		s, err := b.single(&d.table)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if s == "" || s[0] < '0' || s[0] > '2' {
+			return nil, false, fmt.Errorf("o5m: invalid member type and role: %q", s)
+		}
+
+		t := int(s[0] - '0')
+		d.members[t] += delta
+
+		r.Members = append(r.Members, osm.Member{
+			Type: memberTypes[t],
+			Ref:  d.members[t],
+			Role: s[1:],
+		})
+	}
+
+	r.Tags, err = d.decodeTags(b)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return r, false, nil
+}
+
+// refsEnd reads the length of the way nodes or relation members section
+// and returns the position of its end.
+func refsEnd(b *buffer) (int, error) {
+	l, err := b.uvarint()
+	if err != nil {
+		return 0, err
+	}
+
+	end := b.pos + int(l)
+	if end > len(b.data) {
+		return 0, errShortDataset
+	}
+
+	return end, nil
+}
+
+func (d *decoder) decodeTags(b *buffer) (osm.Tags, error) {
+	var tags osm.Tags
+	for !b.done() {
+		k, v, err := b.pair(&d.table)
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, osm.Tag{Key: k, Value: v})
+	}
+
+	return tags, nil
+}
+
+func decodeBounds(b *buffer) (*osm.Bounds, error) {
+	var v [4]int64
+	for i := range v {
+		var err error
+		if v[i], err = b.svarint(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &osm.Bounds{
+		MinLon: float64(v[0]) / coordinateFactor,
+		MinLat: float64(v[1]) / coordinateFactor,
+		MaxLon: float64(v[2]) / coordinateFactor,
+		MaxLat: float64(v[3]) / coordinateFactor,
+	}, nil
+}
+
+// unexpectedEOF converts an io.EOF in the middle of a dataset.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
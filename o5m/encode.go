@@ -0,0 +1,408 @@
+package o5m
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/ich5003/small-osm"
+)
+
+// ErrEncoderClosed is returned when encoding after the encoder is closed.
+var ErrEncoderClosed = errors.New("o5m: encoder closed")
+
+// Encoder writes a stream of osm data in the o5m format. A reset is written
+// whenever the element type changes, so nodes, ways and relations should be
+// encoded in that order for the smallest output.
+//
+// The Encoder is not safe for parallel use.
+type Encoder struct {
+	// Timestamp is the file timestamp, it is not written if zero.
+	// It can be modified until the first object is encoded.
+	Timestamp time.Time
+
+	e      *encoder
+	closed bool
+	err    error
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{e: newEncoder(w, headerO5M)}
+}
+
+// Encode writes the object to the stream. The interface is implemented by:
+//	*osm.Bounds
+//	*osm.Node
+//	*osm.Way
+//	*osm.Relation
+// Deleted versions, elements that are not visible and have no tags, location,
+// nodes or members, are written with only their id and version information.
+// The data is checked since not all sources set Visible, e.g. osm xml
+// without the visible attribute.
+func (e *Encoder) Encode(o osm.Object) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	e.err = e.e.encode(o, deleted(o), e.Timestamp)
+	return e.err
+}
+
+// EncodeScanner encodes all the objects returned by the scanner.
+// The scanner is not closed.
+func (e *Encoder) EncodeScanner(s osm.Scanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Object()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close writes the end of file marker and flushes any buffered data.
+// It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+	e.closed = true
+
+	if e.err != nil {
+		return e.err
+	}
+
+	return e.e.close(e.Timestamp)
+}
+
+// encoder writes the datasets of an o5m or o5c stream and keeps
+// the delta coding and string table state between them.
+type encoder struct {
+	w      *bufio.Writer
+	header string
+
+	started bool
+	current osm.Type
+
+	table writeTable
+	data  []byte
+	refs  []byte
+
+	ids       [3]int64
+	timestamp int64
+	changeset int64
+	lon, lat  int64
+	wayNode   int64
+	members   [3]int64
+}
+
+func newEncoder(w io.Writer, header string) *encoder {
+	return &encoder{
+		w:      bufio.NewWriterSize(w, 64*1024),
+		header: header,
+	}
+}
+
+// start writes the reset, header and file timestamp datasets.
+func (e *encoder) start(timestamp time.Time) error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	if err := e.w.WriteByte(datasetReset); err != nil {
+		return err
+	}
+
+	if err := e.dataset(datasetHeader, []byte(e.header)); err != nil {
+		return err
+	}
+
+	if timestamp.IsZero() {
+		return nil
+	}
+
+	return e.dataset(datasetTimestamp, appendSvarint(nil, timestamp.Unix()))
+}
+
+// reset writes a reset marker and clears the delta coding and string table state.
+func (e *encoder) reset() error {
+	e.table.reset()
+	e.ids = [3]int64{}
+	e.timestamp = 0
+	e.changeset = 0
+	e.lon, e.lat = 0, 0
+	e.wayNode = 0
+	e.members = [3]int64{}
+
+	return e.w.WriteByte(datasetReset)
+}
+
+func (e *encoder) dataset(t byte, data []byte) error {
+	if err := e.w.WriteByte(t); err != nil {
+		return err
+	}
+
+	if _, err := e.w.Write(appendUvarint(nil, uint64(len(data)))); err != nil {
+		return err
+	}
+
+	_, err := e.w.Write(data)
+	return err
+}
+
+// encode writes the object, deleted elements are written with only
+// their id and version information.
+func (e *encoder) encode(o osm.Object, deleted bool, timestamp time.Time) error {
+	var (
+		t  byte
+		ot osm.Type
+	)
+
+	switch o.(type) {
+	case *osm.Bounds:
+		t = datasetBounds
+	case *osm.Node:
+		t, ot = datasetNode, osm.TypeNode
+	case *osm.Way:
+		t, ot = datasetWay, osm.TypeWay
+	case *osm.Relation:
+		t, ot = datasetRelation, osm.TypeRelation
+	default:
+		return fmt.Errorf("o5m: unsupported object type: %T", o)
+	}
+
+	if err := e.start(timestamp); err != nil {
+		return err
+	}
+
+	if ot != "" && ot != e.current {
+		if e.current != "" {
+			if err := e.reset(); err != nil {
+				return err
+			}
+		}
+		e.current = ot
+	}
+
+	var err error
+	switch o := o.(type) {
+	case *osm.Bounds:
+		e.data = e.encodeBounds(e.data[:0], o)
+	case *osm.Node:
+		e.data, err = e.encodeNode(e.data[:0], o, deleted)
+	case *osm.Way:
+		e.data, err = e.encodeWay(e.data[:0], o, deleted)
+	case *osm.Relation:
+		e.data, err = e.encodeRelation(e.data[:0], o, deleted)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return e.dataset(t, e.data)
+}
+
+// deleted returns true if the object is a deleted version of an element.
+func deleted(o osm.Object) bool {
+	switch o := o.(type) {
+	case *osm.Node:
+		return !o.Visible && len(o.Tags) == 0 && o.Lat == 0 && o.Lon == 0
+	case *osm.Way:
+		return !o.Visible && len(o.Tags) == 0 && len(o.Nodes) == 0
+	case *osm.Relation:
+		return !o.Visible && len(o.Tags) == 0 && len(o.Members) == 0
+	}
+
+	return false
+}
+
+// close writes the end of file marker and flushes the buffered data.
+func (e *encoder) close(timestamp time.Time) error {
+	if err := e.start(timestamp); err != nil {
+		return err
+	}
+
+	if err := e.w.WriteByte(datasetEOF); err != nil {
+		return err
+	}
+
+	return e.w.Flush()
+}
+
+func (e *encoder) encodeID(b []byte, t int, id int64, i info) ([]byte, error) {
+	b = appendSvarint(b, id-e.ids[t])
+	e.ids[t] = id
+
+	if i.Version == 0 {
+		return append(b, 0), nil
+	}
+	b = appendUvarint(b, uint64(i.Version))
+
+	var ts int64
+	if !i.Timestamp.IsZero() {
+		ts = i.Timestamp.Unix()
+	}
+
+	b = appendSvarint(b, ts-e.timestamp)
+	e.timestamp = ts
+	if ts == 0 {
+		return b, nil
+	}
+
+	b = appendSvarint(b, int64(i.ChangesetID)-e.changeset)
+	e.changeset = int64(i.ChangesetID)
+
+	var uid string
+	if i.UserID != 0 {
+		uid = string(appendUvarint(nil, uint64(i.UserID)))
+	}
+
+	return e.appendPair(b, uid, i.User)
+}
+
+func (e *encoder) encodeNode(b []byte, n *osm.Node, deleted bool) ([]byte, error) {
+	b, err := e.encodeID(b, 0, int64(n.ID), info{
+		Version:     n.Version,
+		Timestamp:   n.Timestamp,
+		ChangesetID: n.ChangesetID,
+		UserID:      n.UserID,
+		User:        n.User,
+	})
+	if err != nil || deleted {
+		return b, err
+	}
+
+	lon := coordinate(n.Lon)
+	b = appendSvarint(b, lon-e.lon)
+	e.lon = lon
+
+	lat := coordinate(n.Lat)
+	b = appendSvarint(b, lat-e.lat)
+	e.lat = lat
+
+	return e.appendTags(b, n.Tags)
+}
+
+func (e *encoder) encodeWay(b []byte, w *osm.Way, deleted bool) ([]byte, error) {
+	b, err := e.encodeID(b, 1, int64(w.ID), info{
+		Version:     w.Version,
+		Timestamp:   w.Timestamp,
+		ChangesetID: w.ChangesetID,
+		UserID:      w.UserID,
+		User:        w.User,
+	})
+	if err != nil || deleted {
+		return b, err
+	}
+
+	e.refs = e.refs[:0]
+	for _, wn := range w.Nodes {
+		e.refs = appendSvarint(e.refs, int64(wn.ID)-e.wayNode)
+		e.wayNode = int64(wn.ID)
+	}
+
+	b = appendUvarint(b, uint64(len(e.refs)))
+	b = append(b, e.refs...)
+
+	return e.appendTags(b, w.Tags)
+}
+
+func (e *encoder) encodeRelation(b []byte, r *osm.Relation, deleted bool) ([]byte, error) {
+	b, err := e.encodeID(b, 2, int64(r.ID), info{
+		Version:     r.Version,
+		Timestamp:   r.Timestamp,
+		ChangesetID: r.ChangesetID,
+		UserID:      r.UserID,
+		User:        r.User,
+	})
+	if err != nil || deleted {
+		return b, err
+	}
+
+	e.refs = e.refs[:0]
+	for _, m := range r.Members {
+		t, err := memberTypeIndex(m.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		e.refs = appendSvarint(e.refs, m.Ref-e.members[t])
+		e.members[t] = m.Ref
+
+		e.refs, err = e.appendSingle(e.refs, string('0'+byte(t))+m.Role)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b = appendUvarint(b, uint64(len(e.refs)))
+	b = append(b, e.refs...)
+
+	return e.appendTags(b, r.Tags)
+}
+
+func (e *encoder) encodeBounds(b []byte, bounds *osm.Bounds) []byte {
+	b = appendSvarint(b, coordinate(bounds.MinLon))
+	b = appendSvarint(b, coordinate(bounds.MinLat))
+	b = appendSvarint(b, coordinate(bounds.MaxLon))
+	return appendSvarint(b, coordinate(bounds.MaxLat))
+}
+
+func (e *encoder) appendTags(b []byte, tags osm.Tags) ([]byte, error) {
+	var err error
+	for _, t := range tags {
+		b, err = e.appendPair(b, t.Key, t.Value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return b, nil
+}
+
+// appendPair writes the string pair inline or as a table reference.
+func (e *encoder) appendPair(b []byte, s1, s2 string) ([]byte, error) {
+	if strings.IndexByte(s1, 0) >= 0 || strings.IndexByte(s2, 0) >= 0 {
+		return nil, fmt.Errorf("o5m: string contains a zero byte: %q", s1+s2)
+	}
+
+	if ref := e.table.ref(s1+"\x00"+s2, len(s1)+len(s2)); ref != 0 {
+		return appendUvarint(b, uint64(ref)), nil
+	}
+
+	b = append(b, 0)
+	b = append(b, s1...)
+	b = append(b, 0)
+	b = append(b, s2...)
+	return append(b, 0), nil
+}
+
+// appendSingle writes the single string inline or as a table reference.
+func (e *encoder) appendSingle(b []byte, s string) ([]byte, error) {
+	if strings.IndexByte(s, 0) >= 0 {
+		return nil, fmt.Errorf("o5m: string contains a zero byte: %q", s)
+	}
+
+	if ref := e.table.ref(s, len(s)); ref != 0 {
+		return appendUvarint(b, uint64(ref)), nil
+	}
+
+	b = append(b, 0)
+	b = append(b, s...)
+	return append(b, 0), nil
+}
+
+func coordinate(v float64) int64 {
+	return int64(math.Round(v * coordinateFactor))
+}
@@ -0,0 +1,253 @@
+package o5m
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmpbf"
+)
+
+func TestEncoder(t *testing.T) {
+	ts := time.Date(2021, 4, 13, 12, 0, 0, 0, time.UTC)
+	expected := osm.Objects{
+		&osm.Bounds{MinLon: -1.5, MinLat: -2.5, MaxLon: 1.5, MaxLat: 2.5},
+		&osm.Node{
+			ID: 1, Lon: -1.2345678, Lat: 2.3456789, Visible: true,
+			Version: 3, Timestamp: ts, ChangesetID: 10, UserID: 100, User: "user",
+			Tags: osm.Tags{{Key: "amenity", Value: "cafe"}, {Key: "name", Value: "café"}},
+		},
+		&osm.Node{
+			ID: 2, Lon: 1.2, Lat: -0.5, Visible: true,
+			Version: 1, Timestamp: ts.Add(time.Hour), ChangesetID: 11,
+			Tags: osm.Tags{{Key: "amenity", Value: "cafe"}},
+		},
+		&osm.Node{ID: 3, Lon: 1, Lat: 1, Visible: true},
+		&osm.Way{
+			ID: 10, Visible: true,
+			Version: 2, Timestamp: ts, ChangesetID: 10, UserID: 100, User: "user",
+			Nodes: osm.WayNodes{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 1}},
+			Tags:  osm.Tags{{Key: "building", Value: "yes"}},
+		},
+		&osm.Way{ID: 11, Visible: true},
+		&osm.Relation{
+			ID: 20, Visible: true,
+			Version: 1, Timestamp: ts, ChangesetID: 12, UserID: 100, User: "user",
+			Members: osm.Members{
+				{Type: osm.TypeWay, Ref: 10, Role: "outer"},
+				{Type: osm.TypeNode, Ref: 3, Role: "label"},
+				{Type: osm.TypeRelation, Ref: 21},
+				{Type: osm.TypeWay, Ref: 11, Role: "outer"},
+			},
+			Tags: osm.Tags{{Key: "type", Value: "multipolygon"}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	enc.Timestamp = ts
+
+	for _, o := range expected {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	scanner := New(context.Background(), buf)
+	defer scanner.Close()
+
+	objects := scanAll(t, scanner)
+	if len(objects) != len(expected) {
+		t.Fatalf("incorrect number of objects: %v != %v", len(objects), len(expected))
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(objects[i], expected[i]) {
+			t.Errorf("incorrect object %d", i)
+			t.Logf("%+v", objects[i])
+			t.Logf("%+v", expected[i])
+		}
+	}
+
+	if v := scanner.Timestamp(); !v.Equal(ts) {
+		t.Errorf("incorrect file timestamp: %v", v)
+	}
+}
+
+func TestEncoder_deleted(t *testing.T) {
+	ts := time.Date(2021, 4, 13, 12, 0, 0, 0, time.UTC)
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	err := enc.EncodeScanner(New(context.Background(), bytes.NewReader(testFile)))
+	if err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	history := osm.Objects{
+		&osm.Node{ID: 9, Lon: 1, Lat: 2, Visible: true, Version: 1, Timestamp: ts, ChangesetID: 10},
+		&osm.Node{ID: 9, Visible: false, Version: 2, Timestamp: ts.Add(time.Hour), ChangesetID: 11, UserID: 5, User: "u"},
+		&osm.Way{ID: 10, Visible: false, Version: 3, Timestamp: ts, ChangesetID: 12},
+		&osm.Relation{ID: 11, Visible: false, Version: 2, Timestamp: ts, ChangesetID: 12},
+	}
+
+	for _, o := range history {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	expected := scanAll(t, New(context.Background(), bytes.NewReader(testFile)))
+	expected = append(expected, history...)
+
+	objects := scanAll(t, New(context.Background(), buf))
+	if len(objects) != len(expected) {
+		t.Fatalf("incorrect number of objects: %v != %v", len(objects), len(expected))
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(objects[i], expected[i]) {
+			t.Errorf("incorrect object %d", i)
+			t.Logf("%+v", objects[i])
+			t.Logf("%+v", expected[i])
+		}
+	}
+}
+
+func TestEncoder_stringTable(t *testing.T) {
+	// more unique strings than fit in the table so the references
+	// must wrap around and evicted strings are written inline again.
+	var expected osm.Objects
+	for i := 0; i < 2*tableSize+100; i++ {
+		expected = append(expected, &osm.Node{
+			ID:      osm.NodeID(i + 1),
+			Visible: true,
+			Tags: osm.Tags{
+				{Key: "name", Value: fmt.Sprintf("value %d", i%(tableSize+10))},
+				{Key: "amenity", Value: "cafe"},
+			},
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	for _, o := range expected {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	scanner := New(context.Background(), buf)
+	defer scanner.Close()
+
+	objects := scanAll(t, scanner)
+	if !reflect.DeepEqual(objects, expected) {
+		t.Errorf("objects not equal after round trip")
+	}
+}
+
+func TestEncoder_errors(t *testing.T) {
+	enc := NewEncoder(&bytes.Buffer{})
+
+	err := enc.Encode(&osm.Changeset{ID: 1})
+	if err == nil {
+		t.Errorf("should error for unsupported type")
+	}
+
+	err = enc.Encode(&osm.Node{ID: 1, Tags: osm.Tags{{Key: "a\x00", Value: "b"}}})
+	if err == nil {
+		t.Errorf("should error for zero byte in string")
+	}
+
+	if err := enc.Close(); err == nil {
+		t.Errorf("close should return encode error")
+	}
+
+	if err := enc.Encode(&osm.Node{ID: 2}); err != ErrEncoderClosed {
+		t.Errorf("incorrect error: %v", err)
+	}
+}
+
+func TestEncoder_pbf(t *testing.T) {
+	f, err := os.Open("../testdata/delaware-latest.osm.pbf")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	var expected osm.Objects
+	pbf := osmpbf.New(context.Background(), f, 2)
+	for pbf.Scan() {
+		o := pbf.Object()
+		if n, ok := o.(*osm.Node); ok {
+			// o5m stores coordinates in units of 100 nanodegrees.
+			n.Lon = float64(coordinate(n.Lon)) / coordinateFactor
+			n.Lat = float64(coordinate(n.Lat)) / coordinateFactor
+		}
+
+		expected = append(expected, o)
+	}
+
+	if err := pbf.Err(); err != nil {
+		t.Fatalf("pbf scanner error: %v", err)
+	}
+	pbf.Close()
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	for _, o := range expected {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	scanner := New(context.Background(), buf)
+	defer scanner.Close()
+
+	objects := scanAll(t, scanner)
+	if len(objects) != len(expected) {
+		t.Fatalf("incorrect number of objects: %v != %v", len(objects), len(expected))
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(objects[i], expected[i]) {
+			t.Fatalf("incorrect object %d: %v", i, expected[i].ObjectID())
+		}
+	}
+}
+
+func scanAll(t testing.TB, s osm.Scanner) osm.Objects {
+	t.Helper()
+
+	var objects osm.Objects
+	for s.Scan() {
+		objects = append(objects, s.Object())
+	}
+
+	if err := s.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	return objects
+}
@@ -0,0 +1,234 @@
+// Package o5m provides scanners and encoders for the o5m and o5c binary
+// formats used by osmconvert and osmfilter.
+// See: https://wiki.openstreetmap.org/wiki/O5m
+package o5m
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ich5003/small-osm"
+)
+
+// dataset types
+const (
+	datasetNode      = 0x10
+	datasetWay       = 0x11
+	datasetRelation  = 0x12
+	datasetBounds    = 0xdb
+	datasetTimestamp = 0xdc
+	datasetHeader    = 0xe0
+	datasetEOF       = 0xfe
+	datasetReset     = 0xff
+)
+
+const (
+	headerO5M = "o5m2"
+	headerO5C = "o5c2"
+)
+
+const (
+	// tableSize is the number of string pairs kept in the reference table.
+	tableSize = 15000
+
+	// maxTableString is the longest string, or combined string pair,
+	// that is added to the reference table.
+	maxTableString = 250
+
+	// coordinates are stored in units of 100 nanodegrees.
+	coordinateFactor = 1e7
+
+	// maxDatasetSize is the largest dataset that is read, so a corrupt
+	// length does not allocate all the memory. Way and relation datasets
+	// are usually much smaller.
+	maxDatasetSize = 32 * 1024 * 1024
+)
+
+// memberTypes are the o5m relation member types, the index is the
+// first character of the member's type and role string.
+var memberTypes = [3]osm.Type{osm.TypeNode, osm.TypeWay, osm.TypeRelation}
+
+func memberTypeIndex(t osm.Type) (int, error) {
+	for i, mt := range memberTypes {
+		if mt == t {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("o5m: unsupported member type: %q", t)
+}
+
+var errShortDataset = errors.New("o5m: dataset too short")
+
+// readTable is the string reference table used when decoding.
+// A reference of 1 is the most recently added string pair.
+type readTable struct {
+	entries [tableSize][2]string
+	count   int
+}
+
+func (t *readTable) add(s1, s2 string) {
+	if len(s1)+len(s2) > maxTableString {
+		return
+	}
+
+	t.entries[t.count%tableSize] = [2]string{s1, s2}
+	t.count++
+}
+
+func (t *readTable) get(ref uint64) (string, string, error) {
+	if ref == 0 || ref > tableSize || ref > uint64(t.count) {
+		return "", "", fmt.Errorf("o5m: invalid string reference: %d", ref)
+	}
+
+	e := t.entries[(t.count-int(ref))%tableSize]
+	return e[0], e[1], nil
+}
+
+func (t *readTable) reset() {
+	t.count = 0
+}
+
+// writeTable is the string reference table used when encoding.
+// It mirrors the readTable so the references match when decoded.
+type writeTable struct {
+	index map[string]int
+	keys  [tableSize]string
+	count int
+}
+
+// ref returns the reference for the key, or 0 if the key should be written
+// inline. Inline keys that fit are added to the table.
+func (t *writeTable) ref(key string, length int) int {
+	if t.index == nil {
+		t.index = make(map[string]int)
+	}
+
+	if i, ok := t.index[key]; ok && t.count-i <= tableSize {
+		return t.count - i
+	}
+
+	if length > maxTableString {
+		return 0
+	}
+
+	// evict the entry being replaced unless it was added again since
+	slot := t.count % tableSize
+	if old := t.keys[slot]; t.count >= tableSize && t.index[old] == t.count-tableSize {
+		delete(t.index, old)
+	}
+
+	t.keys[slot] = key
+	t.index[key] = t.count
+	t.count++
+
+	return 0
+}
+
+func (t *writeTable) reset() {
+	t.index = nil
+	t.count = 0
+}
+
+// buffer reads the values of a dataset.
+type buffer struct {
+	data []byte
+	pos  int
+}
+
+func (b *buffer) done() bool {
+	return b.pos >= len(b.data)
+}
+
+func (b *buffer) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(b.data[b.pos:])
+	if n <= 0 {
+		return 0, errShortDataset
+	}
+
+	b.pos += n
+	return v, nil
+}
+
+func (b *buffer) svarint() (int64, error) {
+	v, err := b.uvarint()
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+// cstring reads a zero terminated string.
+func (b *buffer) cstring() (string, error) {
+	i := bytes.IndexByte(b.data[b.pos:], 0)
+	if i < 0 {
+		return "", errShortDataset
+	}
+
+	s := string(b.data[b.pos : b.pos+i])
+	b.pos += i + 1
+
+	return s, nil
+}
+
+// pair reads a string pair, either inline or as a table reference.
+func (b *buffer) pair(t *readTable) (string, string, error) {
+	ref, err := b.uvarint()
+	if err != nil {
+		return "", "", err
+	}
+
+	if ref != 0 {
+		return t.get(ref)
+	}
+
+	s1, err := b.cstring()
+	if err != nil {
+		return "", "", err
+	}
+
+	s2, err := b.cstring()
+	if err != nil {
+		return "", "", err
+	}
+
+	t.add(s1, s2)
+	return s1, s2, nil
+}
+
+// single reads a single string, either inline or as a table reference.
+func (b *buffer) single(t *readTable) (string, error) {
+	ref, err := b.uvarint()
+	if err != nil {
+		return "", err
+	}
+
+	if ref != 0 {
+		s, _, err := t.get(ref)
+		return s, err
+	}
+
+	s, err := b.cstring()
+	if err != nil {
+		return "", err
+	}
+
+	t.add(s, "")
+	return s, nil
+}
+
+func appendUvarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+
+	return append(b, byte(v))
+}
+
+func appendSvarint(b []byte, v int64) []byte {
+	return appendUvarint(b, uint64(v<<1)^uint64(v>>63))
+}
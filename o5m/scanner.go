@@ -0,0 +1,114 @@
+package o5m
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/ich5003/small-osm"
+)
+
+var _ osm.Scanner = &Scanner{}
+
+// Scanner provides a convenient interface reading a stream of o5m data.
+// Successive calls to the Scan method will step through the bounds, nodes,
+// ways and relations of the file. Deleted elements, e.g. in an o5c file or
+// a history file, are returned with only their id and version information
+// and Visible set to false.
+//
+// Scanning stops unrecoverably at EOF, the first I/O error, the first
+// decoding error or the context being cancelled.
+//
+// The Scanner API is based on bufio.Scanner
+// https://golang.org/pkg/bufio/#Scanner
+type Scanner struct {
+	ctx    context.Context
+	done   context.CancelFunc
+	closed bool
+
+	decoder *decoder
+	next    osm.Object
+	deleted bool
+	err     error
+}
+
+// New returns a new Scanner to read from r.
+func New(ctx context.Context, r io.Reader) *Scanner {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s := &Scanner{
+		decoder: newDecoder(r),
+	}
+
+	s.ctx, s.done = context.WithCancel(ctx)
+	return s
+}
+
+// Close causes all future calls to Scan to return false.
+// Does not close the underlying reader.
+func (s *Scanner) Close() error {
+	s.closed = true
+	s.done()
+
+	return nil
+}
+
+// Scan advances the Scanner to the next element, which will then be available
+// through the Object method. It returns false when the scan stops, either
+// by reaching the end of the input, an io error, a decoding error or the context
+// being cancelled. After Scan returns false, the Err method will return any
+// error that occurred during scanning, except if it was io.EOF, Err will
+// return nil.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	if s.ctx.Err() != nil {
+		return false
+	}
+
+	s.next, s.deleted, s.err = s.decoder.next()
+	return s.err == nil
+}
+
+// Object returns the most recent token generated by a call to Scan
+// as a new osm.Object. This interface is implemented by:
+//	*osm.Bounds
+//	*osm.Node
+//	*osm.Way
+//	*osm.Relation
+func (s *Scanner) Object() osm.Object {
+	return s.next
+}
+
+// Change returns true if the stream is an o5c change file.
+// The value is set once the file header has been scanned.
+func (s *Scanner) Change() bool {
+	return s.decoder.change
+}
+
+// Timestamp returns the file timestamp, if present. It is usually
+// set once the first element has been scanned.
+func (s *Scanner) Timestamp() time.Time {
+	return s.decoder.fileTimestamp
+}
+
+// Err returns the first non-EOF error that was encountered by the Scanner.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.closed {
+		return osm.ErrScannerClosed
+	}
+
+	return s.ctx.Err()
+}
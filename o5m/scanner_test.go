@@ -0,0 +1,176 @@
+package o5m
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ich5003/small-osm"
+)
+
+// testFile is a small o5m file following the format description.
+// It contains table references, delta coding and reset markers.
+var testFile = []byte{
+	0xff,
+	0xe0, 0x04, 'o', '5', 'm', '2',
+	0xdc, 0x02, 0xd0, 0x0f,
+	0xdb, 0x04, 0x13, 0x27, 0x14, 0x28,
+	// node 1 with inline author and tag
+	0x10, 0x10,
+	0x02, 0x01, 0x14, 0x0a,
+	0x00, 0x07, 0x00, 'u', 0x00,
+	0x14, 0x27,
+	0x00, 'a', 0x00, 'b', 0x00,
+	// node 3 referencing the author and tag
+	0x10, 0x08,
+	0x04, 0x02, 0x00, 0x00, 0x02,
+	0x00, 0x02,
+	0x01,
+	0xff,
+	// way 5 without version information
+	0x11, 0x05,
+	0x0a, 0x00,
+	0x02, 0x02, 0x04,
+	// relation 1
+	0x12, 0x1b,
+	0x02, 0x00,
+	0x0c,
+	0x06, 0x00, '0', 's', 't', 'o', 'p', 0x00,
+	0x0a, 0x00, '1', 0x00,
+	0x00, 't', 'y', 'p', 'e', 0x00, 'r', 'o', 'u', 't', 'e', 0x00,
+	// deleted node 8
+	0x10, 0x02, 0x10, 0x00,
+	0xfe,
+}
+
+func TestScanner(t *testing.T) {
+	scanner := New(context.Background(), bytes.NewReader(testFile))
+	defer scanner.Close()
+
+	var objects osm.Objects
+	for scanner.Scan() {
+		objects = append(objects, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	ts := time.Unix(10, 0).UTC()
+	expected := osm.Objects{
+		&osm.Bounds{MinLon: -10e-7, MinLat: -20e-7, MaxLon: 10e-7, MaxLat: 20e-7},
+		&osm.Node{
+			ID: 1, Lon: 10e-7, Lat: -20e-7, Visible: true,
+			Version: 1, Timestamp: ts, ChangesetID: 5, UserID: 7, User: "u",
+			Tags: osm.Tags{{Key: "a", Value: "b"}},
+		},
+		&osm.Node{
+			ID: 3, Lon: 10e-7, Lat: -19e-7, Visible: true,
+			Version: 2, Timestamp: ts, ChangesetID: 5, UserID: 7, User: "u",
+			Tags: osm.Tags{{Key: "a", Value: "b"}},
+		},
+		&osm.Way{
+			ID: 5, Visible: true,
+			Nodes: osm.WayNodes{{ID: 1}, {ID: 3}},
+		},
+		&osm.Relation{
+			ID: 1, Visible: true,
+			Members: osm.Members{
+				{Type: osm.TypeNode, Ref: 3, Role: "stop"},
+				{Type: osm.TypeWay, Ref: 5},
+			},
+			Tags: osm.Tags{{Key: "type", Value: "route"}},
+		},
+		&osm.Node{ID: 8},
+	}
+
+	if len(objects) != len(expected) {
+		t.Fatalf("incorrect number of objects: %v != %v", len(objects), len(expected))
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(objects[i], expected[i]) {
+			t.Errorf("incorrect object %d", i)
+			t.Logf("%+v", objects[i])
+			t.Logf("%+v", expected[i])
+		}
+	}
+
+	if scanner.Change() {
+		t.Errorf("should not be a change file")
+	}
+
+	if v := scanner.Timestamp(); !v.Equal(time.Unix(1000, 0)) {
+		t.Errorf("incorrect file timestamp: %v", v)
+	}
+}
+
+func TestScanner_errors(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		err  string
+	}{
+		{
+			name: "not o5m",
+			data: []byte("<?xml"),
+			err:  "invalid start of file",
+		},
+		{
+			name: "unsupported header",
+			data: []byte{0xff, 0xe0, 0x04, 'o', '5', 'x', '2'},
+			err:  "unsupported file type",
+		},
+		{
+			name: "invalid reference",
+			data: []byte{0xff, 0x10, 0x06, 0x02, 0x00, 0x00, 0x00, 0x05, 0x00},
+			err:  "invalid string reference",
+		},
+		{
+			name: "truncated",
+			data: []byte{0xff, 0x10, 0x06, 0x02},
+			err:  "unexpected EOF",
+		},
+		{
+			name: "truncated length",
+			data: []byte{0xff, 0x10, 0x80},
+			err:  "unexpected EOF",
+		},
+		{
+			name: "dataset too large",
+			data: []byte{0xff, 0x10, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01},
+			err:  "dataset too large",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scanner := New(context.Background(), bytes.NewReader(tc.data))
+			defer scanner.Close()
+
+			for scanner.Scan() {
+			}
+
+			err := scanner.Err()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("incorrect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestScanner_Close(t *testing.T) {
+	scanner := New(context.Background(), bytes.NewReader(testFile))
+	scanner.Close()
+
+	if scanner.Scan() {
+		t.Errorf("should not scan after close")
+	}
+
+	if err := scanner.Err(); err != osm.ErrScannerClosed {
+		t.Errorf("incorrect error: %v", err)
+	}
+}
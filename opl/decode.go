@@ -0,0 +1,268 @@
+package opl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ich5003/small-osm"
+)
+
+// metadata are the fields shared by nodes, ways and relations.
+// Missing fields are left as their zero value, except visible
+// which defaults to true.
+type metadata struct {
+	Version     int
+	Visible     bool
+	ChangesetID osm.ChangesetID
+	Timestamp   time.Time
+	UserID      osm.UserID
+	User        string
+}
+
+// decodeLine parses the line into a node, way, relation or changeset.
+// The errors are not prefixed, the scanner adds the package and line number.
+// Only the type and id field is required.
+func decodeLine(line string) (osm.Object, error) {
+	fields := strings.Fields(line)
+
+	t, ok := charType(fields[0][0])
+	if !ok {
+		return nil, fmt.Errorf("unsupported object type: %q", fields[0][:1])
+	}
+
+	id, err := strconv.ParseInt(fields[0][1:], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id: %q", fields[0])
+	}
+
+	m := metadata{Visible: true}
+	switch t {
+	case osm.TypeNode:
+		n := &osm.Node{ID: osm.NodeID(id)}
+		err := decodeFields(fields[1:], func(k byte, v string) (err error) {
+			switch k {
+			case 'x':
+				n.Lon, err = parseCoordinate(v)
+			case 'y':
+				n.Lat, err = parseCoordinate(v)
+			case 'T':
+				n.Tags, err = parseTags(v)
+			default:
+				err = m.decode(k, v)
+			}
+			return err
+		})
+
+		n.Version, n.Visible, n.ChangesetID = m.Version, m.Visible, m.ChangesetID
+		n.Timestamp, n.UserID, n.User = m.Timestamp, m.UserID, m.User
+		return n, err
+	case osm.TypeWay:
+		w := &osm.Way{ID: osm.WayID(id)}
+		err := decodeFields(fields[1:], func(k byte, v string) (err error) {
+			switch k {
+			case 'N':
+				w.Nodes, err = parseWayNodes(v)
+			case 'T':
+				w.Tags, err = parseTags(v)
+			default:
+				err = m.decode(k, v)
+			}
+			return err
+		})
+
+		w.Version, w.Visible, w.ChangesetID = m.Version, m.Visible, m.ChangesetID
+		w.Timestamp, w.UserID, w.User = m.Timestamp, m.UserID, m.User
+		return w, err
+	case osm.TypeRelation:
+		r := &osm.Relation{ID: osm.RelationID(id)}
+		err := decodeFields(fields[1:], func(k byte, v string) (err error) {
+			switch k {
+			case 'M':
+				r.Members, err = parseMembers(v)
+			case 'T':
+				r.Tags, err = parseTags(v)
+			default:
+				err = m.decode(k, v)
+			}
+			return err
+		})
+
+		r.Version, r.Visible, r.ChangesetID = m.Version, m.Visible, m.ChangesetID
+		r.Timestamp, r.UserID, r.User = m.Timestamp, m.UserID, m.User
+		return r, err
+	}
+
+	c := &osm.Changeset{ID: osm.ChangesetID(id)}
+	err = decodeFields(fields[1:], func(k byte, v string) (err error) {
+		switch k {
+		case 'k':
+			c.ChangesCount, err = parseInt(v)
+		case 's':
+			c.CreatedAt, err = parseTime(v)
+		case 'e':
+			c.ClosedAt, err = parseTime(v)
+		case 'd':
+			c.CommentsCount, err = parseInt(v)
+		case 'i':
+			var uid int
+			uid, err = parseInt(v)
+			c.UserID = osm.UserID(uid)
+		case 'u':
+			c.User, err = unescape(v)
+		case 'x':
+			c.MinLon, err = parseCoordinate(v)
+		case 'y':
+			c.MinLat, err = parseCoordinate(v)
+		case 'X':
+			c.MaxLon, err = parseCoordinate(v)
+		case 'Y':
+			c.MaxLat, err = parseCoordinate(v)
+		case 'T':
+			c.Tags, err = parseTags(v)
+		default:
+			err = fmt.Errorf("unsupported changeset field: %q", k)
+		}
+		return err
+	})
+	c.Open = c.ClosedAt.IsZero()
+
+	return c, err
+}
+
+// decodeFields calls the function with the key and value of each field.
+func decodeFields(fields []string, fn func(k byte, v string) error) error {
+	for _, f := range fields {
+		if err := fn(f[0], f[1:]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *metadata) decode(k byte, v string) (err error) {
+	switch k {
+	case 'v':
+		m.Version, err = parseInt(v)
+	case 'd':
+		switch v {
+		case "V":
+			m.Visible = true
+		case "D":
+			m.Visible = false
+		default:
+			err = fmt.Errorf("invalid visible value: %q", v)
+		}
+	case 'c':
+		var id int
+		id, err = parseInt(v)
+		m.ChangesetID = osm.ChangesetID(id)
+	case 't':
+		m.Timestamp, err = parseTime(v)
+	case 'i':
+		var id int
+		id, err = parseInt(v)
+		m.UserID = osm.UserID(id)
+	case 'u':
+		m.User, err = unescape(v)
+	default:
+		err = fmt.Errorf("unsupported field: %q", k)
+	}
+
+	return err
+}
+
+func parseInt(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	return strconv.Atoi(v)
+}
+
+// parseWayNodes parses the node ids with optional locations,
+// e.g. n1,n2x1.5y2.5
+func parseWayNodes(v string) (osm.WayNodes, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(v, ",")
+	nodes := make(osm.WayNodes, 0, len(parts))
+	for _, p := range parts {
+		if len(p) < 2 || p[0] != 'n' {
+			return nil, fmt.Errorf("invalid way node: %q", p)
+		}
+
+		var (
+			wn  osm.WayNode
+			err error
+		)
+
+		ref := p[1:]
+		if i := strings.IndexByte(ref, 'x'); i >= 0 {
+			loc := ref[i+1:]
+			ref = ref[:i]
+
+			j := strings.IndexByte(loc, 'y')
+			if j < 0 {
+				return nil, fmt.Errorf("invalid way node location: %q", p)
+			}
+
+			if wn.Lon, err = parseCoordinate(loc[:j]); err != nil {
+				return nil, err
+			}
+
+			if wn.Lat, err = parseCoordinate(loc[j+1:]); err != nil {
+				return nil, err
+			}
+		}
+
+		id, err := strconv.ParseInt(ref, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid way node: %q", p)
+		}
+		wn.ID = osm.NodeID(id)
+
+		nodes = append(nodes, wn)
+	}
+
+	return nodes, nil
+}
+
+// parseMembers parses the typed member refs and roles, e.g. w1@outer,n2@
+func parseMembers(v string) (osm.Members, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(v, ",")
+	members := make(osm.Members, 0, len(parts))
+	for _, p := range parts {
+		i := strings.IndexByte(p, '@')
+		if i < 2 {
+			return nil, fmt.Errorf("invalid member: %q", p)
+		}
+
+		t, ok := charType(p[0])
+		if !ok || t == osm.TypeChangeset {
+			return nil, fmt.Errorf("invalid member type: %q", p)
+		}
+
+		ref, err := strconv.ParseInt(p[1:i], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid member: %q", p)
+		}
+
+		role, err := unescape(p[i+1:])
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, osm.Member{Type: t, Ref: ref, Role: role})
+	}
+
+	return members, nil
+}
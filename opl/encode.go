@@ -0,0 +1,222 @@
+package opl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ich5003/small-osm"
+)
+
+// ErrEncoderClosed is returned when encoding after the encoder is closed.
+var ErrEncoderClosed = errors.New("opl: encoder closed")
+
+// Encoder writes a stream of osm data as OPL, one object per line.
+//
+// The Encoder is not safe for parallel use.
+type Encoder struct {
+	// SkipMetadata are the element metadata fields of nodes, ways and
+	// relations that will not be written. The id, version, tags and
+	// geometry are always written.
+	SkipMetadata osm.MetadataMask
+
+	w      *bufio.Writer
+	line   []byte
+	closed bool
+	err    error
+}
+
+// NewEncoder returns a new encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w)}
+}
+
+// Encode writes the object as a line. The interface is implemented by:
+//	*osm.Node
+//	*osm.Way
+//	*osm.Relation
+//	*osm.Changeset
+// Bounds are skipped since OPL has no representation for them.
+func (e *Encoder) Encode(o osm.Object) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	b := e.line[:0]
+	switch o := o.(type) {
+	case *osm.Bounds:
+		return nil
+	case *osm.Node:
+		b = e.appendMetadata(b, 'n', int64(o.ID), metadata{
+			Version:     o.Version,
+			Visible:     o.Visible,
+			ChangesetID: o.ChangesetID,
+			Timestamp:   o.Timestamp,
+			UserID:      o.UserID,
+			User:        o.User,
+		})
+		b = appendField(b, 'T', formatTags(o.Tags))
+
+		// deleted nodes usually have no location
+		if !o.Visible && o.Lat == 0 && o.Lon == 0 {
+			b = append(b, " x y"...)
+		} else {
+			b = appendField(b, 'x', formatCoordinate(o.Lon))
+			b = appendField(b, 'y', formatCoordinate(o.Lat))
+		}
+	case *osm.Way:
+		b = e.appendMetadata(b, 'w', int64(o.ID), metadata{
+			Version:     o.Version,
+			Visible:     o.Visible,
+			ChangesetID: o.ChangesetID,
+			Timestamp:   o.Timestamp,
+			UserID:      o.UserID,
+			User:        o.User,
+		})
+		b = appendField(b, 'T', formatTags(o.Tags))
+		b = append(b, " N"...)
+		for i, wn := range o.Nodes {
+			if i > 0 {
+				b = append(b, ',')
+			}
+
+			b = append(b, 'n')
+			b = strconv.AppendInt(b, int64(wn.ID), 10)
+			if wn.Lat != 0 || wn.Lon != 0 {
+				b = append(b, 'x')
+				b = append(b, formatCoordinate(wn.Lon)...)
+				b = append(b, 'y')
+				b = append(b, formatCoordinate(wn.Lat)...)
+			}
+		}
+	case *osm.Relation:
+		b = e.appendMetadata(b, 'r', int64(o.ID), metadata{
+			Version:     o.Version,
+			Visible:     o.Visible,
+			ChangesetID: o.ChangesetID,
+			Timestamp:   o.Timestamp,
+			UserID:      o.UserID,
+			User:        o.User,
+		})
+		b = appendField(b, 'T', formatTags(o.Tags))
+		b = append(b, " M"...)
+		for i, m := range o.Members {
+			c, ok := typeChars[m.Type]
+			if !ok || m.Type == osm.TypeChangeset {
+				e.err = fmt.Errorf("opl: unsupported member type: %q", m.Type)
+				return e.err
+			}
+
+			if i > 0 {
+				b = append(b, ',')
+			}
+
+			b = append(b, c)
+			b = strconv.AppendInt(b, m.Ref, 10)
+			b = append(b, '@')
+			b = append(b, escape(m.Role)...)
+		}
+	case *osm.Changeset:
+		b = appendChangeset(b, o)
+	default:
+		return fmt.Errorf("opl: unsupported object type: %T", o)
+	}
+
+	e.line = append(b, '\n')
+	_, e.err = e.w.Write(e.line)
+	return e.err
+}
+
+// EncodeScanner encodes all the objects returned by the scanner.
+// The scanner is not closed.
+func (e *Encoder) EncodeScanner(s osm.Scanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Object()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close flushes any buffered data.
+// It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+	e.closed = true
+
+	if e.err != nil {
+		return e.err
+	}
+
+	return e.w.Flush()
+}
+
+// appendMetadata writes the type and id followed by the element
+// metadata fields that are not skipped.
+func (e *Encoder) appendMetadata(b []byte, t byte, id int64, m metadata) []byte {
+	b = append(b, t)
+	b = strconv.AppendInt(b, id, 10)
+	b = appendField(b, 'v', strconv.Itoa(m.Version))
+
+	if !e.SkipMetadata.Has(osm.MetadataVisible) {
+		if m.Visible {
+			b = appendField(b, 'd', "V")
+		} else {
+			b = appendField(b, 'd', "D")
+		}
+	}
+
+	if !e.SkipMetadata.Has(osm.MetadataChangeset) {
+		b = appendField(b, 'c', strconv.FormatInt(int64(m.ChangesetID), 10))
+	}
+
+	if !e.SkipMetadata.Has(osm.MetadataTimestamp) {
+		b = appendField(b, 't', formatTime(m.Timestamp))
+	}
+
+	if !e.SkipMetadata.Has(osm.MetadataUserID) {
+		b = appendField(b, 'i', strconv.FormatInt(int64(m.UserID), 10))
+	}
+
+	if !e.SkipMetadata.Has(osm.MetadataUser) {
+		b = appendField(b, 'u', escape(m.User))
+	}
+
+	return b
+}
+
+func appendChangeset(b []byte, c *osm.Changeset) []byte {
+	b = append(b, 'c')
+	b = strconv.AppendInt(b, int64(c.ID), 10)
+	b = appendField(b, 'k', strconv.Itoa(c.ChangesCount))
+	b = appendField(b, 's', formatTime(c.CreatedAt))
+	b = appendField(b, 'e', formatTime(c.ClosedAt))
+	b = appendField(b, 'd', strconv.Itoa(c.CommentsCount))
+	b = appendField(b, 'i', strconv.FormatInt(int64(c.UserID), 10))
+	b = appendField(b, 'u', escape(c.User))
+
+	if c.MinLat == 0 && c.MaxLat == 0 && c.MinLon == 0 && c.MaxLon == 0 {
+		b = append(b, " x y X Y"...)
+	} else {
+		b = appendField(b, 'x', formatCoordinate(c.MinLon))
+		b = appendField(b, 'y', formatCoordinate(c.MinLat))
+		b = appendField(b, 'X', formatCoordinate(c.MaxLon))
+		b = appendField(b, 'Y', formatCoordinate(c.MaxLat))
+	}
+
+	return appendField(b, 'T', formatTags(c.Tags))
+}
+
+func appendField(b []byte, k byte, v string) []byte {
+	b = append(b, ' ', k)
+	return append(b, v...)
+}
@@ -0,0 +1,190 @@
+package opl
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmxml"
+)
+
+func TestEncoder(t *testing.T) {
+	ts := time.Date(2016, 7, 19, 5, 46, 34, 0, time.UTC)
+	objects := osm.Objects{
+		&osm.Bounds{MinLat: 1, MaxLat: 2, MinLon: 3, MaxLon: 4},
+		&osm.Node{
+			ID: 1, Version: 2, Visible: true, ChangesetID: 10, Timestamp: ts,
+			UserID: 100, User: "user name", Lon: -73.5852817, Lat: 45.4778317,
+			Tags: osm.Tags{{Key: "name", Value: "a=b, c@d 100%"}},
+		},
+		&osm.Node{ID: 2, Version: 3, ChangesetID: 11, Timestamp: ts, Lat: 1, Lon: 2},
+		&osm.Node{ID: 3, Version: 1},
+		&osm.Way{
+			ID: 3, Version: 1, Visible: true,
+			Nodes: osm.WayNodes{{ID: 1}, {ID: 2, Lon: 2, Lat: 1}},
+		},
+		&osm.Relation{
+			ID: 4, Version: 1, Visible: true,
+			Members: osm.Members{
+				{Type: osm.TypeWay, Ref: 3, Role: "outer"},
+				{Type: osm.TypeNode, Ref: 1},
+			},
+		},
+		&osm.Changeset{
+			ID: 10, ChangesCount: 3, UserID: 100, User: "user",
+			CreatedAt: ts, Open: true,
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	for _, o := range objects {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	expected := `n1 v2 dV c10 t2016-07-19T05:46:34Z i100 uuser%20%name Tname=a%3d%b%2c%%20%c%40%d%20%100%25% x-73.5852817 y45.4778317
+n2 v3 dD c11 t2016-07-19T05:46:34Z i0 u T x2 y1
+n3 v1 dD c0 t i0 u T x y
+w3 v1 dV c0 t i0 u T Nn1,n2x2y1
+r4 v1 dV c0 t i0 u T Mw3@outer,n1@
+c10 k3 s2016-07-19T05:46:34Z e d0 i100 uuser x y X Y T
+`
+	if v := buf.String(); v != expected {
+		t.Errorf("incorrect output:\n%s", v)
+	}
+
+	scanner := New(context.Background(), buf)
+	defer scanner.Close()
+
+	i := 1
+	for scanner.Scan() {
+		if !reflect.DeepEqual(scanner.Object(), objects[i]) {
+			t.Errorf("incorrect object %d", i)
+			t.Logf("%+v", scanner.Object())
+			t.Logf("%+v", objects[i])
+		}
+		i++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+}
+
+func TestEncoder_SkipMetadata(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	enc.SkipMetadata = osm.MetadataAll
+
+	n := &osm.Node{ID: 1, Version: 2, ChangesetID: 10, UserID: 100, User: "user", Lat: 1, Lon: 2}
+	if err := enc.Encode(n); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	if v := buf.String(); v != "n1 v2 T x2 y1\n" {
+		t.Errorf("incorrect output: %q", v)
+	}
+}
+
+func TestEncoder_osmxml(t *testing.T) {
+	f, err := os.Open("../testdata/andorra-latest.osm.bz2")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	var expected osm.Objects
+	xml := osmxml.New(context.Background(), bzip2.NewReader(f))
+	for xml.Scan() {
+		if _, ok := xml.Object().(*osm.Bounds); ok {
+			continue
+		}
+
+		expected = append(expected, xml.Object())
+	}
+
+	if err := xml.Err(); err != nil {
+		t.Fatalf("xml scanner error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	for _, o := range expected {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	scanner := New(context.Background(), buf)
+	defer scanner.Close()
+
+	i := 0
+	for scanner.Scan() {
+		if !reflect.DeepEqual(scanner.Object(), expected[i]) {
+			t.Fatalf("incorrect object %d: %v", i, expected[i].ObjectID())
+		}
+		i++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	if i != len(expected) {
+		t.Errorf("incorrect number of objects: %v != %v", i, len(expected))
+	}
+}
+
+func TestEscape(t *testing.T) {
+	cases := []struct {
+		value   string
+		escaped string
+	}{
+		{"", ""},
+		{"simple", "simple"},
+		{"Café", "Café"},
+		{"a b", "a%20%b"},
+		{"a,b=c@d%", "a%2c%b%3d%c%40%d%25%"},
+		{"tab\there", "tab%9%here"},
+		{"new\nline", "new%a%line"},
+	}
+
+	for _, tc := range cases {
+		if v := escape(tc.value); v != tc.escaped {
+			t.Errorf("incorrect escape of %q: %q != %q", tc.value, v, tc.escaped)
+		}
+
+		v, err := unescape(tc.escaped)
+		if err != nil {
+			t.Errorf("unescape error: %v", err)
+		}
+
+		if v != tc.value {
+			t.Errorf("incorrect unescape of %q: %q != %q", tc.escaped, v, tc.value)
+		}
+	}
+
+	if _, err := unescape(strings.Repeat("%", 3)); err == nil {
+		t.Errorf("should error for an invalid sequence")
+	}
+}
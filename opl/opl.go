@@ -0,0 +1,181 @@
+// Package opl provides a scanner and encoder for the osmium OPL
+// (Object Per Line) text format. Each line contains one node, way,
+// relation or changeset as a list of space separated fields.
+// See: https://osmcode.org/opl-file-format/
+package opl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ich5003/small-osm"
+)
+
+const timeFormat = "2006-01-02T15:04:05Z"
+
+// escape percent encodes the characters that have a meaning in
+// OPL, whitespace and non printable characters as %<hex codepoint>%.
+func escape(s string) string {
+	if strings.IndexFunc(s, needsEscape) < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		if !needsEscape(r) {
+			b.WriteRune(r)
+			continue
+		}
+
+		b.WriteByte('%')
+		b.WriteString(strconv.FormatInt(int64(r), 16))
+		b.WriteByte('%')
+	}
+
+	return b.String()
+}
+
+func needsEscape(r rune) bool {
+	switch r {
+	case ' ', '%', ',', '=', '@':
+		return true
+	}
+
+	return !unicode.IsPrint(r)
+}
+
+// unescape decodes the %<hex codepoint>% sequences of the value.
+func unescape(s string) (string, error) {
+	i := strings.IndexByte(s, '%')
+	if i < 0 {
+		return s, nil
+	}
+
+	var b strings.Builder
+	for i >= 0 {
+		b.WriteString(s[:i])
+		s = s[i+1:]
+
+		end := strings.IndexByte(s, '%')
+		if end < 1 {
+			return "", fmt.Errorf("invalid escape sequence in %q", s)
+		}
+
+		r, err := strconv.ParseUint(s[:end], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			return "", fmt.Errorf("invalid escape sequence: %%%s%%", s[:end])
+		}
+
+		b.WriteRune(rune(r))
+		s = s[end+1:]
+		i = strings.IndexByte(s, '%')
+	}
+
+	b.WriteString(s)
+	return b.String(), nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(timeFormat)
+}
+
+func parseTime(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(timeFormat, v)
+}
+
+// formatCoordinate writes the value with at most 7 decimals,
+// the precision of the osm database.
+func formatCoordinate(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 7, 64)
+	s = strings.TrimRight(s, "0")
+	s = strings.TrimSuffix(s, ".")
+
+	if s == "-0" {
+		return "0"
+	}
+
+	return s
+}
+
+func parseCoordinate(v string) (float64, error) {
+	if v == "" {
+		return 0, nil
+	}
+
+	return strconv.ParseFloat(v, 64)
+}
+
+func formatTags(tags osm.Tags) string {
+	var b strings.Builder
+	for i, t := range tags {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		b.WriteString(escape(t.Key))
+		b.WriteByte('=')
+		b.WriteString(escape(t.Value))
+	}
+
+	return b.String()
+}
+
+func parseTags(v string) (osm.Tags, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(v, ",")
+	tags := make(osm.Tags, 0, len(parts))
+	for _, p := range parts {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid tag: %q", p)
+		}
+
+		k, err := unescape(kv[0])
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := unescape(kv[1])
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, osm.Tag{Key: k, Value: v})
+	}
+
+	return tags, nil
+}
+
+// typeChars are the characters used for the element types
+// of the object and member ids.
+var typeChars = map[osm.Type]byte{
+	osm.TypeNode:      'n',
+	osm.TypeWay:       'w',
+	osm.TypeRelation:  'r',
+	osm.TypeChangeset: 'c',
+}
+
+func charType(c byte) (osm.Type, bool) {
+	for t, tc := range typeChars {
+		if tc == c {
+			return t, true
+		}
+	}
+
+	return "", false
+}
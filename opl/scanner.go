@@ -0,0 +1,131 @@
+package opl
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ich5003/small-osm"
+)
+
+// maxLineSize is the longest supported line, large relations
+// with thousands of members can be several megabytes.
+const maxLineSize = 64 * 1024 * 1024
+
+var _ osm.Scanner = &Scanner{}
+
+// Scanner provides a convenient interface reading a stream of OPL data.
+// Successive calls to the Scan method will step through the lines.
+// Empty lines and lines starting with # are skipped. Fields may be missing
+// or in any order, missing fields are left as their zero value except
+// the visible flag of elements that defaults to true.
+//
+// Scanning stops unrecoverably at EOF, the first I/O error, the first
+// invalid line or the context being cancelled.
+//
+// The Scanner API is based on bufio.Scanner
+// https://golang.org/pkg/bufio/#Scanner
+type Scanner struct {
+	ctx    context.Context
+	done   context.CancelFunc
+	closed bool
+
+	scanner *bufio.Scanner
+	line    int
+	next    osm.Object
+	err     error
+}
+
+// New returns a new Scanner to read from r.
+func New(ctx context.Context, r io.Reader) *Scanner {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s := &Scanner{
+		scanner: bufio.NewScanner(r),
+	}
+	s.scanner.Buffer(make([]byte, 64*1024), maxLineSize)
+
+	s.ctx, s.done = context.WithCancel(ctx)
+	return s
+}
+
+// Close causes all future calls to Scan to return false.
+// Does not close the underlying reader.
+func (s *Scanner) Close() error {
+	s.closed = true
+	s.done()
+
+	return nil
+}
+
+// Scan advances the Scanner to the next object, which will then be available
+// through the Object method. It returns false when the scan stops, either
+// by reaching the end of the input, an io error, an invalid line or the context
+// being cancelled. After Scan returns false, the Err method will return any
+// error that occurred during scanning, except if it was io.EOF, Err will
+// return nil.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		if s.ctx.Err() != nil {
+			return false
+		}
+
+		if !s.scanner.Scan() {
+			s.err = s.scanner.Err()
+			if s.err == nil {
+				s.err = io.EOF
+			}
+
+			return false
+		}
+		s.line++
+
+		line := strings.TrimSpace(s.scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		s.next, s.err = decodeLine(line)
+		if s.err != nil {
+			s.err = fmt.Errorf("opl: line %d: %v", s.line, s.err)
+			return false
+		}
+
+		return true
+	}
+}
+
+// Object returns the most recent object generated by a call to Scan
+// as a new osm.Object. This interface is implemented by:
+//	*osm.Node
+//	*osm.Way
+//	*osm.Relation
+//	*osm.Changeset
+func (s *Scanner) Object() osm.Object {
+	return s.next
+}
+
+// Err returns the first non-EOF error that was encountered by the Scanner.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.closed {
+		return osm.ErrScannerClosed
+	}
+
+	return s.ctx.Err()
+}
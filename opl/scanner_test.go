@@ -0,0 +1,162 @@
+package opl
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestScanner(t *testing.T) {
+	data := `# comment
+n1 v2 dV c10 t2016-07-19T05:46:34Z i100 uuser%20%name Tamenity=cafe,name=Caf%e9%%20%%2c%1 x-73.5852817 y45.4778317
+
+n2 v1 dD c11 t2016-07-19T05:46:35Z i100 uuser%20%name T x y
+w3 v1 dV c10 t2016-07-19T05:46:34Z i100 u Tbuilding=yes Nn1,n2,n1
+r4 v1 dV c10 t2016-07-19T05:46:34Z i100 u Ttype=multipolygon Mw3@outer,n1@,r5@sub%40%area
+c10 k3 s2016-07-19T05:40:00Z e2016-07-19T05:47:00Z d1 i100 uuser%20%name x-74 y45 X-73.5 Y45.5 Tcomment=fix%2c%%20%again
+n5
+w6 Nn1x1.5y2.5,n2
+`
+
+	scanner := New(context.Background(), strings.NewReader(data))
+	defer scanner.Close()
+
+	var objects osm.Objects
+	for scanner.Scan() {
+		objects = append(objects, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	ts := time.Date(2016, 7, 19, 5, 46, 34, 0, time.UTC)
+	expected := osm.Objects{
+		&osm.Node{
+			ID: 1, Version: 2, Visible: true, ChangesetID: 10, Timestamp: ts,
+			UserID: 100, User: "user name", Lon: -73.5852817, Lat: 45.4778317,
+			Tags: osm.Tags{{Key: "amenity", Value: "cafe"}, {Key: "name", Value: "Café ,1"}},
+		},
+		&osm.Node{
+			ID: 2, Version: 1, ChangesetID: 11, Timestamp: ts.Add(time.Second),
+			UserID: 100, User: "user name",
+		},
+		&osm.Way{
+			ID: 3, Version: 1, Visible: true, ChangesetID: 10, Timestamp: ts, UserID: 100,
+			Nodes: osm.WayNodes{{ID: 1}, {ID: 2}, {ID: 1}},
+			Tags:  osm.Tags{{Key: "building", Value: "yes"}},
+		},
+		&osm.Relation{
+			ID: 4, Version: 1, Visible: true, ChangesetID: 10, Timestamp: ts, UserID: 100,
+			Members: osm.Members{
+				{Type: osm.TypeWay, Ref: 3, Role: "outer"},
+				{Type: osm.TypeNode, Ref: 1},
+				{Type: osm.TypeRelation, Ref: 5, Role: "sub@area"},
+			},
+			Tags: osm.Tags{{Key: "type", Value: "multipolygon"}},
+		},
+		&osm.Changeset{
+			ID: 10, ChangesCount: 3, CommentsCount: 1, UserID: 100, User: "user name",
+			CreatedAt: time.Date(2016, 7, 19, 5, 40, 0, 0, time.UTC),
+			ClosedAt:  time.Date(2016, 7, 19, 5, 47, 0, 0, time.UTC),
+			MinLon:    -74, MinLat: 45, MaxLon: -73.5, MaxLat: 45.5,
+			Tags: osm.Tags{{Key: "comment", Value: "fix, again"}},
+		},
+		&osm.Node{ID: 5, Visible: true},
+		&osm.Way{
+			ID: 6, Visible: true,
+			Nodes: osm.WayNodes{{ID: 1, Lon: 1.5, Lat: 2.5}, {ID: 2}},
+		},
+	}
+
+	if len(objects) != len(expected) {
+		t.Fatalf("incorrect number of objects: %v != %v", len(objects), len(expected))
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(objects[i], expected[i]) {
+			t.Errorf("incorrect object %d", i)
+			t.Logf("%+v", objects[i])
+			t.Logf("%+v", expected[i])
+		}
+	}
+}
+
+func TestScanner_errors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		err  string
+	}{
+		{
+			name: "unsupported type",
+			data: "x1 v1",
+			err:  "opl: line 1: unsupported object type",
+		},
+		{
+			name: "invalid id",
+			data: "n1\nnabc v1",
+			err:  "opl: line 2: invalid id",
+		},
+		{
+			name: "invalid escape",
+			data: "n1 Tname=a%zz%",
+			err:  "invalid escape sequence",
+		},
+		{
+			name: "unterminated escape",
+			data: "n1 Tname=a%20",
+			err:  "invalid escape sequence",
+		},
+		{
+			name: "invalid tag",
+			data: "n1 Tname",
+			err:  "invalid tag",
+		},
+		{
+			name: "invalid member",
+			data: "r1 Mx1@role",
+			err:  "invalid member type",
+		},
+		{
+			name: "unsupported field",
+			data: "w1 x1",
+			err:  "unsupported field",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scanner := New(context.Background(), strings.NewReader(tc.data))
+			defer scanner.Close()
+
+			for scanner.Scan() {
+			}
+
+			err := scanner.Err()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Errorf("incorrect error: %v", err)
+			}
+		})
+	}
+}
+
+func TestScanner_Close(t *testing.T) {
+	scanner := New(context.Background(), strings.NewReader("n1\nn2\n"))
+	if !scanner.Scan() {
+		t.Fatalf("should scan first line")
+	}
+	scanner.Close()
+
+	if scanner.Scan() {
+		t.Errorf("should not scan after close")
+	}
+
+	if err := scanner.Err(); err != osm.ErrScannerClosed {
+		t.Errorf("incorrect error: %v", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/ich5003/small-osm/internal/osmpb"
 
@@ -46,7 +47,6 @@ type OSM struct {
 }
 
 // Marshal encodes the osm data using protocol buffers.
-// Will only save the elements: nodes, ways and relations.
 func (o *OSM) Marshal() ([]byte, error) {
 	ss := &stringSet{}
 	encoded := marshalOSM(o, ss, true)
@@ -237,6 +237,27 @@ func marshalOSM(o *OSM, ss *stringSet, includeChangeset bool) *osmpb.OSM {
 		}
 	}
 
+	if len(o.Changesets) > 0 {
+		encoded.Changesets = make([]*osmpb.Changeset, len(o.Changesets))
+		for i, c := range o.Changesets {
+			encoded.Changesets[i] = marshalChangeset(c, ss)
+		}
+	}
+
+	if len(o.Notes) > 0 {
+		encoded.Notes = make([]*osmpb.Note, len(o.Notes))
+		for i, n := range o.Notes {
+			encoded.Notes[i] = marshalNote(n, ss)
+		}
+	}
+
+	if len(o.Users) > 0 {
+		encoded.Users = make([]*osmpb.User, len(o.Users))
+		for i, u := range o.Users {
+			encoded.Users[i] = marshalUser(u, ss)
+		}
+	}
+
 	if o.Bounds != nil {
 		encoded.Bounds = &osmpb.Bounds{
 			MinLat: geoToInt64(o.Bounds.MinLat),
@@ -246,6 +267,27 @@ func marshalOSM(o *OSM, ss *stringSet, includeChangeset bool) *osmpb.OSM {
 		}
 	}
 
+	// only set the attributes if present, they are not set for change data.
+	if o.Version != 0 {
+		encoded.Version = proto.Float64(o.Version)
+	}
+
+	if o.Generator != "" {
+		encoded.Generator = proto.String(o.Generator)
+	}
+
+	if o.Copyright != "" {
+		encoded.Copyright = proto.String(o.Copyright)
+	}
+
+	if o.Attribution != "" {
+		encoded.Attribution = proto.String(o.Attribution)
+	}
+
+	if o.License != "" {
+		encoded.License = proto.String(o.License)
+	}
+
 	return encoded
 }
 
@@ -303,6 +345,32 @@ func unmarshalOSM(encoded *osmpb.OSM, ss []string, cs *Changeset) (*OSM, error)
 		}
 	}
 
+	if len(encoded.Changesets) != 0 {
+		o.Changesets = make([]*Changeset, len(encoded.Changesets))
+		for i, ec := range encoded.Changesets {
+			c, err := unmarshalChangeset(ec, ss)
+			if err != nil {
+				return nil, err
+			}
+
+			o.Changesets[i] = c
+		}
+	}
+
+	if len(encoded.Notes) != 0 {
+		o.Notes = make([]*Note, len(encoded.Notes))
+		for i, en := range encoded.Notes {
+			o.Notes[i] = unmarshalNote(en, ss)
+		}
+	}
+
+	if len(encoded.Users) != 0 {
+		o.Users = make([]*User, len(encoded.Users))
+		for i, eu := range encoded.Users {
+			o.Users[i] = unmarshalUser(eu, ss)
+		}
+	}
+
 	if encoded.Bounds != nil {
 		o.Bounds = &Bounds{
 			MinLat: float64(encoded.Bounds.GetMinLat()) / locMultiple,
@@ -312,6 +380,12 @@ func unmarshalOSM(encoded *osmpb.OSM, ss []string, cs *Changeset) (*OSM, error)
 		}
 	}
 
+	o.Version = encoded.GetVersion()
+	o.Generator = encoded.GetGenerator()
+	o.Copyright = encoded.GetCopyright()
+	o.Attribution = encoded.GetAttribution()
+	o.License = encoded.GetLicense()
+
 	return o, nil
 }
 
@@ -319,19 +393,74 @@ func unmarshalOSM(encoded *osmpb.OSM, ss []string, cs *Changeset) (*OSM, error)
 // as defined by the overpass osmjson.
 // http://overpass-api.de/output_formats.html#json
 func (o OSM) MarshalJSON() ([]byte, error) {
+	elements := o.Objects()
+	if o.Bounds != nil {
+		elements = elements[1:]
+	}
+
 	s := struct {
 		Version     float64 `json:"version,omitempty"`
 		Generator   string  `json:"generator,omitempty"`
 		Copyright   string  `json:"copyright,omitempty"`
 		Attribution string  `json:"attribution,omitempty"`
 		License     string  `json:"license,omitempty"`
+		Bounds      *Bounds `json:"bounds,omitempty"`
 		Elements    Objects `json:"elements"`
 	}{o.Version, o.Generator, o.Copyright,
-		o.Attribution, o.License, o.Objects()}
+		o.Attribution, o.License, o.Bounds, elements}
 
 	return json.Marshal(s)
 }
 
+// UnmarshalJSON will unmarshal the osmjson format returned by the
+// osm api .json endpoints and overpass. The elements are
+// added to the nodes, ways, relations etc. based on their type.
+func (o *OSM) UnmarshalJSON(data []byte) error {
+	s := struct {
+		Version     json.RawMessage   `json:"version"`
+		Generator   string            `json:"generator"`
+		Copyright   string            `json:"copyright"`
+		Attribution string            `json:"attribution"`
+		License     string            `json:"license"`
+		Bounds      *Bounds           `json:"bounds"`
+		Elements    []json.RawMessage `json:"elements"`
+	}{}
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	*o = OSM{
+		Generator:   s.Generator,
+		Copyright:   s.Copyright,
+		Attribution: s.Attribution,
+		License:     s.License,
+		Bounds:      s.Bounds,
+	}
+
+	// the osm api returns the version as a string, overpass as a number
+	if v := strings.Trim(string(s.Version), `"`); v != "" {
+		var err error
+		o.Version, err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("osm: invalid version: %v", err)
+		}
+	}
+
+	for _, e := range s.Elements {
+		obj, err := UnmarshalJSONObject(e)
+		if err != nil {
+			return err
+		}
+
+		if obj != nil {
+			o.Append(obj)
+		}
+	}
+
+	return nil
+}
+
 // MarshalXML implements the xml.Marshaller method to allow for the
 // correct wrapper/start element case and attr data.
 func (o OSM) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
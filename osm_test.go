@@ -209,6 +209,91 @@ func TestOSM_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestOSM_UnmarshalJSON(t *testing.T) {
+	data := []byte(`{"version":"0.6","generator":"OpenStreetMap server","copyright":"OpenStreetMap and contributors",
+"bounds":{"minlat":51.5,"minlon":-0.1,"maxlat":51.6,"maxlon":-0.09},"elements":[
+{"type":"node","id":1,"lat":51.55,"lon":-0.095,"timestamp":"2019-01-01T00:00:00Z","version":2,"changeset":10,"user":"abc","uid":5,"tags":{"name":"x","amenity":"cafe"}},
+{"type":"way","id":2,"timestamp":"2019-01-01T00:00:00Z","version":1,"nodes":[1,3],"tags":{"highway":"residential"}},
+{"type":"relation","id":3,"timestamp":"2019-01-01T00:00:00Z","version":1,"members":[{"type":"way","ref":2,"role":"outer"}]}
+]}`)
+
+	o := &OSM{}
+	if err := json.Unmarshal(data, o); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	if o.Version != 0.6 || o.Generator != "OpenStreetMap server" || o.Copyright != Copyright {
+		t.Errorf("incorrect attributes: %v %v %v", o.Version, o.Generator, o.Copyright)
+	}
+
+	if o.Bounds == nil || o.Bounds.MinLat != 51.5 || o.Bounds.MaxLon != -0.09 {
+		t.Errorf("incorrect bounds: %v", o.Bounds)
+	}
+
+	if len(o.Nodes) != 1 || len(o.Ways) != 1 || len(o.Relations) != 1 {
+		t.Fatalf("incorrect elements: %v %v %v", len(o.Nodes), len(o.Ways), len(o.Relations))
+	}
+
+	if n := o.Nodes[0]; n.Lat != 51.55 || n.User != "abc" || n.Version != 2 {
+		t.Errorf("incorrect node: %+v", n)
+	}
+
+	tags := Tags{{Key: "amenity", Value: "cafe"}, {Key: "name", Value: "x"}}
+	if !reflect.DeepEqual(o.Nodes[0].Tags, tags) {
+		t.Errorf("incorrect tags: %v", o.Nodes[0].Tags)
+	}
+
+	if ids := o.Ways[0].Nodes.NodeIDs(); !reflect.DeepEqual(ids, []NodeID{1, 3}) {
+		t.Errorf("incorrect way nodes: %v", ids)
+	}
+
+	if m := o.Relations[0].Members[0]; m.Type != TypeWay || m.Ref != 2 || m.Role != "outer" {
+		t.Errorf("incorrect member: %+v", m)
+	}
+
+	// overpass uses a number version, round trip through the marshaller
+	marshalled, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+
+	o2 := &OSM{}
+	if err := json.Unmarshal(marshalled, o2); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	if !reflect.DeepEqual(o, o2) {
+		t.Errorf("osm are not equal")
+		t.Logf("%+v", o)
+		t.Logf("%+v", o2)
+	}
+
+	// overpass area and count elements are skipped
+	data = []byte(`{"elements":[
+{"type":"area","id":3600062422,"tags":{"name":"Berlin","type":"boundary"}},
+{"type":"node","id":1,"lat":52.5,"lon":13.4},
+{"type":"count","id":0,"tags":{"nodes":"1","ways":"0","relations":"0","total":"1"}}
+]}`)
+
+	o = &OSM{}
+	if err := json.Unmarshal(data, o); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	if len(o.Nodes) != 1 || o.Nodes[0].ID != 1 || len(o.Objects()) != 1 {
+		t.Errorf("incorrect objects: %+v", o.Objects())
+	}
+
+	// errors
+	if err := json.Unmarshal([]byte(`{"elements":[{"id":1}]}`), o); err == nil {
+		t.Errorf("should return error for elements without a type")
+	}
+
+	if err := json.Unmarshal([]byte(`{"type":"way","id":1}`), &Node{}); err == nil {
+		t.Errorf("should return error for incorrect type")
+	}
+}
+
 func TestOSM_MarshalXML(t *testing.T) {
 	o := &OSM{
 		Version:     0.7,
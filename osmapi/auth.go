@@ -0,0 +1,206 @@
+package osmapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OAuth2TokenURL is the endpoint used to refresh OAuth 2.0 tokens for
+// the production api. Dev servers have their own, for example,
+// https://master.apis.dev.openstreetmap.org/oauth2/token
+const OAuth2TokenURL = "https://www.openstreetmap.org/oauth2/token"
+
+// An Authenticator adds credentials to the api requests made by a Datasource.
+// It is called for every request and must be safe for concurrent use.
+type Authenticator interface {
+	Authenticate(*http.Request) error
+}
+
+// AuthenticatorFunc is an adapter to allow the use of ordinary functions
+// as authenticators. For example, to use a golang.org/x/oauth2.TokenSource:
+//		ds.Authenticator = osmapi.AuthenticatorFunc(func(req *http.Request) error {
+//			token, err := tokenSource.Token()
+//			if err != nil {
+//				return err
+//			}
+//			token.SetAuthHeader(req)
+//			return nil
+//		})
+type AuthenticatorFunc func(*http.Request) error
+
+// Authenticate calls f(req).
+func (f AuthenticatorFunc) Authenticate(req *http.Request) error {
+	return f(req)
+}
+
+// BasicAuth authenticates requests using http basic auth. The production
+// api only supports OAuth 2.0, this is useful for dev servers and tests.
+func BasicAuth(username, password string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	})
+}
+
+// BearerToken authenticates requests using a fixed OAuth 2.0 access token.
+// Tokens issued by openstreetmap.org do not expire so this is usually enough.
+func BearerToken(token string) Authenticator {
+	return AuthenticatorFunc(func(req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+// OAuth2Token is an OAuth 2.0 token as returned by the token endpoint.
+type OAuth2Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+
+	// Expiry is when the access token expires. A zero value
+	// means the token does not expire.
+	Expiry time.Time `json:"-"`
+}
+
+// OAuth2 authenticates requests using an OAuth 2.0 bearer token.
+// If the access token expires it is refreshed using the refresh token.
+type OAuth2 struct {
+	ClientID     string
+	ClientSecret string
+
+	// TokenURL is the endpoint used to refresh the token.
+	// Defaults to OAuth2TokenURL if empty.
+	TokenURL string
+
+	// Client is used to refresh the token, defaults to the http.DefaultClient.
+	Client *http.Client
+
+	mu    sync.Mutex
+	token OAuth2Token
+}
+
+var _ Authenticator = &OAuth2{}
+
+// NewOAuth2 creates an authenticator for the client using the token.
+func NewOAuth2(clientID, clientSecret string, token OAuth2Token) *OAuth2 {
+	return &OAuth2{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		token:        token,
+	}
+}
+
+// Token returns the current token. It will be different from the initial
+// token after a refresh and should be saved for future use.
+func (a *OAuth2) Token() OAuth2Token {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.token
+}
+
+// Authenticate sets the bearer token on the request,
+// refreshing it first if it has expired.
+func (a *OAuth2) Authenticate(req *http.Request) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.expired() {
+		if err := a.refresh(req); err != nil {
+			return err
+		}
+	}
+
+	req.Header.Set("Authorization", "Bearer "+a.token.AccessToken)
+	return nil
+}
+
+// expired returns true if the token should be refreshed, this is done
+// a bit early so it doesn't expire while the request is in flight.
+func (a *OAuth2) expired() bool {
+	if a.token.AccessToken == "" {
+		return true
+	}
+
+	if a.token.Expiry.IsZero() {
+		return false
+	}
+
+	return time.Now().Add(10 * time.Second).After(a.token.Expiry)
+}
+
+func (a *OAuth2) refresh(req *http.Request) error {
+	if a.token.RefreshToken == "" {
+		return fmt.Errorf("osmapi: oauth2 token expired without a refresh token")
+	}
+
+	tokenURL := a.TokenURL
+	if tokenURL == "" {
+		tokenURL = OAuth2TokenURL
+	}
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {a.token.RefreshToken},
+		"client_id":     {a.ClientID},
+	}
+	if a.ClientSecret != "" {
+		form.Set("client_secret", a.ClientSecret)
+	}
+
+	r, err := http.NewRequest(http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := client.Do(r.WithContext(req.Context()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("osmapi: oauth2 refresh failed with status %d: %s", resp.StatusCode, data)
+	}
+
+	var result struct {
+		OAuth2Token
+		ExpiresIn int64 `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("osmapi: invalid oauth2 token response: %v", err)
+	}
+
+	if result.AccessToken == "" {
+		return fmt.Errorf("osmapi: oauth2 token response without access token")
+	}
+
+	token := result.OAuth2Token
+	if token.RefreshToken == "" {
+		// the refresh token can be reused if a new one is not issued
+		token.RefreshToken = a.token.RefreshToken
+	}
+
+	if result.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+
+	a.token = token
+	return nil
+}
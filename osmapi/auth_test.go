@@ -0,0 +1,134 @@
+package osmapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDatasource_Authenticator(t *testing.T) {
+	ctx := context.Background()
+
+	auth := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte(`<osm></osm>`))
+	}))
+	defer ts.Close()
+
+	cases := []struct {
+		name          string
+		authenticator Authenticator
+		expected      string
+	}{
+		{
+			name:          "none",
+			authenticator: nil,
+			expected:      "",
+		},
+		{
+			name:          "basic auth",
+			authenticator: BasicAuth("user", "pass"),
+			expected:      "Basic dXNlcjpwYXNz",
+		},
+		{
+			name:          "bearer token",
+			authenticator: BearerToken("abc"),
+			expected:      "Bearer abc",
+		},
+		{
+			name:          "oauth2",
+			authenticator: NewOAuth2("id", "", OAuth2Token{AccessToken: "def"}),
+			expected:      "Bearer def",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ds := &Datasource{BaseURL: ts.URL, Authenticator: tc.authenticator}
+
+			// reads and writes are authenticated
+			ds.User(ctx, 1)
+			if auth != tc.expected {
+				t.Errorf("incorrect read auth: %v", auth)
+			}
+
+			auth = "not set"
+			ds.CloseChangeset(ctx, 1)
+			if auth != tc.expected {
+				t.Errorf("incorrect write auth: %v", auth)
+			}
+		})
+	}
+}
+
+func TestOAuth2_refresh(t *testing.T) {
+	ctx := context.Background()
+
+	refreshes := 0
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		refreshes++
+		if v := r.PostFormValue("grant_type"); v != "refresh_token" {
+			t.Errorf("incorrect grant type: %v", v)
+		}
+
+		if v := r.PostFormValue("refresh_token"); v != "refresh" {
+			t.Errorf("incorrect refresh token: %v", v)
+		}
+
+		if v := r.PostFormValue("client_id"); v != "id" {
+			t.Errorf("incorrect client id: %v", v)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"new","token_type":"Bearer","expires_in":3600}`))
+	}))
+	defer tokens.Close()
+
+	auth := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		w.Write([]byte(`<osm><user id="1" display_name="user"></user></osm>`))
+	}))
+	defer ts.Close()
+
+	a := NewOAuth2("id", "secret", OAuth2Token{
+		AccessToken:  "old",
+		RefreshToken: "refresh",
+		Expiry:       time.Now().Add(-time.Minute),
+	})
+	a.TokenURL = tokens.URL
+
+	ds := &Datasource{BaseURL: ts.URL, Authenticator: a}
+	for i := 0; i < 2; i++ {
+		if _, err := ds.UserDetails(ctx); err != nil {
+			t.Fatalf("request error: %v", err)
+		}
+	}
+
+	if auth != "Bearer new" {
+		t.Errorf("incorrect auth: %v", auth)
+	}
+
+	if refreshes != 1 {
+		t.Errorf("should refresh once: %v", refreshes)
+	}
+
+	token := a.Token()
+	if token.AccessToken != "new" || token.RefreshToken != "refresh" {
+		t.Errorf("incorrect token: %+v", token)
+	}
+
+	if d := time.Until(token.Expiry); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("incorrect expiry: %v", token.Expiry)
+	}
+
+	// expired without refresh token
+	a = NewOAuth2("id", "", OAuth2Token{AccessToken: "old", Expiry: time.Now()})
+	ds.Authenticator = a
+	if _, err := ds.UserDetails(ctx); err == nil {
+		t.Errorf("should return error")
+	}
+}
@@ -2,7 +2,12 @@ package osmapi
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/ich5003/small-osm"
 )
@@ -44,6 +49,76 @@ func (ds *Datasource) getChangeset(ctx context.Context, url string) (*osm.Change
 	return css.Changesets[0], nil
 }
 
+// changesetsPageSize is the maximum number of changesets returned by the
+// api for a query. Larger results are requested in multiple pages.
+const changesetsPageSize = 100
+
+// Changesets returns the changesets matching the options from the osm rest api.
+// The most recently created changesets are first. The api returns at most 100
+// changesets per request so more are requested, using the created before time,
+// until all the matching changesets are found. An error is returned if more than
+// 100 changesets were created in the same second since they can't be paged through.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func Changesets(ctx context.Context, opts ...ChangesetsOption) (osm.Changesets, error) {
+	return DefaultDatasource.Changesets(ctx, opts...)
+}
+
+// Changesets returns the changesets matching the options from the osm rest api.
+// The most recently created changesets are first. The api returns at most 100
+// changesets per request so more are requested, using the created before time,
+// until all the matching changesets are found. An error is returned if more than
+// 100 changesets were created in the same second since they can't be paged through.
+func (ds *Datasource) Changesets(ctx context.Context, opts ...ChangesetsOption) (osm.Changesets, error) {
+	q := &changesetsQuery{}
+	for _, o := range opts {
+		if err := o.applyChangesets(q); err != nil {
+			return nil, err
+		}
+	}
+
+	var result osm.Changesets
+	seen := make(map[osm.ChangesetID]struct{})
+	for {
+		url := fmt.Sprintf("%s/changesets?%s", ds.baseURL(), q.values())
+
+		o := &osm.OSM{}
+		if err := ds.getFromAPI(ctx, url, &o); err != nil {
+			return nil, err
+		}
+
+		added := 0
+		oldest := time.Time{}
+		for _, cs := range o.Changesets {
+			if oldest.IsZero() || cs.CreatedAt.Before(oldest) {
+				oldest = cs.CreatedAt
+			}
+
+			if _, ok := seen[cs.ID]; ok {
+				continue
+			}
+			seen[cs.ID] = struct{}{}
+
+			result = append(result, cs)
+			added++
+		}
+
+		if len(o.Changesets) < changesetsPageSize {
+			return result, nil
+		}
+
+		// The created before time is exclusive with a precision of seconds,
+		// the next page starts with the second of the oldest changeset
+		// and the duplicates are skipped. A full page of duplicates means
+		// there are more changesets in that second than fit on a page.
+		if added == 0 {
+			return nil, fmt.Errorf("osmapi: more than %d changesets created at %s, unable to page through them",
+				changesetsPageSize, formatTime(oldest))
+		}
+
+		q.createdBefore = oldest.Truncate(time.Second).Add(time.Second)
+	}
+}
+
 // ChangesetDownload returns the full osmchange for the changeset using the osm rest api.
 // Delegates to the DefaultDatasource and uses its http.Client to make the request.
 func ChangesetDownload(ctx context.Context, id osm.ChangesetID) (*osm.Change, error) {
@@ -61,3 +136,173 @@ func (ds *Datasource) ChangesetDownload(ctx context.Context, id osm.ChangesetID)
 
 	return change, nil
 }
+
+// CreateChangeset opens a new changeset with the given tags using the osm rest api.
+// Returns the id of the new changeset to use when uploading changes.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func CreateChangeset(ctx context.Context, tags osm.Tags) (osm.ChangesetID, error) {
+	return DefaultDatasource.CreateChangeset(ctx, tags)
+}
+
+// CreateChangeset opens a new changeset with the given tags using the osm rest api.
+// Returns the id of the new changeset to use when uploading changes.
+func (ds *Datasource) CreateChangeset(ctx context.Context, tags osm.Tags) (osm.ChangesetID, error) {
+	url := fmt.Sprintf("%s/changeset/create", ds.baseURL())
+
+	var result string
+	if err := ds.sendToAPI(ctx, http.MethodPut, url, changesetRequest(tags), &result); err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.ParseInt(strings.TrimSpace(result), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("osmapi: invalid changeset id %q: %v", result, err)
+	}
+
+	return osm.ChangesetID(id), nil
+}
+
+// UpdateChangeset replaces the tags of an open changeset using the osm rest api.
+// Returns the updated changeset.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func UpdateChangeset(ctx context.Context, id osm.ChangesetID, tags osm.Tags) (*osm.Changeset, error) {
+	return DefaultDatasource.UpdateChangeset(ctx, id, tags)
+}
+
+// UpdateChangeset replaces the tags of an open changeset using the osm rest api.
+// Returns the updated changeset.
+func (ds *Datasource) UpdateChangeset(ctx context.Context, id osm.ChangesetID, tags osm.Tags) (*osm.Changeset, error) {
+	url := fmt.Sprintf("%s/changeset/%d", ds.baseURL(), id)
+
+	css := &osm.OSM{}
+	if err := ds.sendToAPI(ctx, http.MethodPut, url, changesetRequest(tags), &css); err != nil {
+		return nil, err
+	}
+
+	if l := len(css.Changesets); l != 1 {
+		return nil, fmt.Errorf("wrong number of changesets, expected 1, got %v", l)
+	}
+
+	return css.Changesets[0], nil
+}
+
+// UploadChangeset uploads the change to an open changeset using the osm rest api.
+// The changeset of all the nodes, ways and relations in the change is set to the id.
+// New elements should use negative placeholder ids, the returned diff result
+// maps them to the ids and versions assigned by the server.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func UploadChangeset(ctx context.Context, id osm.ChangesetID, change *osm.Change) (*DiffResult, error) {
+	return DefaultDatasource.UploadChangeset(ctx, id, change)
+}
+
+// UploadChangeset uploads the change to an open changeset using the osm rest api.
+// The changeset of all the nodes, ways and relations in the change is set to the id.
+// New elements should use negative placeholder ids, the returned diff result
+// maps them to the ids and versions assigned by the server.
+func (ds *Datasource) UploadChangeset(ctx context.Context, id osm.ChangesetID, change *osm.Change) (*DiffResult, error) {
+	url := fmt.Sprintf("%s/changeset/%d/upload", ds.baseURL(), id)
+
+	setChangesetID(change.Create, id)
+	setChangesetID(change.Modify, id)
+	setChangesetID(change.Delete, id)
+
+	result := &DiffResult{}
+	if err := ds.sendToAPI(ctx, http.MethodPost, url, change, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CloseChangeset closes an open changeset using the osm rest api.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func CloseChangeset(ctx context.Context, id osm.ChangesetID) error {
+	return DefaultDatasource.CloseChangeset(ctx, id)
+}
+
+// CloseChangeset closes an open changeset using the osm rest api.
+func (ds *Datasource) CloseChangeset(ctx context.Context, id osm.ChangesetID) error {
+	url := fmt.Sprintf("%s/changeset/%d/close", ds.baseURL(), id)
+	return ds.sendToAPI(ctx, http.MethodPut, url, nil, nil)
+}
+
+// changesetRequest returns the body for creating or updating a changeset.
+// Only the tags can be set by the client, the other attributes are
+// managed by the server.
+func changesetRequest(tags osm.Tags) interface{} {
+	type changeset struct {
+		Tags osm.Tags `xml:"tag"`
+	}
+
+	return &struct {
+		XMLName   xml.Name  `xml:"osm"`
+		Changeset changeset `xml:"changeset"`
+	}{
+		Changeset: changeset{Tags: tags},
+	}
+}
+
+func setChangesetID(o *osm.OSM, id osm.ChangesetID) {
+	if o == nil {
+		return
+	}
+
+	for _, n := range o.Nodes {
+		n.ChangesetID = id
+	}
+
+	for _, w := range o.Ways {
+		w.ChangesetID = id
+	}
+
+	for _, r := range o.Relations {
+		r.ChangesetID = id
+	}
+}
+
+// DiffResult is the response to a changeset upload. It has a result for
+// every node, way and relation in the change, in the same order.
+type DiffResult struct {
+	Version   string           `xml:"version,attr"`
+	Generator string           `xml:"generator,attr"`
+	Results   []DiffResultItem `xml:",any"`
+}
+
+// DiffResultItem maps the id of an uploaded element to its new id and version.
+// For created elements the old id is the placeholder id from the change.
+// For deleted elements the new id and version are zero.
+type DiffResultItem struct {
+	Type       osm.Type
+	OldID      int64
+	NewID      int64
+	NewVersion int
+}
+
+// UnmarshalXML decodes a result element, e.g.
+// <node old_id="-1" new_id="123" new_version="1"/>.
+func (dri *DiffResultItem) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	dri.Type = osm.Type(start.Name.Local)
+
+	var err error
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "old_id":
+			dri.OldID, err = strconv.ParseInt(attr.Value, 10, 64)
+		case "new_id":
+			dri.NewID, err = strconv.ParseInt(attr.Value, 10, 64)
+		case "new_version":
+			dri.NewVersion, err = strconv.Atoi(attr.Value)
+		}
+
+		if err != nil {
+			return fmt.Errorf("osmapi: invalid diff result %s: %v", attr.Name.Local, err)
+		}
+	}
+
+	return d.Skip()
+}
+
+// Deleted returns true if the result is for a deleted element.
+func (dri DiffResultItem) Deleted() bool {
+	return dri.NewID == 0
+}
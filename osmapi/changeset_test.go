@@ -2,10 +2,16 @@ package osmapi
 
 import (
 	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/ich5003/small-osm"
 )
 
 func TestChangeset_urls(t *testing.T) {
@@ -37,6 +43,24 @@ func TestChangeset_urls(t *testing.T) {
 		}
 	})
 
+	t.Run("changesets", func(t *testing.T) {
+		Changesets(ctx,
+			Bounds(&osm.Bounds{MinLat: 1, MaxLat: 2, MinLon: 3, MaxLon: 4}),
+			DisplayName("a b"),
+			OnlyClosed(),
+			ChangesetIDs(1, 2),
+			ClosedAfter(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)),
+		)
+		if !strings.Contains(url, "changesets?bbox=3.000000,1.000000,4.000000,2.000000&display_name=a+b&closed=true&changesets=1,2&time=2019-01-01T00:00:00Z") {
+			t.Errorf("incorrect path: %v", url)
+		}
+
+		Changesets(ctx, UserID(5), OnlyOpen(), CreatedBefore(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)))
+		if !strings.Contains(url, "changesets?user=5&open=true&time=1970-01-01T00:00:00Z,2019-01-01T00:00:00Z") {
+			t.Errorf("incorrect path: %v", url)
+		}
+	})
+
 	t.Run("changeset download", func(t *testing.T) {
 		ChangesetDownload(ctx, 1)
 		if !strings.Contains(url, "changeset/1/download") {
@@ -44,3 +68,262 @@ func TestChangeset_urls(t *testing.T) {
 		}
 	})
 }
+
+func TestChangeset_write(t *testing.T) {
+	ctx := context.Background()
+
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		body, _ := ioutil.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/changeset/create":
+			if !strings.Contains(string(body), `<osm><changeset><tag k="comment" v="fix"></tag></changeset></osm>`) {
+				t.Errorf("incorrect create body: %s", body)
+			}
+			w.Write([]byte("123\n"))
+		case "/changeset/123":
+			w.Write([]byte(`<osm><changeset id="123" open="true"><tag k="comment" v="update"/></changeset></osm>`))
+		case "/changeset/123/upload":
+			c := &osm.Change{}
+			if err := xml.Unmarshal(body, c); err != nil {
+				t.Fatalf("unable to unmarshal upload: %v", err)
+			}
+
+			if c.Create.Nodes[0].ChangesetID != 123 || c.Delete.Ways[0].ChangesetID != 123 {
+				t.Errorf("changeset id not set: %s", body)
+			}
+
+			w.Write([]byte(`<diffResult version="0.6" generator="fake">
+				<node old_id="-1" new_id="1001" new_version="1"/>
+				<way old_id="5" />
+			</diffResult>`))
+		case "/changeset/123/close":
+		default:
+			t.Errorf("unexpected request: %v", r.URL)
+		}
+	}))
+	defer ts.Close()
+
+	ds := &Datasource{BaseURL: ts.URL}
+
+	id, err := ds.CreateChangeset(ctx, osm.Tags{{Key: "comment", Value: "fix"}})
+	if err != nil {
+		t.Fatalf("create error: %v", err)
+	}
+
+	if id != 123 {
+		t.Errorf("incorrect id: %v", id)
+	}
+
+	cs, err := ds.UpdateChangeset(ctx, id, osm.Tags{{Key: "comment", Value: "update"}})
+	if err != nil {
+		t.Fatalf("update error: %v", err)
+	}
+
+	if v := cs.Comment(); v != "update" {
+		t.Errorf("incorrect comment: %v", v)
+	}
+
+	change := &osm.Change{
+		Create: &osm.OSM{Nodes: osm.Nodes{{ID: -1, Lat: 1, Lon: 2}}},
+		Delete: &osm.OSM{Ways: osm.Ways{{ID: 5, Version: 3}}},
+	}
+
+	result, err := ds.UploadChangeset(ctx, id, change)
+	if err != nil {
+		t.Fatalf("upload error: %v", err)
+	}
+
+	expected := []DiffResultItem{
+		{Type: osm.TypeNode, OldID: -1, NewID: 1001, NewVersion: 1},
+		{Type: osm.TypeWay, OldID: 5},
+	}
+	if len(result.Results) != len(expected) {
+		t.Fatalf("incorrect results: %+v", result.Results)
+	}
+
+	for i, r := range result.Results {
+		if r != expected[i] {
+			t.Errorf("incorrect result: %+v != %+v", r, expected[i])
+		}
+	}
+
+	if !result.Results[1].Deleted() {
+		t.Errorf("way should be deleted")
+	}
+
+	if err := ds.CloseChangeset(ctx, id); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	expectedRequests := []string{
+		"PUT /changeset/create",
+		"PUT /changeset/123",
+		"POST /changeset/123/upload",
+		"PUT /changeset/123/close",
+	}
+	if strings.Join(requests, ",") != strings.Join(expectedRequests, ",") {
+		t.Errorf("incorrect requests: %v", requests)
+	}
+}
+
+func TestChangeset_writeErrors(t *testing.T) {
+	ctx := context.Background()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/changeset/1/close":
+			w.Header().Set("Error", "The changeset 1 was closed at 2019-01-01 00:00:00 UTC")
+			w.WriteHeader(http.StatusConflict)
+		case "/changeset/2/upload":
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte("Way -1 requires the nodes with id in (-5), which either do not exist, or are not visible."))
+		case "/changeset/create":
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer ts.Close()
+
+	ds := &Datasource{BaseURL: ts.URL}
+
+	err := ds.CloseChangeset(ctx, 1)
+	if e, ok := err.(*ConflictError); !ok || !strings.Contains(e.Message, "was closed") {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	_, err = ds.UploadChangeset(ctx, 2, &osm.Change{})
+	if e, ok := err.(*PreconditionFailedError); !ok || !strings.Contains(e.Message, "requires the nodes") {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	_, err = ds.CreateChangeset(ctx, nil)
+	if _, ok := err.(*UnauthorizedError); !ok {
+		t.Errorf("incorrect error: %v", err)
+	}
+}
+
+func TestChangesets_pagination(t *testing.T) {
+	ctx := context.Background()
+
+	// 250 changesets, one a minute, except two pairs created in the same
+	// second at the page boundaries.
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var all osm.Changesets
+	for i := 0; i < 250; i++ {
+		created := start.Add(-time.Duration(i) * time.Minute)
+		if i == 100 || i == 200 {
+			created = all[i-1].CreatedAt
+		}
+
+		all = append(all, &osm.Changeset{ID: osm.ChangesetID(1000 - i), CreatedAt: created})
+	}
+
+	requests := 0
+	ts := changesetsServer(t, all, &requests)
+	defer ts.Close()
+
+	ds := &Datasource{BaseURL: ts.URL}
+	css, err := ds.Changesets(ctx, UserID(1))
+	if err != nil {
+		t.Fatalf("request error: %v", err)
+	}
+
+	if len(css) != len(all) {
+		t.Fatalf("incorrect number of changesets: %v", len(css))
+	}
+
+	for i, cs := range css {
+		if cs.ID != all[i].ID {
+			t.Errorf("incorrect changeset %d: %v != %v", i, cs.ID, all[i].ID)
+		}
+	}
+
+	if requests != 3 {
+		t.Errorf("incorrect number of requests: %v", requests)
+	}
+}
+
+func TestChangesets_sameSecond(t *testing.T) {
+	ctx := context.Background()
+
+	// more changesets created in the same second than fit on a page
+	start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	var all osm.Changesets
+	for i := 0; i < 150; i++ {
+		created := start.Add(-time.Duration(i) * time.Minute)
+		if i >= 10 && i < 130 {
+			created = start.Add(-10 * time.Minute)
+		}
+
+		all = append(all, &osm.Changeset{ID: osm.ChangesetID(1000 - i), CreatedAt: created})
+	}
+
+	requests := 0
+	ts := changesetsServer(t, all, &requests)
+	defer ts.Close()
+
+	ds := &Datasource{BaseURL: ts.URL}
+	css, err := ds.Changesets(ctx, UserID(1))
+	if err == nil || !strings.Contains(err.Error(), "2018-12-31T23:50:00Z") {
+		t.Errorf("should return error for too many changesets in one second: %v", err)
+	}
+
+	if css != nil {
+		t.Errorf("should not return partial results: %v", len(css))
+	}
+}
+
+// changesetsServer returns a fake api that pages through the changesets,
+// which must be sorted by created time, newest first.
+func changesetsServer(t *testing.T, all osm.Changesets, requests *int) *httptest.Server {
+	start := all[0].CreatedAt
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		before := start.Add(time.Hour)
+		if v := r.URL.Query().Get("time"); v != "" {
+			parts := strings.Split(v, ",")
+			if len(parts) != 2 {
+				t.Fatalf("incorrect time: %v", v)
+			}
+
+			var err error
+			before, err = time.Parse(time.RFC3339, parts[1])
+			if err != nil {
+				t.Fatalf("invalid time: %v", err)
+			}
+		}
+
+		w.Write([]byte(`<osm>`))
+		count := 0
+		for _, cs := range all {
+			if count == changesetsPageSize || !cs.CreatedAt.Before(before) {
+				continue
+			}
+
+			fmt.Fprintf(w, `<changeset id="%d" created_at="%s"/>`, cs.ID, cs.CreatedAt.Format(time.RFC3339))
+			count++
+		}
+		w.Write([]byte(`</osm>`))
+	}))
+}
+
+func TestChangesets_errors(t *testing.T) {
+	ctx := context.Background()
+	ds := &Datasource{BaseURL: "http://127.0.0.1:0"}
+
+	if _, err := ds.Changesets(ctx, UserID(1), DisplayName("a")); err == nil {
+		t.Errorf("should return error for user id and display name")
+	}
+
+	if _, err := ds.Changesets(ctx, ChangesetIDs()); err == nil {
+		t.Errorf("should return error for no ids")
+	}
+
+	if _, err := ds.Changesets(ctx, Bounds(nil)); err == nil {
+		t.Errorf("should return error for nil bounds")
+	}
+}
@@ -2,10 +2,14 @@
 package osmapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/xml"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/ich5003/small-osm"
@@ -32,6 +36,14 @@ type Datasource struct {
 	// See the RateLimiter docs for more information.
 	Limiter RateLimiter
 
+	// If Authenticator is non-nil it is used to add credentials to every
+	// request. Editing and some other requests, like UserDetails, require it.
+	Authenticator Authenticator
+
+	// If Retry is non-nil failed requests are retried using the policy.
+	// See the RetryPolicy docs for which requests are retried.
+	Retry *RetryPolicy
+
 	BaseURL string
 	Client  *http.Client
 }
@@ -54,6 +66,28 @@ func NewDatasource(client *http.Client) *Datasource {
 }
 
 func (ds *Datasource) getFromAPI(ctx context.Context, url string, item interface{}) error {
+	return ds.doRequest(ctx, http.MethodGet, url, nil, item)
+}
+
+// sendToAPI encodes the body as xml and sends it to the api using the method.
+// The request must be authenticated, see the Datasource Authenticator.
+func (ds *Datasource) sendToAPI(ctx context.Context, method, url string, body, item interface{}) error {
+	var data []byte
+	if body != nil {
+		var err error
+		data, err = xml.Marshal(body)
+		if err != nil {
+			return err
+		}
+	}
+
+	return ds.doRequest(ctx, method, url, data, item)
+}
+
+// doRequest makes the request and decodes the xml response into the item.
+// If the item is a *string the raw response is returned, a nil item
+// discards the response. Failed requests are retried using the Retry policy.
+func (ds *Datasource) doRequest(ctx context.Context, method, url string, body []byte, item interface{}) error {
 	client := ds.Client
 	if client == nil {
 		client = DefaultDatasource.Client
@@ -63,24 +97,69 @@ func (ds *Datasource) getFromAPI(ctx context.Context, url string, item interface
 		client = http.DefaultClient
 	}
 
+	for attempt := 0; ; attempt++ {
+		resp, err := ds.send(ctx, client, method, url, body)
+		if wait, ok := ds.Retry.retry(ctx, attempt, method, resp, err); ok {
+			if resp != nil {
+				resp.Body.Close()
+			}
+
+			if err := sleep(ctx, wait); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = decodeResponse(resp, url, item)
+		resp.Body.Close()
+
+		return err
+	}
+}
+
+// send makes a single attempt of the request. It waits for the rate
+// limiter so retries are also limited.
+func (ds *Datasource) send(ctx context.Context, client *http.Client, method, url string, body []byte) (*http.Response, error) {
 	if ds.Limiter != nil {
 		err := ds.Limiter.Wait(ctx)
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return err
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
 	}
 
-	resp, err := client.Do(req.WithContext(ctx))
+	req, err := http.NewRequest(method, url, r)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "text/xml; charset=utf-8")
+	}
+
+	if ds.Authenticator != nil {
+		err := ds.Authenticator.Authenticate(req)
+		if err != nil {
+			return nil, err
+		}
 	}
-	defer resp.Body.Close()
 
+	return client.Do(req)
+}
+
+// decodeResponse converts error status codes into errors and decodes
+// the response into the item.
+func decodeResponse(resp *http.Response, url string, item interface{}) error {
 	if resp.StatusCode == http.StatusNotFound {
 		return &NotFoundError{URL: url}
 	}
@@ -97,6 +176,18 @@ func (ds *Datasource) getFromAPI(ctx context.Context, url string, item interface
 		return &RequestURITooLongError{URL: url}
 	}
 
+	if resp.StatusCode == http.StatusUnauthorized {
+		return &UnauthorizedError{URL: url}
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		return &ConflictError{URL: url, Message: errorMessage(resp)}
+	}
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return &PreconditionFailedError{URL: url, Message: errorMessage(resp)}
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return &UnexpectedStatusCodeError{
 			Code: resp.StatusCode,
@@ -104,9 +195,33 @@ func (ds *Datasource) getFromAPI(ctx context.Context, url string, item interface
 		}
 	}
 
+	switch item := item.(type) {
+	case nil:
+		return nil
+	case *string:
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+
+		*item = string(data)
+		return nil
+	}
+
 	return xml.NewDecoder(resp.Body).Decode(item)
 }
 
+// errorMessage returns the reason the api gave for rejecting a request.
+// It is in the Error header and the plain text body of the response.
+func errorMessage(resp *http.Response) string {
+	if m := resp.Header.Get("Error"); m != "" {
+		return m
+	}
+
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	return strings.TrimSpace(string(data))
+}
+
 func (ds *Datasource) baseURL() string {
 	if ds.BaseURL != "" {
 		return ds.BaseURL
@@ -167,6 +282,41 @@ func (e *RequestURITooLongError) Error() string {
 	return fmt.Sprintf("osmapi: uri too long at %s", e.URL)
 }
 
+// UnauthorizedError means 401 from the api. Returned for requests that
+// require authentication, like creating a changeset, without valid credentials.
+type UnauthorizedError struct {
+	URL string
+}
+
+// Error returns an error message with the url causing the problem.
+func (e *UnauthorizedError) Error() string {
+	return fmt.Sprintf("osmapi: unauthorized at %s", e.URL)
+}
+
+// ConflictError means 409 from the api. Returned when modifying a closed
+// changeset, one owned by another user or an element with an outdated version.
+type ConflictError struct {
+	URL     string
+	Message string
+}
+
+// Error returns an error message with the url and the reason from the api.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("osmapi: conflict at %s: %s", e.URL, e.Message)
+}
+
+// PreconditionFailedError means 412 from the api. Returned when an upload
+// references missing elements or deletes elements that are still used.
+type PreconditionFailedError struct {
+	URL     string
+	Message string
+}
+
+// Error returns an error message with the url and the reason from the api.
+func (e *PreconditionFailedError) Error() string {
+	return fmt.Sprintf("osmapi: precondition failed at %s: %s", e.URL, e.Message)
+}
+
 // UnexpectedStatusCodeError is return for a non 200 or 404 status code.
 type UnexpectedStatusCodeError struct {
 	Code int
@@ -70,13 +70,17 @@ func (ds *Datasource) Nodes(ctx context.Context, ids []osm.NodeID, opts ...Featu
 
 // NodeVersion returns the specific version of the node from the osm rest api.
 // Delegates to the DefaultDatasource and uses its http.Client to make the request.
-func NodeVersion(ctx context.Context, id osm.NodeID, v int) (*osm.Node, error) {
-	return DefaultDatasource.NodeVersion(ctx, id, v)
+func NodeVersion(ctx context.Context, id osm.NodeID, v int, opts ...FeatureOption) (*osm.Node, error) {
+	return DefaultDatasource.NodeVersion(ctx, id, v, opts...)
 }
 
 // NodeVersion returns the specific version of the node from the osm rest api.
-func (ds *Datasource) NodeVersion(ctx context.Context, id osm.NodeID, v int) (*osm.Node, error) {
-	url := fmt.Sprintf("%s/node/%d/%d", ds.baseURL(), id, v)
+func (ds *Datasource) NodeVersion(ctx context.Context, id osm.NodeID, v int, opts ...FeatureOption) (*osm.Node, error) {
+	params, err := featureOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/node/%d/%d?%s", ds.baseURL(), id, v, params)
 
 	o := &osm.OSM{}
 	if err := ds.getFromAPI(ctx, url, &o); err != nil {
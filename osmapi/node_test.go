@@ -55,6 +55,11 @@ func TestNode_urls(t *testing.T) {
 		if !strings.Contains(url, "node/1/2") {
 			t.Errorf("incorrect path: %v", url)
 		}
+
+		NodeVersion(ctx, 1, 2, ShowRedactions())
+		if !strings.Contains(url, "node/1/2?show_redactions=true") {
+			t.Errorf("incorrect path: %v", url)
+		}
 	})
 
 	t.Run("node history", func(t *testing.T) {
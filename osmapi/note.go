@@ -3,8 +3,10 @@ package osmapi
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/ich5003/small-osm"
 )
@@ -40,6 +42,10 @@ func Notes(ctx context.Context, bounds *osm.Bounds, opts ...NotesOption) (osm.No
 
 var _ NotesOption = Limit(1)
 var _ NotesOption = MaxDaysClosed(1)
+var _ NotesSearchOption = UserID(1)
+var _ NotesSearchOption = From(time.Time{})
+var _ NotesSearchOption = Sort(NotesSortCreatedAt)
+var _ NotesSearchOption = Order(NotesOrderNewest)
 
 // Notes returns the notes in a bounding box. Can provide options to limit the results
 // or change what it means to be "closed". See the options or osm api v0.6 docs for details.
@@ -71,20 +77,21 @@ func (ds *Datasource) Notes(ctx context.Context, bounds *osm.Bounds, opts ...Not
 // Can provide options to limit the results or change what it means to be "closed".
 // See the options or osm api v0.6 docs for details.
 // Delegates to the DefaultDatasource and uses its http.Client to make the request.
-func NotesSearch(ctx context.Context, query string, opts ...NotesOption) (osm.Notes, error) {
+func NotesSearch(ctx context.Context, query string, opts ...NotesSearchOption) (osm.Notes, error) {
 	return DefaultDatasource.NotesSearch(ctx, query, opts...)
 }
 
 // NotesSearch returns the notes whose text matches the query.
 // Can provide options to limit the results or change what it means to be "closed".
+// The results can also be filtered by user and date and sorted.
 // See the options or osm api v0.6 docs for details.
-func (ds *Datasource) NotesSearch(ctx context.Context, query string, opts ...NotesOption) (osm.Notes, error) {
+func (ds *Datasource) NotesSearch(ctx context.Context, query string, opts ...NotesSearchOption) (osm.Notes, error) {
 	params := make([]string, 0, 1+len(opts))
 	params = append(params, fmt.Sprintf("q=%s", url.QueryEscape(query)))
 
 	var err error
 	for _, o := range opts {
-		params, err = o.applyNotes(params)
+		params, err = o.applyNotesSearch(params)
 		if err != nil {
 			return nil, err
 		}
@@ -99,3 +106,95 @@ func (ds *Datasource) NotesSearch(ctx context.Context, query string, opts ...Not
 
 	return o.Notes, nil
 }
+
+// CreateNote creates a new note at the location with the text using the osm rest api.
+// If the request is not authenticated the note is created anonymously.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func CreateNote(ctx context.Context, lat, lon float64, text string) (*osm.Note, error) {
+	return DefaultDatasource.CreateNote(ctx, lat, lon, text)
+}
+
+// CreateNote creates a new note at the location with the text using the osm rest api.
+// If the request is not authenticated the note is created anonymously.
+func (ds *Datasource) CreateNote(ctx context.Context, lat, lon float64, text string) (*osm.Note, error) {
+	url := fmt.Sprintf("%s/notes?lat=%f&lon=%f&text=%s", ds.baseURL(), lat, lon, url.QueryEscape(text))
+	return ds.sendNote(ctx, http.MethodPost, url)
+}
+
+// CommentNote adds a comment to an open note using the osm rest api.
+// Returns the updated note.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func CommentNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	return DefaultDatasource.CommentNote(ctx, id, text)
+}
+
+// CommentNote adds a comment to an open note using the osm rest api.
+// Returns the updated note.
+func (ds *Datasource) CommentNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	url := fmt.Sprintf("%s/notes/%d/comment?text=%s", ds.baseURL(), id, url.QueryEscape(text))
+	return ds.sendNote(ctx, http.MethodPost, url)
+}
+
+// CloseNote closes the note, with an optional comment, using the osm rest api.
+// Returns the updated note.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func CloseNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	return DefaultDatasource.CloseNote(ctx, id, text)
+}
+
+// CloseNote closes the note, with an optional comment, using the osm rest api.
+// Returns the updated note.
+func (ds *Datasource) CloseNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	url := fmt.Sprintf("%s/notes/%d/close%s", ds.baseURL(), id, noteText(text))
+	return ds.sendNote(ctx, http.MethodPost, url)
+}
+
+// ReopenNote reopens a closed note, with an optional comment, using the osm rest api.
+// Returns the updated note.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func ReopenNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	return DefaultDatasource.ReopenNote(ctx, id, text)
+}
+
+// ReopenNote reopens a closed note, with an optional comment, using the osm rest api.
+// Returns the updated note.
+func (ds *Datasource) ReopenNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	url := fmt.Sprintf("%s/notes/%d/reopen%s", ds.baseURL(), id, noteText(text))
+	return ds.sendNote(ctx, http.MethodPost, url)
+}
+
+// HideNote hides the note, with an optional comment, using the osm rest api.
+// Only moderators can hide notes. Returns the updated note.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func HideNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	return DefaultDatasource.HideNote(ctx, id, text)
+}
+
+// HideNote hides the note, with an optional comment, using the osm rest api.
+// Only moderators can hide notes. Returns the updated note.
+func (ds *Datasource) HideNote(ctx context.Context, id osm.NoteID, text string) (*osm.Note, error) {
+	url := fmt.Sprintf("%s/notes/%d%s", ds.baseURL(), id, noteText(text))
+	return ds.sendNote(ctx, http.MethodDelete, url)
+}
+
+func (ds *Datasource) sendNote(ctx context.Context, method, url string) (*osm.Note, error) {
+	o := &osm.OSM{}
+	if err := ds.sendToAPI(ctx, method, url, nil, &o); err != nil {
+		return nil, err
+	}
+
+	if l := len(o.Notes); l != 1 {
+		return nil, fmt.Errorf("wrong number of notes, expected 1, got %v", l)
+	}
+
+	return o.Notes[0], nil
+}
+
+// noteText returns the query string for the optional comment text.
+func noteText(text string) string {
+	if text == "" {
+		return ""
+	}
+
+	return "?text=" + url.QueryEscape(text)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ich5003/small-osm"
 )
@@ -59,5 +60,111 @@ func TestNote_urls(t *testing.T) {
 		if !strings.Contains(url, "notes/search?q=asdf&limit=1&closed=4") {
 			t.Errorf("incorrect path: %v", url)
 		}
+
+		NotesSearch(ctx, "asdf",
+			DisplayName("a b"),
+			From(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)),
+			To(time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)),
+			Sort(NotesSortCreatedAt),
+			Order(NotesOrderOldest),
+		)
+		if !strings.Contains(url, "notes/search?q=asdf&display_name=a+b&from=2019-01-01T00:00:00Z&to=2019-02-01T00:00:00Z&sort=created_at&order=oldest") {
+			t.Errorf("incorrect path: %v", url)
+		}
+
+		NotesSearch(ctx, "asdf", UserID(5))
+		if !strings.Contains(url, "notes/search?q=asdf&user=5") {
+			t.Errorf("incorrect path: %v", url)
+		}
 	})
 }
+
+func TestNotesSearch_errors(t *testing.T) {
+	ctx := context.Background()
+	ds := &Datasource{BaseURL: "http://127.0.0.1:0"}
+
+	if _, err := ds.NotesSearch(ctx, "a", UserID(1), DisplayName("a")); err == nil {
+		t.Errorf("should return error for user id and display name")
+	}
+
+	if _, err := ds.NotesSearch(ctx, "a", Sort("id")); err == nil {
+		t.Errorf("should return error for invalid sort")
+	}
+
+	if _, err := ds.NotesSearch(ctx, "a", Order("random")); err == nil {
+		t.Errorf("should return error for invalid order")
+	}
+
+	// the notes by bounding box api ignores the search options
+	searchOnly := []NotesSearchOption{
+		UserID(1), DisplayName("a"), From(time.Time{}), To(time.Time{}),
+		Sort(NotesSortCreatedAt), Order(NotesOrderNewest),
+	}
+	for _, o := range searchOnly {
+		if _, ok := o.(NotesOption); ok {
+			t.Errorf("%T should only be valid for notes search", o)
+		}
+	}
+}
+
+func TestNote_write(t *testing.T) {
+	ctx := context.Background()
+
+	request := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r.Method + " " + r.URL.String()
+		w.Write([]byte(`<osm><note lon="2" lat="1"><id>3</id><status>open</status></note></osm>`))
+	}))
+	defer ts.Close()
+
+	ds := &Datasource{BaseURL: ts.URL}
+
+	cases := []struct {
+		name     string
+		call     func() (*osm.Note, error)
+		expected string
+	}{
+		{
+			name:     "create",
+			call:     func() (*osm.Note, error) { return ds.CreateNote(ctx, 1, 2, "a & b") },
+			expected: "POST /notes?lat=1.000000&lon=2.000000&text=a+%26+b",
+		},
+		{
+			name:     "comment",
+			call:     func() (*osm.Note, error) { return ds.CommentNote(ctx, 3, "still there") },
+			expected: "POST /notes/3/comment?text=still+there",
+		},
+		{
+			name:     "close",
+			call:     func() (*osm.Note, error) { return ds.CloseNote(ctx, 3, "") },
+			expected: "POST /notes/3/close",
+		},
+		{
+			name:     "reopen",
+			call:     func() (*osm.Note, error) { return ds.ReopenNote(ctx, 3, "not fixed") },
+			expected: "POST /notes/3/reopen?text=not+fixed",
+		},
+		{
+			name:     "hide",
+			call:     func() (*osm.Note, error) { return ds.HideNote(ctx, 3, "spam") },
+			expected: "DELETE /notes/3?text=spam",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			note, err := tc.call()
+			if err != nil {
+				t.Fatalf("request error: %v", err)
+			}
+
+			if request != tc.expected {
+				t.Errorf("incorrect request: %v", request)
+			}
+
+			if note.ID != 3 || note.Lat != 1 || note.Lon != 2 {
+				t.Errorf("incorrect note: %+v", note)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@ package osmapi
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/ich5003/small-osm"
 )
 
 // FeatureOption can be used when fetching a feature or a set of different features.
@@ -26,11 +30,33 @@ func (o *at) applyFeature(p []string) ([]string, error) {
 
 func (o *at) feature() {}
 
+// ShowRedactions adds a `show_redactions=true` parameter to the request.
+// Redacted versions of elements are only returned to moderators, so the
+// request must be authenticated with a moderator token. Only valid when
+// requesting a specific version, e.g. NodeVersion.
+func ShowRedactions() FeatureOption {
+	return &showRedactions{}
+}
+
+type showRedactions struct{}
+
+func (o *showRedactions) applyFeature(p []string) ([]string, error) {
+	return append(p, "show_redactions=true"), nil
+}
+
 // NotesOption defines a valid option for the osmapi.Notes by bounding box api.
+// All of them are also valid for NotesSearch.
 type NotesOption interface {
+	NotesSearchOption
 	applyNotes([]string) ([]string, error)
 }
 
+// NotesSearchOption defines a valid option for the osmapi.NotesSearch api.
+// Options that filter by user or date are only supported when searching.
+type NotesSearchOption interface {
+	applyNotesSearch([]string) ([]string, error)
+}
+
 // Limit indicates the number of results to return valid values [1,10000].
 // Default is 100.
 func Limit(num int) NotesOption {
@@ -53,12 +79,284 @@ func (o *limit) applyNotes(p []string) ([]string, error) {
 	return append(p, fmt.Sprintf("limit=%d", o.n)), nil
 }
 
+func (o *limit) applyNotesSearch(p []string) ([]string, error) {
+	return o.applyNotes(p)
+}
+
 type maxDaysClosed struct{ n int }
 
 func (o *maxDaysClosed) applyNotes(p []string) ([]string, error) {
 	return append(p, fmt.Sprintf("closed=%d", o.n)), nil
 }
 
+func (o *maxDaysClosed) applyNotesSearch(p []string) ([]string, error) {
+	return o.applyNotes(p)
+}
+
+// NotesSort defines the date used to sort and filter notes.
+type NotesSort string
+
+// The notes can be sorted by when they were created or last updated.
+const (
+	NotesSortCreatedAt NotesSort = "created_at"
+	NotesSortUpdatedAt NotesSort = "updated_at"
+)
+
+// NotesOrder defines the order of the notes.
+type NotesOrder string
+
+// Notes can be returned newest or oldest first.
+const (
+	NotesOrderNewest NotesOrder = "newest"
+	NotesOrderOldest NotesOrder = "oldest"
+)
+
+// From limits the results to notes created, or updated depending
+// on the sort, after the time.
+func From(t time.Time) NotesSearchOption {
+	return &notesTime{"from", t}
+}
+
+// To limits the results to notes created, or updated depending
+// on the sort, before the time.
+func To(t time.Time) NotesSearchOption {
+	return &notesTime{"to", t}
+}
+
+// Sort defines the date to sort the results by, the default is
+// NotesSortUpdatedAt.
+func Sort(s NotesSort) NotesSearchOption {
+	return &notesSort{s}
+}
+
+// Order defines the order of the results, the default is
+// NotesOrderNewest.
+func Order(o NotesOrder) NotesSearchOption {
+	return &notesOrder{o}
+}
+
+type notesTime struct {
+	name string
+	t    time.Time
+}
+
+func (o *notesTime) applyNotesSearch(p []string) ([]string, error) {
+	return append(p, o.name+"="+formatTime(o.t)), nil
+}
+
+type notesSort struct{ s NotesSort }
+
+func (o *notesSort) applyNotesSearch(p []string) ([]string, error) {
+	if o.s != NotesSortCreatedAt && o.s != NotesSortUpdatedAt {
+		return nil, fmt.Errorf("osmapi: invalid notes sort: %q", o.s)
+	}
+	return append(p, "sort="+string(o.s)), nil
+}
+
+type notesOrder struct{ o NotesOrder }
+
+func (o *notesOrder) applyNotesSearch(p []string) ([]string, error) {
+	if o.o != NotesOrderNewest && o.o != NotesOrderOldest {
+		return nil, fmt.Errorf("osmapi: invalid notes order: %q", o.o)
+	}
+	return append(p, "order="+string(o.o)), nil
+}
+
+// ChangesetsOption defines a valid option for the osmapi.Changesets query api.
+type ChangesetsOption interface {
+	applyChangesets(*changesetsQuery) error
+}
+
+// UserOption can be used to filter changesets or searched notes by user.
+type UserOption interface {
+	ChangesetsOption
+	NotesSearchOption
+}
+
+var _ ChangesetsOption = Bounds(nil)
+var _ UserOption = UserID(1)
+var _ UserOption = DisplayName("")
+var _ ChangesetsOption = ClosedAfter(time.Time{})
+var _ ChangesetsOption = CreatedBefore(time.Time{})
+var _ ChangesetsOption = OnlyOpen()
+var _ ChangesetsOption = OnlyClosed()
+var _ ChangesetsOption = ChangesetIDs()
+
+// changesetsQuery collects the parameters of a changesets request.
+// The time range is kept separately since it is used to paginate.
+type changesetsQuery struct {
+	params        []string
+	user          bool
+	closedAfter   time.Time
+	createdBefore time.Time
+}
+
+// Bounds limits the results to changesets that intersect the bounding box.
+func Bounds(b *osm.Bounds) ChangesetsOption {
+	return &bounds{b}
+}
+
+// UserID limits the results to changesets, or searched notes interacted with,
+// by the user with the id. Can not be combined with DisplayName.
+func UserID(id osm.UserID) UserOption {
+	return &userID{id}
+}
+
+// DisplayName limits the results to changesets, or searched notes interacted with,
+// by the user with the name. Can not be combined with UserID.
+func DisplayName(name string) UserOption {
+	return &displayName{name}
+}
+
+// ClosedAfter limits the results to changesets closed after the time.
+// This includes all the open changesets.
+func ClosedAfter(t time.Time) ChangesetsOption {
+	return &closedAfter{t}
+}
+
+// CreatedBefore limits the results to changesets created before the time.
+func CreatedBefore(t time.Time) ChangesetsOption {
+	return &createdBefore{t}
+}
+
+// OnlyOpen limits the results to changesets that are still open.
+func OnlyOpen() ChangesetsOption {
+	return &changesetState{"open=true"}
+}
+
+// OnlyClosed limits the results to changesets that are closed.
+func OnlyClosed() ChangesetsOption {
+	return &changesetState{"closed=true"}
+}
+
+// ChangesetIDs limits the results to the changesets with the given ids.
+func ChangesetIDs(ids ...osm.ChangesetID) ChangesetsOption {
+	return &changesetIDs{ids}
+}
+
+type bounds struct{ b *osm.Bounds }
+
+func (o *bounds) applyChangesets(q *changesetsQuery) error {
+	if o.b == nil {
+		return errors.New("osmapi: bounds must not be nil")
+	}
+
+	q.params = append(q.params, fmt.Sprintf("bbox=%f,%f,%f,%f",
+		o.b.MinLon, o.b.MinLat,
+		o.b.MaxLon, o.b.MaxLat))
+	return nil
+}
+
+type userID struct{ id osm.UserID }
+
+func (o *userID) applyChangesets(q *changesetsQuery) error {
+	if q.user {
+		return errors.New("osmapi: only one user id or display name allowed")
+	}
+	q.user = true
+
+	q.params = append(q.params, fmt.Sprintf("user=%d", o.id))
+	return nil
+}
+
+func (o *userID) applyNotesSearch(p []string) ([]string, error) {
+	if hasUserParam(p) {
+		return nil, errors.New("osmapi: only one user id or display name allowed")
+	}
+	return append(p, fmt.Sprintf("user=%d", o.id)), nil
+}
+
+type displayName struct{ name string }
+
+func (o *displayName) applyChangesets(q *changesetsQuery) error {
+	if q.user {
+		return errors.New("osmapi: only one user id or display name allowed")
+	}
+	q.user = true
+
+	q.params = append(q.params, "display_name="+url.QueryEscape(o.name))
+	return nil
+}
+
+func (o *displayName) applyNotesSearch(p []string) ([]string, error) {
+	if hasUserParam(p) {
+		return nil, errors.New("osmapi: only one user id or display name allowed")
+	}
+	return append(p, "display_name="+url.QueryEscape(o.name)), nil
+}
+
+func hasUserParam(params []string) bool {
+	for _, p := range params {
+		if strings.HasPrefix(p, "user=") || strings.HasPrefix(p, "display_name=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+type closedAfter struct{ t time.Time }
+
+func (o *closedAfter) applyChangesets(q *changesetsQuery) error {
+	q.closedAfter = o.t
+	return nil
+}
+
+type createdBefore struct{ t time.Time }
+
+func (o *createdBefore) applyChangesets(q *changesetsQuery) error {
+	q.createdBefore = o.t
+	return nil
+}
+
+type changesetState struct{ param string }
+
+func (o *changesetState) applyChangesets(q *changesetsQuery) error {
+	q.params = append(q.params, o.param)
+	return nil
+}
+
+type changesetIDs struct{ ids []osm.ChangesetID }
+
+func (o *changesetIDs) applyChangesets(q *changesetsQuery) error {
+	if len(o.ids) == 0 {
+		return errors.New("osmapi: at least one changeset id is required")
+	}
+
+	data := make([]byte, 0, 11*len(o.ids))
+	for i, id := range o.ids {
+		if i != 0 {
+			data = append(data, byte(','))
+		}
+		data = strconv.AppendInt(data, int64(id), 10)
+	}
+
+	q.params = append(q.params, "changesets="+string(data))
+	return nil
+}
+
+// values returns the query string for the request. The api requires a
+// closed after time when filtering by created before.
+func (q *changesetsQuery) values() string {
+	params := q.params
+	if !q.createdBefore.IsZero() {
+		params = append(params, fmt.Sprintf("time=%s,%s",
+			formatTime(q.closedAfter), formatTime(q.createdBefore)))
+	} else if !q.closedAfter.IsZero() {
+		params = append(params, "time="+formatTime(q.closedAfter))
+	}
+
+	return strings.Join(params, "&")
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
 func featureOptions(opts []FeatureOption) (string, error) {
 	if len(opts) == 0 {
 		return "", nil
@@ -70,13 +70,17 @@ func (ds *Datasource) Relations(ctx context.Context, ids []osm.RelationID, opts
 
 // RelationVersion returns the specific version of the relation from the osm rest api.
 // Delegates to the DefaultDatasource and uses its http.Client to make the request.
-func RelationVersion(ctx context.Context, id osm.RelationID, v int) (*osm.Relation, error) {
-	return DefaultDatasource.RelationVersion(ctx, id, v)
+func RelationVersion(ctx context.Context, id osm.RelationID, v int, opts ...FeatureOption) (*osm.Relation, error) {
+	return DefaultDatasource.RelationVersion(ctx, id, v, opts...)
 }
 
 // RelationVersion returns the specific version of the relation from the osm rest api.
-func (ds *Datasource) RelationVersion(ctx context.Context, id osm.RelationID, v int) (*osm.Relation, error) {
-	url := fmt.Sprintf("%s/relation/%d/%d", ds.baseURL(), id, v)
+func (ds *Datasource) RelationVersion(ctx context.Context, id osm.RelationID, v int, opts ...FeatureOption) (*osm.Relation, error) {
+	params, err := featureOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/relation/%d/%d?%s", ds.baseURL(), id, v, params)
 
 	o := &osm.OSM{}
 	if err := ds.getFromAPI(ctx, url, &o); err != nil {
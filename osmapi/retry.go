@@ -0,0 +1,135 @@
+package osmapi
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// statusBandwidthLimitExceeded is returned by the api when too much
+// data has been downloaded recently.
+const statusBandwidthLimitExceeded = 509
+
+// RetryPolicy defines how failed requests are retried. Requests are retried
+// for network errors and the 429, 5xx and 509 status codes. The wait between
+// attempts grows exponentially, with jitter, unless the api provides a time
+// using the Retry-After header. For example:
+//		osmapi.DefaultDatasource.Retry = osmapi.DefaultRetryPolicy
+//
+// Requests that modify data, e.g. uploading a changeset, are only retried
+// for 429 and 509 since the change may have been applied otherwise.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried
+	// after the first attempt.
+	MaxRetries int
+
+	// MinBackoff is the wait before the first retry, it is doubled
+	// for every retry after that up to MaxBackoff. The wait does not
+	// grow if MaxBackoff is zero.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is a reasonable policy for long running
+// processes using the production api.
+var DefaultRetryPolicy = &RetryPolicy{
+	MaxRetries: 5,
+	MinBackoff: 1 * time.Second,
+	MaxBackoff: 1 * time.Minute,
+}
+
+// retry returns true and the time to wait if the attempt should be retried.
+func (p *RetryPolicy) retry(ctx context.Context, attempt int, method string, resp *http.Response, err error) (time.Duration, bool) {
+	if p == nil || attempt >= p.MaxRetries || ctx.Err() != nil {
+		return 0, false
+	}
+
+	idempotent := method == http.MethodGet || method == http.MethodHead
+	if err != nil {
+		// only network errors, not rate limiter or authentication errors
+		if _, ok := err.(*url.Error); !ok || !idempotent {
+			return 0, false
+		}
+
+		return p.backoff(attempt), true
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusTooManyRequests || code == statusBandwidthLimitExceeded:
+	case code >= 500 && idempotent:
+	default:
+		return 0, false
+	}
+
+	if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+		return wait, true
+	}
+
+	return p.backoff(attempt), true
+}
+
+// backoff returns the exponential backoff for the attempt. Half of it
+// is random so concurrent requests don't retry at the same time.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 0; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter parses the Retry-After header, it can be a number
+// of seconds or a date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	t, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	wait := time.Until(t)
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait, true
+}
+
+// sleep waits for the duration or until the context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
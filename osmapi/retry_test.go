@@ -0,0 +1,213 @@
+package osmapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type countingLimiter struct{ count int }
+
+func (l *countingLimiter) Wait(context.Context) error {
+	l.count++
+	return nil
+}
+
+func TestDatasource_Retry(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		requests int
+		failures int
+		status   int
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= failures {
+			w.WriteHeader(status)
+			return
+		}
+
+		w.Write([]byte(`<osm><user id="1" display_name="user"></user></osm>`))
+	}))
+	defer ts.Close()
+
+	policy := &RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: time.Millisecond,
+		MaxBackoff: 4 * time.Millisecond,
+	}
+
+	cases := []struct {
+		name     string
+		failures int
+		status   int
+		requests int
+		err      bool
+	}{
+		{name: "no failures", requests: 1},
+		{name: "server error", failures: 2, status: http.StatusServiceUnavailable, requests: 3},
+		{name: "too many requests", failures: 1, status: http.StatusTooManyRequests, requests: 2},
+		{name: "bandwidth limit", failures: 1, status: 509, requests: 2},
+		{name: "too many failures", failures: 10, status: http.StatusBadGateway, requests: 4, err: true},
+		{name: "not retried", failures: 1, status: http.StatusNotFound, requests: 1, err: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests, failures, status = 0, tc.failures, tc.status
+
+			limiter := &countingLimiter{}
+			ds := &Datasource{BaseURL: ts.URL, Retry: policy, Limiter: limiter}
+
+			_, err := ds.User(ctx, 1)
+			if (err != nil) != tc.err {
+				t.Errorf("incorrect error: %v", err)
+			}
+
+			if requests != tc.requests {
+				t.Errorf("incorrect number of requests: %v", requests)
+			}
+
+			if limiter.count != tc.requests {
+				t.Errorf("limiter should wait for every attempt: %v", limiter.count)
+			}
+		})
+	}
+
+	t.Run("no policy", func(t *testing.T) {
+		requests, failures, status = 0, 1, http.StatusServiceUnavailable
+
+		ds := &Datasource{BaseURL: ts.URL}
+		if _, err := ds.User(ctx, 1); err == nil {
+			t.Errorf("should return error")
+		}
+
+		if requests != 1 {
+			t.Errorf("incorrect number of requests: %v", requests)
+		}
+	})
+
+	t.Run("writes only retried if rejected", func(t *testing.T) {
+		ds := &Datasource{BaseURL: ts.URL, Retry: policy}
+
+		requests, failures, status = 0, 1, http.StatusInternalServerError
+		if err := ds.CloseChangeset(ctx, 1); err == nil {
+			t.Errorf("should return error")
+		}
+
+		if requests != 1 {
+			t.Errorf("incorrect number of requests: %v", requests)
+		}
+
+		requests, failures, status = 0, 1, http.StatusTooManyRequests
+		if err := ds.CloseChangeset(ctx, 1); err != nil {
+			t.Errorf("request error: %v", err)
+		}
+
+		if requests != 2 {
+			t.Errorf("incorrect number of requests: %v", requests)
+		}
+	})
+}
+
+func TestDatasource_Retry_networkError(t *testing.T) {
+	ctx := context.Background()
+
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+
+		w.Write([]byte(`<osm><user id="1" display_name="user"></user></osm>`))
+	}))
+	defer ts.Close()
+
+	ds := &Datasource{
+		BaseURL: ts.URL,
+		Retry:   &RetryPolicy{MaxRetries: 1, MinBackoff: time.Millisecond},
+	}
+
+	if _, err := ds.User(ctx, 1); err != nil {
+		t.Fatalf("request error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("incorrect number of requests: %v", requests)
+	}
+}
+
+func TestDatasource_Retry_retryAfter(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer ts.Close()
+
+	ds := &Datasource{BaseURL: ts.URL, Retry: DefaultRetryPolicy}
+
+	// the wait is longer than the context so it should stop
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := ds.User(ctx, 1); err != context.DeadlineExceeded {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	if d := time.Since(start); d > time.Second {
+		t.Errorf("should stop waiting when the context is done: %v", d)
+	}
+
+	if requests != 1 {
+		t.Errorf("incorrect number of requests: %v", requests)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0, true},
+	}
+
+	for _, tc := range cases {
+		wait, ok := retryAfter(tc.value)
+		if wait != tc.wait || ok != tc.ok {
+			t.Errorf("%q: incorrect result: %v %v", tc.value, wait, ok)
+		}
+	}
+
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := retryAfter(future); !ok || wait < 59*time.Minute || wait > time.Hour {
+		t.Errorf("incorrect wait for date: %v %v", wait, ok)
+	}
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, e := range expected {
+		for i := 0; i < 10; i++ {
+			d := p.backoff(attempt)
+			if d < e/2 || d > e {
+				t.Errorf("attempt %d: incorrect backoff: %v", attempt, d)
+			}
+		}
+	}
+}
@@ -28,3 +28,55 @@ func (ds *Datasource) User(ctx context.Context, id osm.UserID) (*osm.User, error
 
 	return o.Users[0], nil
 }
+
+// UserDetails returns the user the requests are authenticated as.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func UserDetails(ctx context.Context) (*osm.User, error) {
+	return DefaultDatasource.UserDetails(ctx)
+}
+
+// UserDetails returns the user the requests are authenticated as.
+func (ds *Datasource) UserDetails(ctx context.Context) (*osm.User, error) {
+	url := fmt.Sprintf("%s/user/details", ds.baseURL())
+
+	o := &osm.OSM{}
+	if err := ds.getFromAPI(ctx, url, &o); err != nil {
+		return nil, err
+	}
+
+	if l := len(o.Users); l != 1 {
+		return nil, fmt.Errorf("wrong number of users, expected 1, got %v", l)
+	}
+
+	return o.Users[0], nil
+}
+
+// UserPreferences returns the preferences of the user the requests are
+// authenticated as. The preferences are arbitrary key/value pairs.
+// Delegates to the DefaultDatasource and uses its http.Client to make the request.
+func UserPreferences(ctx context.Context) (map[string]string, error) {
+	return DefaultDatasource.UserPreferences(ctx)
+}
+
+// UserPreferences returns the preferences of the user the requests are
+// authenticated as. The preferences are arbitrary key/value pairs.
+func (ds *Datasource) UserPreferences(ctx context.Context) (map[string]string, error) {
+	url := fmt.Sprintf("%s/user/preferences", ds.baseURL())
+
+	o := &struct {
+		Preferences []struct {
+			Key   string `xml:"k,attr"`
+			Value string `xml:"v,attr"`
+		} `xml:"preferences>preference"`
+	}{}
+	if err := ds.getFromAPI(ctx, url, &o); err != nil {
+		return nil, err
+	}
+
+	prefs := make(map[string]string, len(o.Preferences))
+	for _, p := range o.Preferences {
+		prefs[p.Key] = p.Value
+	}
+
+	return prefs, nil
+}
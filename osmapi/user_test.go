@@ -29,4 +29,40 @@ func TestUser_urls(t *testing.T) {
 			t.Errorf("incorrect path: %v", url)
 		}
 	})
+
+	t.Run("user details", func(t *testing.T) {
+		UserDetails(ctx)
+		if !strings.Contains(url, "user/details") {
+			t.Errorf("incorrect path: %v", url)
+		}
+	})
+}
+
+func TestUserPreferences(t *testing.T) {
+	ctx := context.Background()
+
+	url := ""
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		url = r.URL.String()
+		w.Write([]byte(`<osm version="0.6"><preferences>
+			<preference k="gps.trace.visibility" v="identifiable"/>
+			<preference k="editor" v="id"/>
+		</preferences></osm>`))
+	}))
+	defer ts.Close()
+
+	ds := &Datasource{BaseURL: ts.URL}
+
+	prefs, err := ds.UserPreferences(ctx)
+	if err != nil {
+		t.Fatalf("request error: %v", err)
+	}
+
+	if !strings.Contains(url, "user/preferences") {
+		t.Errorf("incorrect path: %v", url)
+	}
+
+	if len(prefs) != 2 || prefs["editor"] != "id" || prefs["gps.trace.visibility"] != "identifiable" {
+		t.Errorf("incorrect preferences: %v", prefs)
+	}
 }
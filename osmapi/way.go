@@ -69,13 +69,17 @@ func (ds *Datasource) Ways(ctx context.Context, ids []osm.WayID, opts ...Feature
 
 // WayVersion returns the specific version of the way from the osm rest api.
 // Delegates to the DefaultDatasource and uses its http.Client to make the request.
-func WayVersion(ctx context.Context, id osm.WayID, v int) (*osm.Way, error) {
-	return DefaultDatasource.WayVersion(ctx, id, v)
+func WayVersion(ctx context.Context, id osm.WayID, v int, opts ...FeatureOption) (*osm.Way, error) {
+	return DefaultDatasource.WayVersion(ctx, id, v, opts...)
 }
 
 // WayVersion returns the specific version of the way from the osm rest api.
-func (ds *Datasource) WayVersion(ctx context.Context, id osm.WayID, v int) (*osm.Way, error) {
-	url := fmt.Sprintf("%s/way/%d/%d", ds.baseURL(), id, v)
+func (ds *Datasource) WayVersion(ctx context.Context, id osm.WayID, v int, opts ...FeatureOption) (*osm.Way, error) {
+	params, err := featureOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	url := fmt.Sprintf("%s/way/%d/%d?%s", ds.baseURL(), id, v, params)
 
 	o := &osm.OSM{}
 	if err := ds.getFromAPI(ctx, url, &o); err != nil {
@@ -0,0 +1,188 @@
+// Package osmfile opens osm data files of any of the supported formats.
+// The format and compression of the input is detected from its first
+// bytes, the output format is chosen using the file extension.
+package osmfile
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format is an osm data file format.
+type Format string
+
+// The supported formats.
+const (
+	FormatUnknown Format = ""
+	FormatPBF     Format = "pbf"
+	FormatXML     Format = "xml"
+	FormatChange  Format = "osc"
+	FormatJSON    Format = "json"
+	FormatO5M     Format = "o5m"
+	FormatO5C     Format = "o5c"
+	FormatOPL     Format = "opl"
+)
+
+// Compression is the compression applied to a whole file.
+type Compression string
+
+// The supported compressions. Bzip2 is only supported when reading.
+const (
+	CompressionNone  Compression = ""
+	CompressionGzip  Compression = "gzip"
+	CompressionBzip2 Compression = "bzip2"
+)
+
+// sniffSize is the number of bytes used to detect the format. It should
+// include the xml declaration, any comments and the root element.
+const sniffSize = 4096
+
+// Detect reads the start of the data to determine its compression and
+// format. The returned reader contains all the data, decompressed.
+// The format is FormatUnknown if it could not be detected.
+func Detect(r io.Reader) (Format, Compression, io.Reader, error) {
+	br := bufio.NewReaderSize(r, sniffSize)
+
+	head, err := peek(br)
+	if err != nil {
+		return FormatUnknown, CompressionNone, nil, err
+	}
+
+	c := CompressionNone
+	switch {
+	case bytes.HasPrefix(head, []byte{0x1f, 0x8b}):
+		c = CompressionGzip
+
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return FormatUnknown, c, nil, err
+		}
+		br = bufio.NewReaderSize(gz, sniffSize)
+	case bytes.HasPrefix(head, []byte("BZh")):
+		c = CompressionBzip2
+		br = bufio.NewReaderSize(bzip2.NewReader(br), sniffSize)
+	}
+
+	if c != CompressionNone {
+		head, err = peek(br)
+		if err != nil {
+			return FormatUnknown, c, nil, err
+		}
+	}
+
+	return sniff(head), c, br, nil
+}
+
+// peek returns the start of the data without consuming it.
+func peek(br *bufio.Reader) ([]byte, error) {
+	head, err := br.Peek(sniffSize)
+	if err == io.EOF || err == bufio.ErrBufferFull {
+		err = nil
+	}
+
+	return head, err
+}
+
+// sniff returns the format of the data using its first bytes.
+func sniff(head []byte) Format {
+	// o5m and o5c start with a reset and the header dataset
+	if len(head) >= 7 && head[0] == 0xff && head[1] == 0xe0 {
+		switch string(head[3:7]) {
+		case "o5m2":
+			return FormatO5M
+		case "o5c2":
+			return FormatO5C
+		}
+	}
+
+	// pbf starts with the length of the header followed by the
+	// protobuf encoded blob header, its first field is the block type.
+	if len(head) >= 6 && head[4] == 0x0a {
+		t := head[6:]
+		if bytes.HasPrefix(t, []byte("OSMHeader")) || bytes.HasPrefix(t, []byte("OSMData")) {
+			return FormatPBF
+		}
+	}
+
+	text := bytes.TrimPrefix(head, []byte("\xef\xbb\xbf"))
+	text = bytes.TrimLeft(text, " \t\r\n")
+	if len(text) == 0 {
+		return FormatUnknown
+	}
+
+	switch text[0] {
+	case '<':
+		return sniffXML(text)
+	case '{':
+		return FormatJSON
+	case '#':
+		return FormatOPL
+	case 'n', 'w', 'r', 'c':
+		if len(text) > 1 && (text[1] == '-' || (text[1] >= '0' && text[1] <= '9')) {
+			return FormatOPL
+		}
+	}
+
+	return FormatUnknown
+}
+
+// sniffXML finds the root element after any declaration and comments.
+func sniffXML(text []byte) Format {
+	for {
+		i := bytes.Index(text, []byte("<osm"))
+		if i < 0 {
+			return FormatUnknown
+		}
+		text = text[i+len("<osm"):]
+
+		switch {
+		case bytes.HasPrefix(text, []byte("Change")):
+			return FormatChange
+		case len(text) > 0 && strings.IndexByte(" \t\r\n>/", text[0]) >= 0:
+			return FormatXML
+		}
+	}
+}
+
+// FormatFromName returns the format and compression based on the extension
+// of the file name, e.g. map.osm.pbf or 871.osc.gz.
+func FormatFromName(name string) (Format, Compression) {
+	ext := strings.ToLower(filepath.Ext(name))
+
+	c := CompressionNone
+	switch ext {
+	case ".gz":
+		c = CompressionGzip
+	case ".bz2":
+		c = CompressionBzip2
+	}
+
+	if c != CompressionNone {
+		name = name[:len(name)-len(ext)]
+		ext = strings.ToLower(filepath.Ext(name))
+	}
+
+	switch ext {
+	case ".pbf":
+		return FormatPBF, c
+	case ".osm", ".xml":
+		return FormatXML, c
+	case ".osc":
+		return FormatChange, c
+	case ".json":
+		return FormatJSON, c
+	case ".o5m":
+		return FormatO5M, c
+	case ".o5c":
+		return FormatO5C, c
+	case ".opl":
+		return FormatOPL, c
+	}
+
+	return FormatUnknown, c
+}
@@ -0,0 +1,159 @@
+package osmfile
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   string
+		format Format
+	}{
+		{
+			name:   "xml",
+			data:   `<?xml version="1.0" encoding="UTF-8"?><osm version="0.6"></osm>`,
+			format: FormatXML,
+		},
+		{
+			name:   "xml with comment",
+			data:   "\xef\xbb\xbf<!-- <osmosis> -->\n<osm>\n</osm>",
+			format: FormatXML,
+		},
+		{
+			name:   "osc",
+			data:   `<?xml version='1.0' encoding='UTF-8'?><osmChange version="0.6"></osmChange>`,
+			format: FormatChange,
+		},
+		{
+			name:   "json",
+			data:   ` {"version":0.6,"elements":[]}`,
+			format: FormatJSON,
+		},
+		{
+			name:   "pbf",
+			data:   "\x00\x00\x00\x0d\x0a\x09OSMHeader\x18\x7c",
+			format: FormatPBF,
+		},
+		{
+			name:   "o5m",
+			data:   "\xff\xe0\x04o5m2\xfe",
+			format: FormatO5M,
+		},
+		{
+			name:   "o5c",
+			data:   "\xff\xe0\x04o5c2\xfe",
+			format: FormatO5C,
+		},
+		{
+			name:   "opl",
+			data:   "n1 v1 dV x1 y2\n",
+			format: FormatOPL,
+		},
+		{
+			name:   "opl negative id",
+			data:   "w-1 v1\n",
+			format: FormatOPL,
+		},
+		{
+			name:   "unknown",
+			data:   "hello world",
+			format: FormatUnknown,
+		},
+		{
+			name:   "empty",
+			data:   "",
+			format: FormatUnknown,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, c, r, err := Detect(bytes.NewReader([]byte(tc.data)))
+			if err != nil {
+				t.Fatalf("detect error: %v", err)
+			}
+
+			if f != tc.format {
+				t.Errorf("incorrect format: %q != %q", f, tc.format)
+			}
+
+			if c != CompressionNone {
+				t.Errorf("incorrect compression: %q", c)
+			}
+
+			data, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("read error: %v", err)
+			}
+
+			if string(data) != tc.data {
+				t.Errorf("reader should return all the data")
+			}
+		})
+	}
+}
+
+func TestDetect_gzip(t *testing.T) {
+	data := `<osmChange version="0.6"></osmChange>`
+
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	gz.Write([]byte(data))
+	gz.Close()
+
+	f, c, r, err := Detect(buf)
+	if err != nil {
+		t.Fatalf("detect error: %v", err)
+	}
+
+	if f != FormatChange {
+		t.Errorf("incorrect format: %q", f)
+	}
+
+	if c != CompressionGzip {
+		t.Errorf("incorrect compression: %q", c)
+	}
+
+	result, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+
+	if string(result) != data {
+		t.Errorf("reader should return the decompressed data")
+	}
+}
+
+func TestFormatFromName(t *testing.T) {
+	cases := []struct {
+		name        string
+		format      Format
+		compression Compression
+	}{
+		{"delaware-latest.osm.pbf", FormatPBF, CompressionNone},
+		{"andorra-latest.osm.bz2", FormatXML, CompressionBzip2},
+		{"map.OSM", FormatXML, CompressionNone},
+		{"871.osc.gz", FormatChange, CompressionGzip},
+		{"data.json", FormatJSON, CompressionNone},
+		{"planet.o5m", FormatO5M, CompressionNone},
+		{"changes.o5c.gz", FormatO5C, CompressionGzip},
+		{"fixture.opl", FormatOPL, CompressionNone},
+		{"readme.txt", FormatUnknown, CompressionNone},
+		{"data.gz", FormatUnknown, CompressionGzip},
+	}
+
+	for _, tc := range cases {
+		f, c := FormatFromName(tc.name)
+		if f != tc.format {
+			t.Errorf("%s: incorrect format: %q != %q", tc.name, f, tc.format)
+		}
+
+		if c != tc.compression {
+			t.Errorf("%s: incorrect compression: %q != %q", tc.name, c, tc.compression)
+		}
+	}
+}
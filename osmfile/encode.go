@@ -0,0 +1,194 @@
+package osmfile
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/o5m"
+	"github.com/ich5003/small-osm/opl"
+	"github.com/ich5003/small-osm/osmjson"
+	"github.com/ich5003/small-osm/osmpbf"
+	"github.com/ich5003/small-osm/osmxml"
+)
+
+// Encoder is implemented by the encoders of the supported output formats.
+type Encoder interface {
+	Encode(osm.Object) error
+	EncodeScanner(osm.Scanner) error
+	Close() error
+}
+
+// ChangeEncoder is implemented by the encoders of the supported change formats.
+type ChangeEncoder interface {
+	Encode(osm.ActionType, osm.Object) error
+	EncodeChange(*osm.Change) error
+	Close() error
+}
+
+// NewEncoder returns an encoder that writes to w in the format, and
+// compression, given by the extension of the name. The encoder is one of:
+//	*osmpbf.Encoder
+//	*osmxml.Encoder
+//	*osmjson.Encoder
+//	*o5m.Encoder
+//	*opl.Encoder
+// wrapped to also close the compressor if compressed. Pbf data is
+// encoded using runtime.GOMAXPROCS goroutines.
+func NewEncoder(ctx context.Context, w io.Writer, name string) (Encoder, error) {
+	f, c := FormatFromName(name)
+
+	w, gz, err := compress(w, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var e Encoder
+	switch f {
+	case FormatPBF:
+		e = osmpbf.NewEncoder(ctx, w, runtime.GOMAXPROCS(0))
+	case FormatXML:
+		e = osmxml.NewEncoder(w)
+	case FormatJSON:
+		e = osmjson.NewEncoder(w)
+	case FormatO5M:
+		e = o5m.NewEncoder(w)
+	case FormatOPL:
+		e = opl.NewEncoder(w)
+	case FormatChange, FormatO5C:
+		return nil, fmt.Errorf("osmfile: use NewChangeEncoder for change files: %s", name)
+	default:
+		return nil, fmt.Errorf("osmfile: unsupported output format: %s", name)
+	}
+
+	if gz == nil {
+		return e, nil
+	}
+
+	return &encoder{Encoder: e, closers: []io.Closer{gz}}, nil
+}
+
+// Create creates the named file and returns an encoder for it,
+// see NewEncoder. Closing the encoder closes the file.
+func Create(ctx context.Context, name string) (Encoder, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := NewEncoder(ctx, f, name)
+	if err != nil {
+		f.Close()
+		os.Remove(name)
+		return nil, err
+	}
+
+	if ec, ok := e.(*encoder); ok {
+		ec.closers = append(ec.closers, f)
+		return ec, nil
+	}
+
+	return &encoder{Encoder: e, closers: []io.Closer{f}}, nil
+}
+
+// NewChangeEncoder returns a change encoder that writes to w in the format,
+// and compression, given by the extension of the name. The encoder is an
+// *osmxml.ChangeEncoder or *o5m.ChangeEncoder, wrapped to also close
+// the compressor if compressed.
+func NewChangeEncoder(w io.Writer, name string) (ChangeEncoder, error) {
+	f, c := FormatFromName(name)
+
+	w, gz, err := compress(w, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var e ChangeEncoder
+	switch f {
+	case FormatChange:
+		e = osmxml.NewChangeEncoder(w)
+	case FormatO5C:
+		e = o5m.NewChangeEncoder(w)
+	default:
+		return nil, fmt.Errorf("osmfile: unsupported change output format: %s", name)
+	}
+
+	if gz == nil {
+		return e, nil
+	}
+
+	return &changeEncoder{ChangeEncoder: e, closers: []io.Closer{gz}}, nil
+}
+
+// CreateChange creates the named file and returns a change encoder for it,
+// see NewChangeEncoder. Closing the encoder closes the file.
+func CreateChange(name string) (ChangeEncoder, error) {
+	f, err := os.Create(name)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := NewChangeEncoder(f, name)
+	if err != nil {
+		f.Close()
+		os.Remove(name)
+		return nil, err
+	}
+
+	if ec, ok := e.(*changeEncoder); ok {
+		ec.closers = append(ec.closers, f)
+		return ec, nil
+	}
+
+	return &changeEncoder{ChangeEncoder: e, closers: []io.Closer{f}}, nil
+}
+
+// compress wraps the writer with the compressor, if any, that
+// must be closed after the encoder.
+func compress(w io.Writer, c Compression) (io.Writer, io.Closer, error) {
+	switch c {
+	case CompressionNone:
+		return w, nil, nil
+	case CompressionGzip:
+		gz := gzip.NewWriter(w)
+		return gz, gz, nil
+	}
+
+	return nil, nil, fmt.Errorf("osmfile: unsupported output compression: %s", c)
+}
+
+// encoder closes the compressor and file after the encoder.
+type encoder struct {
+	Encoder
+	closers []io.Closer
+}
+
+func (e *encoder) Close() error {
+	return closeAll(e.Encoder, e.closers)
+}
+
+// changeEncoder closes the compressor and file after the change encoder.
+type changeEncoder struct {
+	ChangeEncoder
+	closers []io.Closer
+}
+
+func (e *changeEncoder) Close() error {
+	return closeAll(e.ChangeEncoder, e.closers)
+}
+
+// closeAll closes everything in order and returns the first error.
+func closeAll(c io.Closer, closers []io.Closer) error {
+	err := c.Close()
+	for _, c := range closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,151 @@
+package osmfile
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+var testObjects = osm.Objects{
+	&osm.Node{ID: 1, Version: 1, Visible: true, Lat: 1, Lon: 2, Tags: osm.Tags{{Key: "a", Value: "b"}}},
+	&osm.Node{ID: 2, Version: 1, Visible: true, Lat: 3, Lon: 4},
+	&osm.Way{ID: 3, Version: 1, Visible: true, Nodes: osm.WayNodes{{ID: 1}, {ID: 2}}},
+	&osm.Relation{ID: 4, Version: 1, Visible: true, Members: osm.Members{{Type: osm.TypeWay, Ref: 3}}},
+}
+
+func TestCreate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "osmfile")
+	if err != nil {
+		t.Fatalf("temp dir error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	cases := []struct {
+		name        string
+		format      Format
+		compression Compression
+	}{
+		{"map.osm.pbf", FormatPBF, CompressionNone},
+		{"map.osm", FormatXML, CompressionNone},
+		{"map.osm.gz", FormatXML, CompressionGzip},
+		{"map.json", FormatJSON, CompressionNone},
+		{"map.o5m", FormatO5M, CompressionNone},
+		{"map.opl.gz", FormatOPL, CompressionGzip},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name := filepath.Join(dir, tc.name)
+
+			e, err := Create(context.Background(), name)
+			if err != nil {
+				t.Fatalf("create error: %v", err)
+			}
+
+			for _, o := range testObjects {
+				if err := e.Encode(o); err != nil {
+					t.Fatalf("encode error: %v", err)
+				}
+			}
+
+			if err := e.Close(); err != nil {
+				t.Fatalf("close error: %v", err)
+			}
+
+			s, err := Open(context.Background(), name)
+			if err != nil {
+				t.Fatalf("open error: %v", err)
+			}
+			defer s.Close()
+
+			if s.Format != tc.format || s.Compression != tc.compression {
+				t.Errorf("incorrect detection: %q %q", s.Format, s.Compression)
+			}
+
+			var ids osm.ObjectIDs
+			for s.Scan() {
+				ids = append(ids, s.Object().ObjectID())
+			}
+
+			if err := s.Err(); err != nil {
+				t.Fatalf("scan error: %v", err)
+			}
+
+			if len(ids) != len(testObjects) {
+				t.Fatalf("incorrect number of objects: %v != %v", len(ids), len(testObjects))
+			}
+
+			for i, o := range testObjects {
+				if ids[i] != o.ObjectID() {
+					t.Errorf("incorrect object: %v != %v", ids[i], o.ObjectID())
+				}
+			}
+		})
+	}
+}
+
+func TestNewChangeEncoder(t *testing.T) {
+	change := &osm.Change{}
+	change.AppendCreate(testObjects[0])
+	change.AppendDelete(&osm.Node{ID: 5, Version: 2})
+
+	for _, name := range []string{"changes.osc.gz", "changes.o5c"} {
+		t.Run(name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			e, err := NewChangeEncoder(buf, name)
+			if err != nil {
+				t.Fatalf("encoder error: %v", err)
+			}
+
+			if err := e.EncodeChange(change); err != nil {
+				t.Fatalf("encode error: %v", err)
+			}
+
+			if err := e.Close(); err != nil {
+				t.Fatalf("close error: %v", err)
+			}
+
+			s, err := NewScanner(context.Background(), buf)
+			if err != nil {
+				t.Fatalf("scanner error: %v", err)
+			}
+			defer s.Close()
+
+			format, _ := FormatFromName(name)
+			if s.Format != format {
+				t.Errorf("incorrect format: %q != %q", s.Format, format)
+			}
+
+			count := 0
+			for s.Scan() {
+				count++
+			}
+
+			if err := s.Err(); err != nil {
+				t.Fatalf("scan error: %v", err)
+			}
+
+			if count != 2 {
+				t.Errorf("incorrect number of objects: %v", count)
+			}
+		})
+	}
+}
+
+func TestNewEncoder_errors(t *testing.T) {
+	names := []string{"map.osc", "map.osm.bz2", "map.txt"}
+	for _, name := range names {
+		if _, err := NewEncoder(context.Background(), &bytes.Buffer{}, name); err == nil {
+			t.Errorf("%s: should return error", name)
+		}
+	}
+
+	if _, err := NewChangeEncoder(&bytes.Buffer{}, "map.osm"); err == nil {
+		t.Errorf("should return error for non change format")
+	}
+}
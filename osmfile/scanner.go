@@ -0,0 +1,102 @@
+package osmfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/o5m"
+	"github.com/ich5003/small-osm/opl"
+	"github.com/ich5003/small-osm/osmjson"
+	"github.com/ich5003/small-osm/osmpbf"
+	"github.com/ich5003/small-osm/osmxml"
+)
+
+var _ osm.Scanner = &Scanner{}
+
+// Scanner wraps the scanner for the detected format. The underlying
+// scanner can be used for format specific options, e.g. the Action method
+// of an *osmxml.ChangeScanner or *o5m.ChangeScanner for change files.
+type Scanner struct {
+	osm.Scanner
+
+	// Format and Compression are the detected format and compression.
+	Format      Format
+	Compression Compression
+
+	closer io.Closer
+}
+
+// NewScanner detects the format and compression of the data and returns
+// a scanner for it. The scanner is one of:
+//	*osmpbf.Scanner
+//	*osmxml.Scanner
+//	*osmxml.ChangeScanner
+//	*osmjson.Scanner
+//	*o5m.Scanner
+//	*o5m.ChangeScanner
+//	*opl.Scanner
+// Pbf and xml data are decoded using runtime.GOMAXPROCS goroutines.
+func NewScanner(ctx context.Context, r io.Reader) (*Scanner, error) {
+	f, c, r, err := Detect(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scanner{Format: f, Compression: c}
+	switch f {
+	case FormatPBF:
+		s.Scanner = osmpbf.New(ctx, r, runtime.GOMAXPROCS(0))
+	case FormatXML:
+		xs := osmxml.New(ctx, r)
+		xs.Procs = runtime.GOMAXPROCS(0)
+		s.Scanner = xs
+	case FormatChange:
+		s.Scanner = osmxml.NewChangeScanner(ctx, r)
+	case FormatJSON:
+		s.Scanner = osmjson.New(ctx, r)
+	case FormatO5M:
+		s.Scanner = o5m.New(ctx, r)
+	case FormatO5C:
+		s.Scanner = o5m.NewChangeScanner(ctx, r)
+	case FormatOPL:
+		s.Scanner = opl.New(ctx, r)
+	default:
+		return nil, fmt.Errorf("osmfile: unknown format")
+	}
+
+	return s, nil
+}
+
+// Open opens the named file and returns a scanner for its detected
+// format and compression. Closing the scanner closes the file.
+func Open(ctx context.Context, name string) (*Scanner, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewScanner(ctx, f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	s.closer = f
+
+	return s, nil
+}
+
+// Close closes the underlying scanner and the file if opened using Open.
+func (s *Scanner) Close() error {
+	err := s.Scanner.Close()
+	if s.closer != nil {
+		if cerr := s.closer.Close(); err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
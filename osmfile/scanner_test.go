@@ -0,0 +1,95 @@
+package osmfile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmpbf"
+	"github.com/ich5003/small-osm/osmxml"
+)
+
+func TestOpen(t *testing.T) {
+	cases := []struct {
+		name        string
+		format      Format
+		compression Compression
+	}{
+		{"../testdata/delaware-latest.osm.pbf", FormatPBF, CompressionNone},
+		{"../testdata/andorra-latest.osm.bz2", FormatXML, CompressionBzip2},
+		{"../testdata/minute_871.osc", FormatChange, CompressionNone},
+		{"../testdata/way-updates.osm", FormatXML, CompressionNone},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := Open(context.Background(), tc.name)
+			if err != nil {
+				t.Fatalf("open error: %v", err)
+			}
+			defer s.Close()
+
+			if s.Format != tc.format {
+				t.Errorf("incorrect format: %q != %q", s.Format, tc.format)
+			}
+
+			if s.Compression != tc.compression {
+				t.Errorf("incorrect compression: %q != %q", s.Compression, tc.compression)
+			}
+
+			count := 0
+			for s.Scan() {
+				count++
+			}
+
+			if err := s.Err(); err != nil {
+				t.Fatalf("scan error: %v", err)
+			}
+
+			if count == 0 {
+				t.Errorf("should scan objects")
+			}
+		})
+	}
+}
+
+func TestOpen_scannerType(t *testing.T) {
+	pbf, err := Open(context.Background(), "../testdata/delaware-latest.osm.pbf")
+	if err != nil {
+		t.Fatalf("open error: %v", err)
+	}
+	defer pbf.Close()
+
+	if _, ok := pbf.Scanner.(*osmpbf.Scanner); !ok {
+		t.Errorf("incorrect scanner type: %T", pbf.Scanner)
+	}
+
+	s, err := Open(context.Background(), "../testdata/minute_871.osc")
+	if err != nil {
+		t.Fatalf("open error: %v", err)
+	}
+	defer s.Close()
+
+	cs, ok := s.Scanner.(*osmxml.ChangeScanner)
+	if !ok {
+		t.Fatalf("incorrect scanner type: %T", s.Scanner)
+	}
+
+	if !cs.Scan() {
+		t.Fatalf("should scan: %v", cs.Err())
+	}
+
+	if cs.Action() != osm.ActionModify {
+		t.Errorf("incorrect action: %v", cs.Action())
+	}
+}
+
+func TestOpen_errors(t *testing.T) {
+	if _, err := Open(context.Background(), "../testdata/missing.osm"); err == nil {
+		t.Errorf("should error for missing file")
+	}
+
+	if _, err := Open(context.Background(), "../testdata/compare_scanners.go"); err == nil {
+		t.Errorf("should error for unknown format")
+	}
+}
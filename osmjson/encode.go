@@ -0,0 +1,162 @@
+package osmjson
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ich5003/small-osm"
+)
+
+// ErrEncoderClosed is returned when encoding after the encoder is closed.
+var ErrEncoderClosed = errors.New("osmjson: encoder closed")
+
+// Encoder writes a stream of osm data as an osmjson document, i.e.
+// {"version":0.6,"elements":[{"type":"node",...}]}, the same layout as
+// osm.OSM.MarshalJSON. Objects are written as they are encoded so large
+// files can be written with constant memory.
+//
+// The Encoder is not safe for parallel use.
+type Encoder struct {
+	// Header contains the attributes and the bounds of the document.
+	// It can be modified until the first object is encoded. Only the
+	// attributes and bounds are written, the elements are ignored.
+	Header osm.OSM
+
+	w       *bufio.Writer
+	started bool
+	first   bool
+	closed  bool
+	err     error
+}
+
+// NewEncoder returns a new encoder that writes to w.
+// The header version defaults to 0.6.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		Header: osm.OSM{Version: 0.6},
+		w:      bufio.NewWriter(w),
+		first:  true,
+	}
+}
+
+// Encode writes the object to the stream. The interface is implemented by:
+//	*osm.Bounds
+//	*osm.Node
+//	*osm.Way
+//	*osm.Relation
+//	*osm.Changeset
+//	*osm.Note
+//	*osm.User
+// Bounds encoded before anything else are used as the header bounds, otherwise
+// they are ignored since the format only has bounds for the whole document.
+func (e *Encoder) Encode(o osm.Object) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	switch o := o.(type) {
+	case *osm.Bounds:
+		if !e.started && e.Header.Bounds == nil {
+			e.Header.Bounds = o
+		}
+
+		return nil
+	case *osm.Node, *osm.Way, *osm.Relation, *osm.Changeset, *osm.Note, *osm.User:
+	default:
+		return fmt.Errorf("osmjson: unsupported object type: %T", o)
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(o)
+	if err != nil {
+		e.err = err
+		return err
+	}
+
+	if !e.first {
+		data = append([]byte{','}, data...)
+	}
+	e.first = false
+
+	_, e.err = e.w.Write(data)
+	return e.err
+}
+
+// EncodeScanner encodes all the objects returned by the scanner.
+// The scanner is not closed.
+func (e *Encoder) EncodeScanner(s osm.Scanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Object()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close writes the end of the document and flushes any buffered data.
+// It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+	e.closed = true
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if _, err := e.w.WriteString("]}"); err != nil {
+		return err
+	}
+
+	return e.w.Flush()
+}
+
+// start writes the header attributes, bounds and the start of the elements.
+func (e *Encoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	h := struct {
+		Version     float64     `json:"version,omitempty"`
+		Generator   string      `json:"generator,omitempty"`
+		Copyright   string      `json:"copyright,omitempty"`
+		Attribution string      `json:"attribution,omitempty"`
+		License     string      `json:"license,omitempty"`
+		Bounds      *osm.Bounds `json:"bounds,omitempty"`
+	}{e.Header.Version, e.Header.Generator, e.Header.Copyright,
+		e.Header.Attribution, e.Header.License, e.Header.Bounds}
+
+	data, err := json.Marshal(h)
+	if err != nil {
+		e.err = err
+		return err
+	}
+
+	// replace the closing brace with the start of the elements
+	data = data[:len(data)-1]
+	if len(data) > 1 {
+		data = append(data, ',')
+	}
+	data = append(data, `"elements":[`...)
+
+	_, e.err = e.w.Write(data)
+	return e.err
+}
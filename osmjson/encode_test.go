@@ -0,0 +1,97 @@
+package osmjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmtest"
+)
+
+func TestEncoder(t *testing.T) {
+	expected := osm.Objects{
+		&osm.Node{ID: 1, Lat: 1.5, Lon: 3.5, Version: 2, Visible: true, Tags: osm.Tags{{Key: "amenity", Value: "cafe"}}},
+		&osm.Node{ID: 2, Lat: 1.6, Lon: 3.6, Version: 1, Visible: true},
+		&osm.Way{ID: 3, Version: 1, Visible: true, Nodes: osm.WayNodes{{ID: 1}, {ID: 2}}},
+		&osm.Relation{ID: 4, Version: 1, Visible: true, Members: osm.Members{{Type: osm.TypeWay, Ref: 3, Role: "outer"}}},
+		&osm.Changeset{ID: 5, Tags: osm.Tags{{Key: "comment", Value: "test"}}},
+		&osm.User{ID: 6, Name: "user"},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	enc.Header.Generator = "test"
+
+	bounds := &osm.Bounds{MinLat: 1, MaxLat: 2, MinLon: 3, MaxLon: 4}
+	if err := enc.Encode(bounds); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	if err := enc.EncodeScanner(osmtest.NewScanner(expected)); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	if err := enc.Encode(expected[0]); err != ErrEncoderClosed {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	o := &osm.OSM{}
+	if err := json.Unmarshal(buf.Bytes(), o); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+
+	if o.Version != 0.6 || o.Generator != "test" || !reflect.DeepEqual(o.Bounds, bounds) {
+		t.Errorf("incorrect header: %v %v %v", o.Version, o.Generator, o.Bounds)
+	}
+
+	scanner := New(context.Background(), buf)
+	defer scanner.Close()
+
+	var objects osm.Objects
+	for scanner.Scan() {
+		objects = append(objects, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	// the header bounds are returned first
+	expected = append(osm.Objects{bounds}, expected...)
+	if len(objects) != len(expected) {
+		t.Fatalf("incorrect number of objects: %v != %v", len(objects), len(expected))
+	}
+
+	for i := range expected {
+		e, _ := json.Marshal(expected[i])
+		a, _ := json.Marshal(objects[i])
+		if !bytes.Equal(a, e) {
+			t.Errorf("objects not equal:\n%s\n%s", a, e)
+		}
+	}
+}
+
+func TestEncoder_empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	enc.Header.Version = 0
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	if v := buf.String(); v != `{"elements":[]}` {
+		t.Errorf("incorrect json: %v", v)
+	}
+
+	if err := NewEncoder(buf).Encode(&osm.Note{ID: 1}); err != nil {
+		t.Errorf("encode error: %v", err)
+	}
+}
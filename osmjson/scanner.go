@@ -0,0 +1,181 @@
+// Package osmjson provides a scanner and encoder for the osmjson format returned
+// by the osm api .json endpoints and overpass.
+package osmjson
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ich5003/small-osm"
+)
+
+var _ osm.Scanner = &Scanner{}
+
+// Scanner provides a convenient interface reading a stream of osmjson data,
+// i.e. {"version":0.6,"elements":[{"type":"node",...}]}. Successive calls
+// to the Scan method will step through the elements without decoding the
+// whole document.
+//
+// Scanning stops unrecoverably at EOF, the first I/O error, the first json error or
+// the context being cancelled. When a scan stops, the reader may have advanced
+// arbitrarily far past the last token.
+//
+// The Scanner API is based on bufio.Scanner
+// https://golang.org/pkg/bufio/#Scanner
+type Scanner struct {
+	ctx    context.Context
+	done   context.CancelFunc
+	closed bool
+
+	decoder *json.Decoder
+	started bool
+	inArray bool
+	next    osm.Object
+	err     error
+}
+
+// New returns a new Scanner to read from r.
+func New(ctx context.Context, r io.Reader) *Scanner {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s := &Scanner{
+		decoder: json.NewDecoder(r),
+	}
+
+	s.ctx, s.done = context.WithCancel(ctx)
+	return s
+}
+
+// Close causes all future calls to Scan to return false.
+// Does not close the underlying reader.
+func (s *Scanner) Close() error {
+	s.closed = true
+	s.done()
+
+	return nil
+}
+
+// Scan advances the Scanner to the next element, which will then be available
+// through the Object method. It returns false when the scan stops, either
+// by reaching the end of the input, an io error, a json error or the context
+// being cancelled. After Scan returns false, the Err method will return any
+// error that occurred during scanning, except if it was io.EOF, Err will
+// return nil.
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	if !s.started {
+		s.started = true
+		if s.err = s.expectDelim('{'); s.err != nil {
+			return false
+		}
+	}
+
+	for {
+		if s.ctx.Err() != nil {
+			return false
+		}
+
+		s.next, s.err = s.scan()
+		if s.err != nil {
+			return false
+		}
+
+		if s.next != nil {
+			return true
+		}
+	}
+}
+
+// scan reads the next json value. It returns a nil object
+// if the value is not an element.
+func (s *Scanner) scan() (osm.Object, error) {
+	if s.inArray {
+		if !s.decoder.More() {
+			s.inArray = false
+			return nil, s.expectDelim(']')
+		}
+
+		var data json.RawMessage
+		if err := s.decoder.Decode(&data); err != nil {
+			return nil, err
+		}
+
+		return osm.UnmarshalJSONObject(data)
+	}
+
+	if !s.decoder.More() {
+		if err := s.expectDelim('}'); err != nil {
+			return nil, err
+		}
+
+		return nil, io.EOF
+	}
+
+	key, err := s.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch key {
+	case "elements":
+		s.inArray = true
+		return nil, s.expectDelim('[')
+	case "bounds":
+		bounds := &osm.Bounds{}
+		return bounds, s.decoder.Decode(bounds)
+	}
+
+	// skip the other attributes, e.g. version and generator
+	var skip json.RawMessage
+	return nil, s.decoder.Decode(&skip)
+}
+
+func (s *Scanner) expectDelim(d json.Delim) error {
+	t, err := s.decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	if t != d {
+		return fmt.Errorf("osmjson: expected %v, got %v", d, t)
+	}
+
+	return nil
+}
+
+// Object returns the most recent token generated by a call to Scan
+// as a new osm.Object. This interface is implemented by:
+//	*osm.Bounds
+//	*osm.Node
+//	*osm.Way
+//	*osm.Relation
+//	*osm.Changeset
+//	*osm.Note
+//	*osm.User
+func (s *Scanner) Object() osm.Object {
+	return s.next
+}
+
+// Err returns the first non-EOF error that was encountered by the Scanner.
+func (s *Scanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.closed {
+		return osm.ErrScannerClosed
+	}
+
+	return s.ctx.Err()
+}
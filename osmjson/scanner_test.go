@@ -0,0 +1,163 @@
+package osmjson
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestScanner(t *testing.T) {
+	o := &osm.OSM{
+		Version:   0.6,
+		Generator: "test",
+		Bounds:    &osm.Bounds{MinLat: 1, MaxLat: 2, MinLon: 3, MaxLon: 4},
+		Nodes: osm.Nodes{
+			{ID: 1, Lat: 1.5, Lon: 3.5, Tags: osm.Tags{{Key: "amenity", Value: "cafe"}}},
+			{ID: 2, Lat: 1.6, Lon: 3.6},
+		},
+		Ways: osm.Ways{
+			{ID: 3, Nodes: osm.WayNodes{{ID: 1}, {ID: 2}}},
+		},
+		Relations: osm.Relations{
+			{ID: 4, Members: osm.Members{{Type: osm.TypeWay, Ref: 3, Role: "outer"}}},
+		},
+		Changesets: osm.Changesets{
+			{ID: 5, Tags: osm.Tags{{Key: "comment", Value: "test"}}},
+		},
+		Users: osm.Users{
+			{ID: 6, Name: "user"},
+		},
+	}
+
+	data, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+
+	scanner := New(context.Background(), bytes.NewReader(data))
+	defer scanner.Close()
+
+	var objects osm.Objects
+	for scanner.Scan() {
+		objects = append(objects, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	expected := o.Objects()
+	if len(objects) != len(expected) {
+		t.Fatalf("incorrect number of objects: %v != %v", len(objects), len(expected))
+	}
+
+	for i := range expected {
+		e, err := json.Marshal(expected[i])
+		if err != nil {
+			t.Fatalf("marshal error: %v", err)
+		}
+
+		a, err := json.Marshal(objects[i])
+		if err != nil {
+			t.Fatalf("marshal error: %v", err)
+		}
+
+		if !bytes.Equal(a, e) {
+			t.Errorf("objects not equal:\n%s\n%s", a, e)
+		}
+	}
+
+	if w := objects[3].(*osm.Way); !reflect.DeepEqual(w.Nodes, o.Ways[0].Nodes) {
+		t.Errorf("incorrect way: %+v", w)
+	}
+}
+
+func TestScanner_order(t *testing.T) {
+	// elements before the other attributes
+	data := `{"elements":[{"type":"node","id":1},{"type":"way","id":2,"nodes":[1]}],"version":"0.6","generator":"x"}`
+
+	scanner := New(context.Background(), strings.NewReader(data))
+	var ids osm.ObjectIDs
+	for scanner.Scan() {
+		ids = append(ids, scanner.Object().ObjectID())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	expected := osm.ObjectIDs{osm.NodeID(1).ObjectID(0), osm.WayID(2).ObjectID(0)}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("incorrect ids: %v", ids)
+	}
+}
+
+func TestScanner_overpass(t *testing.T) {
+	data := `{"version":0.6,"generator":"Overpass API","elements":[
+{"type":"area","id":3600062422,"tags":{"name":"Berlin","type":"boundary"}},
+{"type":"node","id":1,"lat":52.5,"lon":13.4},
+{"type":"count","id":0,"tags":{"nodes":"1","ways":"0","relations":"0","total":"1"}},
+{"type":"way","id":2,"nodes":[1]}
+]}`
+
+	scanner := New(context.Background(), strings.NewReader(data))
+	var ids osm.ObjectIDs
+	for scanner.Scan() {
+		ids = append(ids, scanner.Object().ObjectID())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	expected := osm.ObjectIDs{osm.NodeID(1).ObjectID(0), osm.WayID(2).ObjectID(0)}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("incorrect ids: %v", ids)
+	}
+}
+
+func TestScanner_errors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+	}{
+		{"not an object", `[{"type":"node","id":1}]`},
+		{"missing type", `{"elements":[{"id":1}]}`},
+		{"invalid json", `{"elements":[{"type":"node","id":1]}`},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			scanner := New(context.Background(), strings.NewReader(tc.data))
+			for scanner.Scan() {
+			}
+
+			if scanner.Err() == nil {
+				t.Errorf("should return error")
+			}
+		})
+	}
+}
+
+func TestScanner_Close(t *testing.T) {
+	data := `{"elements":[{"type":"node","id":1},{"type":"node","id":2}]}`
+
+	scanner := New(context.Background(), strings.NewReader(data))
+	if !scanner.Scan() {
+		t.Fatalf("should scan first object: %v", scanner.Err())
+	}
+
+	scanner.Close()
+	if scanner.Scan() {
+		t.Errorf("should not scan after close")
+	}
+
+	if err := scanner.Err(); err != osm.ErrScannerClosed {
+		t.Errorf("incorrect error: %v", err)
+	}
+}
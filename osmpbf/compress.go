@@ -0,0 +1,85 @@
+package osmpbf
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+	"github.com/ulikunitz/xz/lzma"
+)
+
+// The less common blob compressions use pure go implementations
+// so they are available with and without cgo.
+
+var (
+	zstdOnce    sync.Once
+	zstdDecoder *zstd.Decoder
+	zstdEncoder *zstd.Encoder
+	zstdErr     error
+)
+
+func zstdInit() {
+	// DecodeAll and EncodeAll are safe for concurrent use,
+	// so one decoder and encoder is shared by all the goroutines.
+	// The decoder memory is limited so a small blob can't expand
+	// far past the max blob size before the raw size is checked.
+	zstdDecoder, zstdErr = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxBlobSize))
+	if zstdErr != nil {
+		return
+	}
+
+	zstdEncoder, zstdErr = zstd.NewWriter(nil)
+}
+
+func zstdDecompress(data []byte, dst []byte) ([]byte, error) {
+	zstdOnce.Do(zstdInit)
+	if zstdErr != nil {
+		return nil, zstdErr
+	}
+
+	return zstdDecoder.DecodeAll(data, dst)
+}
+
+func zstdCompress(data []byte) ([]byte, error) {
+	zstdOnce.Do(zstdInit)
+	if zstdErr != nil {
+		return nil, zstdErr
+	}
+
+	return zstdEncoder.EncodeAll(data, nil), nil
+}
+
+// The lz4 data is a single lz4 block without the frame,
+// the uncompressed size is known from the blob raw_size.
+func lz4Decompress(data []byte, dst []byte) ([]byte, error) {
+	n, err := lz4.UncompressBlock(data, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	return dst[:n], nil
+}
+
+// lz4Compress returns an empty result if the data is not compressible.
+func lz4Compress(data []byte) ([]byte, error) {
+	dst := make([]byte, lz4.CompressBlockBound(len(data)))
+
+	n, err := lz4.CompressBlock(data, dst, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return dst[:n], nil
+}
+
+func lzmaReader(data []byte) (io.ReadCloser, error) {
+	r, err := lzma.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.NopCloser(r), nil
+}
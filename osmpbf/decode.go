@@ -22,9 +22,9 @@ const (
 
 var (
 	parseCapabilities = map[string]bool{
-		"OsmSchema-V0.6":        true,
-		"DenseNodes":            true,
-		"HistoricalInformation": true,
+		"OsmSchema-V0.6":             true,
+		"DenseNodes":                 true,
+		featureHistoricalInformation: true,
 	}
 )
 
@@ -46,6 +46,19 @@ type Header struct {
 	ReplicationBaseURL   string
 }
 
+// HistoricalInformation returns true if the file is a full history file.
+// These can contain multiple versions of an element, and deleted versions
+// which will have Visible set to false.
+func (h *Header) HistoricalInformation() bool {
+	for _, f := range h.RequiredFeatures {
+		if f == featureHistoricalInformation {
+			return true
+		}
+	}
+
+	return false
+}
+
 // iPair is the group sent on the chan into the decoder
 // goroutines that unzip and decode the pbf from the headerblock.
 type iPair struct {
@@ -70,6 +83,15 @@ type decoder struct {
 	r         io.Reader
 	bytesRead int64
 
+	// set when resuming from an offset, see Resume
+	seeker io.ReadSeeker
+	offset int64
+
+	// set if the versions of the first element at the resume offset
+	// start in an earlier block, the block is decoded from that element.
+	spanOffset int64
+	spanID     osm.FeatureID
+
 	ctx    context.Context
 	cancel func()
 	wg     sync.WaitGroup
@@ -113,9 +135,16 @@ func (dec *decoder) Start(n int) error {
 	headerBuf := make([]byte, maxBlobHeaderSize)
 	blobBuf := make([]byte, maxBlobSize)
 
+	if dec.seeker != nil {
+		if err := dec.seek(sizeBuf, headerBuf, blobBuf); err != nil {
+			return err
+		}
+	}
+
 	// read OSMHeader
 	// NOTE: if the first block is not a header, i.e. after a restart we need
 	// to decode that block. It gets pushed on the first "input" below.
+	firstOffset := dec.bytesRead
 	blobHeader, blob, err := dec.readFileBlock(sizeBuf, headerBuf, blobBuf)
 	if err != nil {
 		return err
@@ -158,7 +187,7 @@ func (dec *decoder) Start(n int) error {
 				if p.Err == nil {
 					// send decoded objects or decoding error
 					objects, err := dd.Decode(p.Blob)
-					out = oPair{Offset: p.Offset, Objects: objects, Err: err}
+					out = oPair{Offset: p.Offset, Objects: dec.trim(p.Offset, objects), Err: err}
 				} else {
 					out = oPair{Err: p.Err} // send input error as is
 				}
@@ -191,7 +220,7 @@ func (dec *decoder) Start(n int) error {
 		// On restart the first block may not be a header and will need to be
 		// added to the first input.
 		if blobHeader.GetType() != osmHeaderType {
-			dec.inputs[0] <- iPair{Offset: 0, Blob: blob, Err: err}
+			dec.inputs[0] <- iPair{Offset: firstOffset, Blob: blob, Err: err}
 
 			i = (i + 1) % n
 		}
@@ -252,6 +281,284 @@ func (dec *decoder) Start(n int) error {
 	return nil
 }
 
+// ScanBlocks reads and decodes the data blocks using n goroutines and calls fn
+// with the objects of each block as soon as it is decoded. Unlike Start there is
+// no serializer so the blocks are not in file order. It returns when all the
+// blocks have been handled, with the first error from decoding or fn.
+func (dec *decoder) ScanBlocks(n int, fn func(int64, []osm.Object) error) error {
+	if n < 1 {
+		n = 1
+	}
+
+	sizeBuf := make([]byte, 4)
+	headerBuf := make([]byte, maxBlobHeaderSize)
+	blobBuf := make([]byte, maxBlobSize)
+
+	if dec.seeker != nil {
+		if err := dec.seek(sizeBuf, headerBuf, blobBuf); err != nil {
+			return err
+		}
+	}
+
+	var (
+		errOnce sync.Once
+		err     error
+	)
+
+	fail := func(e error) {
+		errOnce.Do(func() {
+			err = e
+			dec.cancel()
+		})
+	}
+
+	inputs := make(chan iPair, n)
+
+	dec.wg.Add(n)
+	for i := 0; i < n; i++ {
+		dd := &dataDecoder{scanner: dec.scanner}
+
+		go func() {
+			defer dec.wg.Done()
+
+			for p := range inputs {
+				if dec.ctx.Err() != nil {
+					continue // drain the inputs
+				}
+
+				objects, err := dd.Decode(p.Blob)
+				if err == nil {
+					err = fn(p.Offset, dec.trim(p.Offset, objects))
+				}
+
+				if err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	// read the OSMHeader, on resume the first block is data and is decoded.
+	for first := true; ; first = false {
+		offset := dec.bytesRead
+		blobHeader, blob, e := dec.readFileBlock(sizeBuf, headerBuf, blobBuf)
+		if e == io.EOF {
+			break
+		}
+
+		if e == nil && first && blobHeader.GetType() == osmHeaderType {
+			dec.header, e = decodeOSMHeader(blob)
+			if e != nil {
+				fail(e)
+				break
+			}
+
+			continue
+		}
+
+		if e == nil && blobHeader.GetType() != osmDataType {
+			e = fmt.Errorf("unexpected fileblock of type %s", blobHeader.GetType())
+		}
+
+		if e != nil {
+			fail(e)
+			break
+		}
+
+		select {
+		case inputs <- iPair{Offset: offset, Blob: blob}:
+		case <-dec.ctx.Done():
+		}
+
+		if dec.ctx.Err() != nil {
+			break
+		}
+	}
+
+	close(inputs)
+	dec.wg.Wait()
+
+	if err != nil {
+		return err
+	}
+
+	return dec.ctx.Err()
+}
+
+// seek reads the header from the start of the file, if not already known,
+// and then moves the reader to the offset to resume from.
+func (dec *decoder) seek(sizeBuf, headerBuf, blobBuf []byte) error {
+	if dec.header == nil {
+		if _, err := dec.seeker.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+
+		blobHeader, blob, err := dec.readFileBlock(sizeBuf, headerBuf, blobBuf)
+		if err != nil {
+			return err
+		}
+
+		if blobHeader.GetType() != osmHeaderType {
+			return fmt.Errorf("unexpected first fileblock of type %s", blobHeader.GetType())
+		}
+
+		dec.header, err = decodeOSMHeader(blob)
+		if err != nil {
+			return err
+		}
+	}
+
+	// only history files have multiple versions of an element
+	if dec.header.HistoricalInformation() {
+		if err := dec.seekSpanStart(sizeBuf, headerBuf, blobBuf); err != nil {
+			return err
+		}
+	}
+
+	if _, err := dec.seeker.Seek(dec.offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	dec.bytesRead = dec.offset
+	dec.pOffset = dec.offset
+	dec.cOffset = dec.offset
+
+	return nil
+}
+
+// seekSpanStart moves the offset back to the block where the versions of the first
+// element at the offset start. The blocks before the offset are found by reading
+// the blob headers from the start of the file.
+func (dec *decoder) seekSpanStart(sizeBuf, headerBuf, blobBuf []byte) error {
+	offsets, err := dec.blockOffsets(sizeBuf, headerBuf)
+	if err != nil {
+		return err
+	}
+
+	blocks, err := dec.indexBlockAt(dec.offset, sizeBuf, headerBuf, blobBuf)
+	if err != nil || len(blocks) == 0 {
+		return err
+	}
+
+	// history files are sorted by type, id and version so the first
+	// element of the block is the min id of the first type.
+	first := blocks[0]
+	id, err := first.Type.FeatureID(first.MinID)
+	if err != nil {
+		return err
+	}
+
+	for i := len(offsets) - 1; i >= 0; i-- {
+		blocks, err := dec.indexBlockAt(offsets[i], sizeBuf, headerBuf, blobBuf)
+		if err != nil {
+			return err
+		}
+
+		if len(blocks) == 0 {
+			break
+		}
+
+		last := blocks[len(blocks)-1]
+		if last.Type != first.Type || last.MaxID != first.MinID {
+			break
+		}
+
+		dec.offset = offsets[i]
+		dec.spanOffset = offsets[i]
+		dec.spanID = id
+
+		// continue if the block only has versions of the element
+		if len(blocks) > 1 || blocks[0].MinID != first.MinID {
+			break
+		}
+	}
+
+	return nil
+}
+
+// blockOffsets returns the offsets of the data blocks before the offset.
+// Only the blob headers are read, the data is skipped.
+func (dec *decoder) blockOffsets(sizeBuf, headerBuf []byte) ([]int64, error) {
+	if _, err := dec.seeker.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var (
+		offset  int64
+		offsets []int64
+	)
+	for offset < dec.offset {
+		blobHeaderSize, err := dec.readBlobHeaderSize(sizeBuf)
+		if err == io.EOF {
+			return nil, fmt.Errorf("resume offset %d is after the end of the file", dec.offset)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		blobHeader, err := dec.readBlobHeader(headerBuf[:blobHeaderSize])
+		if err != nil {
+			return nil, err
+		}
+
+		if blobHeader.GetType() == osmDataType {
+			offsets = append(offsets, offset)
+		}
+
+		offset += 4 + int64(blobHeaderSize) + int64(blobHeader.GetDatasize())
+		if _, err := dec.seeker.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	if offset != dec.offset {
+		return nil, fmt.Errorf("resume offset %d is not the start of a block", dec.offset)
+	}
+
+	return offsets, nil
+}
+
+// indexBlockAt returns the index entries of the data block at the offset.
+// It returns nothing at the end of the file or if the block is not data.
+func (dec *decoder) indexBlockAt(offset int64, sizeBuf, headerBuf, blobBuf []byte) ([]IndexBlock, error) {
+	if _, err := dec.seeker.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	blobHeader, blob, err := dec.readFileBlock(sizeBuf, headerBuf, blobBuf)
+	if err == io.EOF {
+		return nil, nil
+	}
+
+	if err != nil || blobHeader.GetType() != osmDataType {
+		return nil, err
+	}
+
+	data, err := getData(blob, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return indexPrimitiveBlock(data)
+}
+
+// trim removes the objects before the element whose versions span
+// into the resume block, if this is the block where they start.
+func (dec *decoder) trim(offset int64, objects []osm.Object) []osm.Object {
+	if dec.spanOffset == 0 || offset != dec.spanOffset {
+		return objects
+	}
+
+	for i, o := range objects {
+		if e, ok := o.(osm.Element); ok && e.FeatureID() == dec.spanID {
+			return objects[i:]
+		}
+	}
+
+	return nil
+}
+
 // Next reads the next object from the input stream and returns either a
 // Node, Way or Relation struct representing the underlying OpenStreetMap PBF
 // data, or error encountered. The end of the input stream is reported by an io.EOF error.
@@ -339,57 +646,109 @@ func (dec *decoder) readBlob(buf []byte) (*osmpbf.Blob, error) {
 }
 
 func getData(blob *osmpbf.Blob, data []byte) ([]byte, error) {
-	switch {
-	case blob.Raw != nil:
+	var (
+		r   io.ReadCloser
+		err error
+	)
+
+	if blob.Raw != nil {
 		return blob.GetRaw(), nil
+	}
 
+	// the raw size is used to allocate the buffer for the compressed data
+	l := int(blob.GetRawSize())
+	if l < 0 || l > maxBlobSize {
+		return nil, fmt.Errorf("invalid raw blob data size %d", l)
+	}
+
+	switch {
 	case blob.ZlibData != nil:
-		r, err := zlibReader(blob.GetZlibData())
+		r, err = zlibReader(blob.GetZlibData())
+
+	case blob.LzmaData != nil:
+		r, err = lzmaReader(blob.GetLzmaData())
+
+	case blob.Lz4Data != nil:
+		data, err = lz4Decompress(blob.GetLz4Data(), rawBuffer(data, l)[:l])
 		if err != nil {
 			return nil, err
 		}
+		return checkRawSize(blob, data)
 
-		// using the bytes.Buffer allows for the preallocation of the necessary space.
-		l := blob.GetRawSize() + bytes.MinRead
-		if cap(data) < int(l) {
-			data = make([]byte, 0, l+l/10)
-		} else {
-			data = data[:0]
-		}
-		buf := bytes.NewBuffer(data)
-		if _, err = buf.ReadFrom(r); err != nil {
+	case blob.ZstdData != nil:
+		data, err = zstdDecompress(blob.GetZstdData(), rawBuffer(data, l))
+		if err != nil {
 			return nil, err
 		}
+		return checkRawSize(blob, data)
 
-		if buf.Len() != int(blob.GetRawSize()) {
-			return nil, fmt.Errorf("raw blob data size %d but expected %d", buf.Len(), blob.GetRawSize())
-		}
-
-		return buf.Bytes(), nil
 	default:
 		return nil, errors.New("unknown blob data")
 	}
-}
 
-func decodeOSMHeader(blob *osmpbf.Blob) (*Header, error) {
-	data, err := getData(blob, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer r.Close()
 
-	headerBlock := &osmpbf.HeaderBlock{}
-	if err := proto.Unmarshal(data, headerBlock); err != nil {
+	// using the bytes.Buffer allows for the preallocation of the necessary space.
+	// Reading one byte past the raw size is enough for the size check to fail
+	// without decompressing all of the data.
+	buf := bytes.NewBuffer(rawBuffer(data, l))
+	if _, err = buf.ReadFrom(io.LimitReader(r, int64(l)+1)); err != nil {
+		return nil, err
+	}
+
+	return checkRawSize(blob, buf.Bytes())
+}
+
+// rawBuffer returns an empty buffer with enough capacity for the
+// uncompressed blob data, reusing the given one if possible.
+func rawBuffer(data []byte, rawSize int) []byte {
+	l := rawSize + bytes.MinRead
+	if cap(data) < l {
+		return make([]byte, 0, l+l/10)
+	}
+
+	return data[:0]
+}
+
+func checkRawSize(blob *osmpbf.Blob, data []byte) ([]byte, error) {
+	if len(data) != int(blob.GetRawSize()) {
+		return nil, fmt.Errorf("raw blob data size %d but expected %d", len(data), blob.GetRawSize())
+	}
+
+	return data, nil
+}
+
+func decodeOSMHeader(blob *osmpbf.Blob) (*Header, error) {
+	header, err := readOSMHeader(blob)
+	if err != nil {
 		return nil, err
 	}
 
 	// Check we have the parse capabilities
-	requiredFeatures := headerBlock.GetRequiredFeatures()
-	for _, feature := range requiredFeatures {
+	for _, feature := range header.RequiredFeatures {
 		if !parseCapabilities[feature] {
 			return nil, fmt.Errorf("parser does not have %s capability", feature)
 		}
 	}
 
+	return header, nil
+}
+
+// readOSMHeader decodes the header without checking the required features.
+func readOSMHeader(blob *osmpbf.Blob) (*Header, error) {
+	data, err := getData(blob, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	headerBlock := &osmpbf.HeaderBlock{}
+	if err := proto.Unmarshal(data, headerBlock); err != nil {
+		return nil, err
+	}
+
 	// read the header
 	header := &Header{
 		RequiredFeatures:   headerBlock.GetRequiredFeatures(),
@@ -9,6 +9,23 @@ import (
 	"github.com/paulmach/protoscan"
 )
 
+// infoMetadata maps the field numbers of the Info and DenseInfo
+// messages to the element metadata they contain.
+var infoMetadata = [...]osm.MetadataMask{
+	2: osm.MetadataTimestamp,
+	3: osm.MetadataChangeset,
+	4: osm.MetadataUserID,
+	5: osm.MetadataUser,
+	6: osm.MetadataVisible,
+}
+
+// skipInfoField returns true if the Info or DenseInfo field
+// should not be decoded.
+func (dec *dataDecoder) skipInfoField(fieldNumber int) bool {
+	return fieldNumber < len(infoMetadata) &&
+		dec.scanner.SkipMetadata&infoMetadata[fieldNumber] != 0
+}
+
 // dataDecoder is a decoder for Blob with OSMData (PrimitiveBlock).
 type dataDecoder struct {
 	scanner *Scanner
@@ -44,6 +61,12 @@ type dataDecoder struct {
 	lons *protoscan.Iterator
 
 	keyvals *protoscan.Iterator
+
+	// filter tags resolved for the current block and
+	// buffers for the tags being matched
+	filterTags []filterTag
+	filterKeys []uint32
+	filterVals []uint32
 }
 
 func (dec *dataDecoder) Decode(blob *osmpbf.Blob) ([]osm.Object, error) {
@@ -123,6 +146,8 @@ func (dec *dataDecoder) scanPrimitiveBlock(data []byte) error {
 	}
 
 	// we need the offsets and granularities for the group decoding
+	// and the string table to match the filter tags.
+	dec.prepareFilter()
 
 	msg.Reset(nil)
 	for msg.Next() {
@@ -173,6 +198,17 @@ func (dec *dataDecoder) scanPrimitiveGroup(data []byte) error {
 				return err
 			}
 
+			if dec.scanner.Filter != nil {
+				match, err := dec.matchElement(data)
+				if err != nil {
+					return err
+				}
+
+				if !match {
+					continue
+				}
+			}
+
 			err = dec.scanWays(data)
 			if err != nil {
 				return err
@@ -187,6 +223,17 @@ func (dec *dataDecoder) scanPrimitiveGroup(data []byte) error {
 				return err
 			}
 
+			if dec.scanner.Filter != nil {
+				match, err := dec.matchElement(data)
+				if err != nil {
+					return err
+				}
+
+				if !match {
+					continue
+				}
+			}
+
 			err = dec.scanRelations(data)
 			if err != nil {
 				return err
@@ -218,12 +265,22 @@ func (dec *dataDecoder) scanDenseNodes(data []byte) error {
 			}
 
 			// verify all the fields are "found" since we reuse object from last block
-			// and can't just check for nil
-			var foundVersions, foundTimestamps, foundChangesets,
-				foundUids, foundUsids, foundVisibles bool
+			// and can't just check for nil, skipped fields are never read.
+			var foundVersions, foundVisibles bool
+
+			skip := dec.scanner.SkipMetadata
+			foundTimestamps := skip.Has(osm.MetadataTimestamp)
+			foundChangesets := skip.Has(osm.MetadataChangeset)
+			foundUids := skip.Has(osm.MetadataUserID)
+			foundUsids := skip.Has(osm.MetadataUser)
 
 			info := protoscan.New(d)
 			for info.Next() {
+				if dec.skipInfoField(info.FieldNumber()) {
+					info.Skip()
+					continue
+				}
+
 				var err error
 				switch info.FieldNumber() {
 				case 1: // version
@@ -309,6 +366,9 @@ func (dec *dataDecoder) extractDenseNodes() error {
 	latOffset := dec.primitiveBlock.GetLatOffset()
 	lonOffset := dec.primitiveBlock.GetLonOffset()
 
+	skip := dec.scanner.SkipMetadata
+	filter := dec.scanner.Filter
+
 	// we also assume all the iterators have the same length....
 
 	nodes := make([]osm.Node, dec.versions.Count(protoscan.WireTypeVarint))
@@ -336,39 +396,54 @@ func (dec *dataDecoder) extractDenseNodes() error {
 		n.Version = int(v2)
 
 		// Timestamp
-		v3, err := dec.timestamps.Sint64()
-		if err != nil {
-			return err
+		if !skip.Has(osm.MetadataTimestamp) {
+			v3, err := dec.timestamps.Sint64()
+			if err != nil {
+				return err
+			}
+			timestamp += v3
+			n.Timestamp = fromTimestamp(timestamp, dateGranularity)
 		}
-		timestamp += v3
-		_ = time.Duration(timestamp*dateGranularity) * time.Millisecond
 
 		// Changeset
-		v4, err := dec.changesets.Sint64()
-		if err != nil {
-			return err
+		if !skip.Has(osm.MetadataChangeset) {
+			v4, err := dec.changesets.Sint64()
+			if err != nil {
+				return err
+			}
+			changeset += v4
+			n.ChangesetID = osm.ChangesetID(changeset)
 		}
-		changeset += v4
 
 		// uid
-		v5, err := dec.uids.Sint32()
-		if err != nil {
-			return err
+		if !skip.Has(osm.MetadataUserID) {
+			v5, err := dec.uids.Sint32()
+			if err != nil {
+				return err
+			}
+			uid += v5
+			n.UserID = osm.UserID(uid)
 		}
-		uid += v5
 
 		// usid
-		v6, err := dec.usids.Sint32()
-		if err != nil {
-			return err
+		if !skip.Has(osm.MetadataUser) {
+			v6, err := dec.usids.Sint32()
+			if err != nil {
+				return err
+			}
+			usid += v6
+			n.User = st[usid]
 		}
-		usid += v6
 
 		// Visible
-		if dec.visibles != nil {
-			_, err := dec.visibles.Bool()
-			if err != nil {
-				return err
+		if !skip.Has(osm.MetadataVisible) {
+			n.Visible = true
+			if dec.visibles != nil {
+				v7, err := dec.visibles.Bool()
+				if err != nil {
+					return err
+				}
+				n.Visible = v7
 			}
 		}
 
@@ -388,8 +463,21 @@ func (dec *dataDecoder) extractDenseNodes() error {
 		lon += v9
 		n.Lon = 1e-9 * float64(lonOffset+(granularity*lon))
 
-		// tags, could be missing if all nodes are tagless
-		if dec.keyvals != nil {
+		if filter != nil {
+			match, err := dec.matchDenseNode(n)
+			if err != nil {
+				return err
+			}
+
+			if !match {
+				// reuse the node, all the fields will be set again
+				index--
+				continue
+			}
+
+			n.Tags = denseTags(st, dec.filterKeys, dec.filterVals)
+		} else if dec.keyvals != nil {
+			// tags, could be missing if all nodes are tagless
 			// TODO: precompute length of tags to preallocate
 			for {
 				k, err := dec.keyvals.Int32()
@@ -410,7 +498,9 @@ func (dec *dataDecoder) extractDenseNodes() error {
 			}
 		}
 
-		dec.q = append(dec.q, n)
+		if dec.scanner.FilterNode == nil || dec.scanner.FilterNode(n) {
+			dec.q = append(dec.q, n)
+		}
 	}
 
 	return nil
@@ -419,13 +509,14 @@ func (dec *dataDecoder) extractDenseNodes() error {
 func (dec *dataDecoder) scanWays(data []byte) error {
 	st := dec.primitiveBlock.GetStringtable().GetS()
 	granularity := int64(dec.primitiveBlock.GetGranularity())
+	dateGranularity := int64(dec.primitiveBlock.GetDateGranularity())
 
 	latOffset := dec.primitiveBlock.GetLatOffset()
 	lonOffset := dec.primitiveBlock.GetLonOffset()
 
 	msg := protoscan.New(data)
 
-	way := &osm.Way{}
+	way := &osm.Way{Visible: !dec.scanner.SkipMetadata.Has(osm.MetadataVisible)}
 	var foundKeys, foundVals bool
 	for msg.Next() {
 		var i64 int64
@@ -449,6 +540,11 @@ func (dec *dataDecoder) scanWays(data []byte) error {
 
 			info := protoscan.New(d)
 			for info.Next() {
+				if dec.skipInfoField(info.FieldNumber()) {
+					info.Skip()
+					continue
+				}
+
 				switch info.FieldNumber() {
 				case 1:
 					v, err := info.Int32()
@@ -456,6 +552,36 @@ func (dec *dataDecoder) scanWays(data []byte) error {
 						return err
 					}
 					way.Version = int(v)
+				case 2:
+					v, err := info.Int64()
+					if err != nil {
+						return err
+					}
+					way.Timestamp = fromTimestamp(v, dateGranularity)
+				case 3:
+					v, err := info.Int64()
+					if err != nil {
+						return err
+					}
+					way.ChangesetID = osm.ChangesetID(v)
+				case 4:
+					v, err := info.Int32()
+					if err != nil {
+						return err
+					}
+					way.UserID = osm.UserID(v)
+				case 5:
+					v, err := info.Uint32()
+					if err != nil {
+						return err
+					}
+					way.User = st[v]
+				case 6:
+					v, err := info.Bool()
+					if err != nil {
+						return err
+					}
+					way.Visible = v
 				default:
 					info.Skip()
 				}
@@ -542,7 +668,7 @@ func (dec *dataDecoder) scanWays(data []byte) error {
 		}
 	}
 
-	if !dec.scanner.OnlyCoastlines || way.Tags.Find("natural") == "coastline" {
+	if dec.scanner.FilterWay == nil || dec.scanner.FilterWay(way) {
 		dec.q = append(dec.q, way)
 	}
 
@@ -599,7 +725,7 @@ func (dec *dataDecoder) scanRelations(data []byte) error {
 
 	msg := protoscan.New(data)
 
-	relation := &osm.Relation{Visible: true}
+	relation := &osm.Relation{Visible: !dec.scanner.SkipMetadata.Has(osm.MetadataVisible)}
 	var foundKeys, foundVals, foundRoles, foundMemids, foundTypes bool
 	for msg.Next() {
 		var i64 int64
@@ -623,6 +749,11 @@ func (dec *dataDecoder) scanRelations(data []byte) error {
 
 			info := protoscan.New(d)
 			for info.Next() {
+				if dec.skipInfoField(info.FieldNumber()) {
+					info.Skip()
+					continue
+				}
+
 				switch info.FieldNumber() {
 				case 1:
 					v, err := info.Int32()
@@ -635,8 +766,7 @@ func (dec *dataDecoder) scanRelations(data []byte) error {
 					if err != nil {
 						return err
 					}
-					millisec := time.Duration(v*dateGranularity) * time.Millisecond
-					relation.Timestamp = time.Unix(0, millisec.Nanoseconds()).UTC()
+					relation.Timestamp = fromTimestamp(v, dateGranularity)
 				case 3:
 					v, err := info.Int64()
 					if err != nil {
@@ -709,7 +839,10 @@ func (dec *dataDecoder) scanRelations(data []byte) error {
 		}
 	}
 
-	dec.q = append(dec.q, relation)
+	if dec.scanner.FilterRelation == nil || dec.scanner.FilterRelation(relation) {
+		dec.q = append(dec.q, relation)
+	}
+
 	return nil
 }
 
@@ -737,3 +870,14 @@ func scanTags(stringTable []string, keys, vals *protoscan.Iterator) (osm.Tags, e
 
 	return tags, nil
 }
+
+// fromTimestamp converts the timestamp in units of the date granularity to a time.
+// A timestamp of 0 is returned as the zero time, it is written if the time is unknown.
+func fromTimestamp(ts, dateGranularity int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+
+	millisec := time.Duration(ts*dateGranularity) * time.Millisecond
+	return time.Unix(0, millisec.Nanoseconds()).UTC()
+}
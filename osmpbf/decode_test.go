@@ -1,6 +1,7 @@
 package osmpbf
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -13,6 +14,8 @@ import (
 	"time"
 
 	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmpbf/internal/osmpbf"
+	"github.com/ulikunitz/xz/lzma"
 )
 
 const (
@@ -369,3 +372,123 @@ func TestDecode_Close(t *testing.T) {
 		t.Errorf("close error: %v", err)
 	}
 }
+
+func TestGetData(t *testing.T) {
+	raw := bytes.Repeat([]byte("some osm data "), 1000)
+
+	lzmaData := &bytes.Buffer{}
+	w, err := lzma.NewWriter(lzmaData)
+	if err != nil {
+		t.Fatalf("lzma writer error: %v", err)
+	}
+	w.Write(raw)
+	w.Close()
+
+	lz4Data, err := lz4Compress(raw)
+	if err != nil {
+		t.Fatalf("lz4 error: %v", err)
+	}
+
+	zlibData := &bytes.Buffer{}
+	zw := zlibWriter(zlibData)
+	zw.Write(raw)
+	zw.Close()
+
+	zstdData, err := zstdCompress(raw)
+	if err != nil {
+		t.Fatalf("zstd error: %v", err)
+	}
+
+	size := int32(len(raw))
+	cases := []struct {
+		name string
+		blob *osmpbf.Blob
+	}{
+		{"raw", &osmpbf.Blob{Raw: raw}},
+		{"zlib", &osmpbf.Blob{ZlibData: zlibData.Bytes(), RawSize: size}},
+		{"lzma", &osmpbf.Blob{LzmaData: lzmaData.Bytes(), RawSize: size}},
+		{"lz4", &osmpbf.Blob{Lz4Data: lz4Data, RawSize: size}},
+		{"zstd", &osmpbf.Blob{ZstdData: zstdData, RawSize: size}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := getData(tc.blob, nil)
+			if err != nil {
+				t.Fatalf("get data error: %v", err)
+			}
+
+			if !bytes.Equal(data, raw) {
+				t.Errorf("incorrect data")
+			}
+
+			// reuse a buffer, as done by the decoder
+			data, err = getData(tc.blob, make([]byte, 10, 100))
+			if err != nil {
+				t.Fatalf("get data error: %v", err)
+			}
+
+			if !bytes.Equal(data, raw) {
+				t.Errorf("incorrect data with buffer")
+			}
+
+			// invalid raw size, checked before allocating the buffer
+			if tc.blob.Raw == nil {
+				for _, rs := range []int32{-1, maxBlobSize + 1, 1 << 30} {
+					tc.blob.RawSize = rs
+					if _, err := getData(tc.blob, nil); err == nil {
+						t.Errorf("should return error for raw size %d", rs)
+					}
+				}
+				tc.blob.RawSize = size
+			}
+
+			// incorrect raw size
+			if tc.blob.Raw == nil {
+				for _, rs := range []int32{size + 1, size - 1, 10} {
+					tc.blob.RawSize = rs
+					if _, err := getData(tc.blob, nil); err == nil {
+						t.Errorf("should return error for incorrect raw size %d", rs)
+					}
+				}
+			}
+		})
+	}
+
+	// data that decompresses to more than the max blob size
+	// should fail without decompressing all of it.
+	bomb := make([]byte, maxBlobSize+1024*1024)
+
+	lzmaData.Reset()
+	w, err = lzma.NewWriter(lzmaData)
+	if err != nil {
+		t.Fatalf("lzma writer error: %v", err)
+	}
+	w.Write(bomb)
+	w.Close()
+
+	zlibData.Reset()
+	zw = zlibWriter(zlibData)
+	zw.Write(bomb)
+	zw.Close()
+
+	zstdData, err = zstdCompress(bomb)
+	if err != nil {
+		t.Fatalf("zstd error: %v", err)
+	}
+
+	for _, blob := range []*osmpbf.Blob{
+		{ZlibData: zlibData.Bytes(), RawSize: size},
+		{LzmaData: lzmaData.Bytes(), RawSize: size},
+		{ZstdData: zstdData, RawSize: size},
+	} {
+		if _, err := getData(blob, nil); err == nil {
+			t.Errorf("should return error for data larger than the max blob size")
+		}
+	}
+
+	_, err = getData(&osmpbf.Blob{OBSOLETEBzip2Data: []byte{1}}, nil)
+	if err == nil {
+		t.Errorf("should return error for unknown blob data")
+	}
+}
@@ -0,0 +1,484 @@
+package osmpbf
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmpbf/internal/osmpbf"
+)
+
+const defaultBlockSize = 8000
+
+// ErrEncoderClosed is returned when encoding to a closed Encoder.
+var ErrEncoderClosed = errors.New("osmpbf: encoder closed")
+
+// header features with an effect on how the data is encoded.
+const (
+	featureHistoricalInformation = "HistoricalInformation"
+	featureLocationsOnWays       = "LocationsOnWays"
+)
+
+// Compression defines how the encoded blocks are compressed.
+type Compression int
+
+// Compression types of the blobs.
+const (
+	// CompressionZlib is the default and is supported by all readers.
+	CompressionZlib Compression = iota
+
+	// CompressionNone writes the blocks raw. This makes for larger
+	// files but faster reading and writing.
+	CompressionNone
+
+	// CompressionZstd and CompressionLZ4 are proposed extensions to the
+	// format. They are faster than zlib but not supported by all readers.
+	CompressionZstd
+	CompressionLZ4
+
+	// CompressionLZMA can be read but is not supported by the Encoder.
+	CompressionLZMA
+)
+
+// String returns the name of the compression as used in the blob.
+func (c Compression) String() string {
+	switch c {
+	case CompressionZlib:
+		return "zlib"
+	case CompressionNone:
+		return "raw"
+	case CompressionZstd:
+		return "zstd"
+	case CompressionLZ4:
+		return "lz4"
+	case CompressionLZMA:
+		return "lzma"
+	}
+
+	return fmt.Sprintf("Compression(%d)", int(c))
+}
+
+// Encoder writes a stream of osm data in the OSM PBF format.
+// Nodes, ways and relations are grouped into blocks that are encoded and
+// compressed in parallel, but written in the order they were given.
+//
+// The Encoder is not safe for parallel use.
+type Encoder struct {
+	// Header is written as the first block of the file. It can be modified
+	// until the first element is encoded. RequiredFeatures defaults to
+	// OsmSchema-V0.6 and DenseNodes. Visible flags are only written if
+	// HistoricalInformation is a required feature. Way node locations are
+	// only written if LocationsOnWays is an optional feature.
+	Header Header
+
+	// BlockSize is the max number of elements in a block. Elements of
+	// different types are always written in different blocks.
+	// Default is 8000, the osmosis and osmium default.
+	BlockSize int
+
+	// Compression used for the data blocks. Default is CompressionZlib.
+	Compression Compression
+
+	ctx    context.Context
+	cancel func()
+	w      io.Writer
+	procs  int
+
+	started bool
+	closed  bool
+	block   []osm.Object
+	wg      sync.WaitGroup
+
+	// encoding goroutines, same setup as the decoder
+	inputs  []chan<- []osm.Object
+	outputs []<-chan ePair
+	index   int
+
+	errLock sync.Mutex
+	err     error
+}
+
+// ePair is the group sent on the chan out of the encoder goroutines.
+// It contains the complete fileblock ready to be written.
+type ePair struct {
+	Data []byte
+	Err  error
+}
+
+// NewEncoder returns a new encoder that writes to w.
+// procs indicates the amount of parallelism used to encode and
+// compress the blocks.
+func NewEncoder(ctx context.Context, w io.Writer, procs int) *Encoder {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if procs < 1 {
+		procs = 1
+	}
+
+	e := &Encoder{
+		w:     w,
+		procs: procs,
+	}
+	e.ctx, e.cancel = context.WithCancel(ctx)
+
+	return e
+}
+
+// Encode adds the object to the stream. Only nodes, ways and relations can
+// be encoded, changesets, notes and users are ignored so the objects of
+// any scanner can be encoded. If osm.Bounds are given before any element,
+// they will be used as the header bounds if not already set, otherwise they are ignored.
+func (e *Encoder) Encode(o osm.Object) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if err := e.error(); err != nil {
+		return err
+	}
+
+	var t osm.Type
+	switch o := o.(type) {
+	case *osm.Node:
+		t = osm.TypeNode
+	case *osm.Way:
+		t = osm.TypeWay
+	case *osm.Relation:
+		t = osm.TypeRelation
+	case *osm.Bounds:
+		if !e.started && e.Header.Bounds == nil {
+			e.Header.Bounds = o
+		}
+		return nil
+	case *osm.Changeset, *osm.Note, *osm.User:
+		return nil
+	default:
+		return fmt.Errorf("osmpbf: unsupported object type: %T", o)
+	}
+
+	if !e.started {
+		if err := e.start(); err != nil {
+			return err
+		}
+	}
+
+	if len(e.block) > 0 && e.block[0].ObjectID().Type() != t {
+		if err := e.flush(); err != nil {
+			return err
+		}
+	}
+
+	e.block = append(e.block, o)
+	if len(e.block) >= e.blockSize() {
+		return e.flush()
+	}
+
+	return nil
+}
+
+// EncodeScanner encodes all the objects returned by the scanner.
+// The scanner is not closed.
+func (e *Encoder) EncodeScanner(s osm.Scanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Object()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close writes any remaining data and waits for the encoding goroutines
+// to finish. It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	var err error
+	if !e.started {
+		err = e.start()
+	}
+
+	if err == nil {
+		err = e.flush()
+	}
+	e.closed = true
+
+	for _, input := range e.inputs {
+		close(input)
+	}
+	e.wg.Wait()
+
+	if err == nil {
+		err = e.error()
+	}
+	e.cancel()
+
+	return err
+}
+
+func (e *Encoder) blockSize() int {
+	if e.BlockSize <= 0 {
+		return defaultBlockSize
+	}
+
+	return e.BlockSize
+}
+
+func (e *Encoder) error() error {
+	e.errLock.Lock()
+	defer e.errLock.Unlock()
+
+	if e.err != nil {
+		return e.err
+	}
+
+	return e.ctx.Err()
+}
+
+func (e *Encoder) setError(err error) {
+	e.errLock.Lock()
+	defer e.errLock.Unlock()
+
+	if e.err == nil {
+		e.err = err
+	}
+}
+
+// flush sends the current block to the encoding goroutines.
+func (e *Encoder) flush() error {
+	if len(e.block) == 0 {
+		return nil
+	}
+
+	input := e.inputs[e.index]
+	e.index = (e.index + 1) % e.procs
+
+	select {
+	case input <- e.block:
+	case <-e.ctx.Done():
+		return e.error()
+	}
+
+	e.block = make([]osm.Object, 0, len(e.block))
+	return nil
+}
+
+// start writes the header and starts the encoding goroutines.
+func (e *Encoder) start() error {
+	e.started = true
+
+	data, err := encodeOSMHeader(&e.Header)
+	if err != nil {
+		e.setError(err)
+		return err
+	}
+
+	fileblock, err := encodeFileBlock(osmHeaderType, data, e.Compression)
+	if err != nil {
+		e.setError(err)
+		return err
+	}
+
+	if _, err := e.w.Write(fileblock); err != nil {
+		e.setError(err)
+		return err
+	}
+
+	locations := false
+	for _, f := range e.Header.OptionalFeatures {
+		locations = locations || f == featureLocationsOnWays
+	}
+
+	// High level overview of the encoder, the reverse of the decoder:
+	// Full blocks are sent round-robin to the input channels. n goroutines
+	// read from the input channels, encode and compress the block and put
+	// the result on their output channel. A final goroutine round-robin reads
+	// the output channels and writes to the writer to maintain the order.
+	e.wg.Add(e.procs + 1)
+	for i := 0; i < e.procs; i++ {
+		input := make(chan []osm.Object, 1)
+		output := make(chan ePair, 1)
+
+		de := &dataEncoder{
+			compression: e.Compression,
+			visibles:    e.Header.HistoricalInformation(),
+			locations:   locations,
+		}
+
+		go func() {
+			defer close(output)
+			defer e.wg.Done()
+
+			for objects := range input {
+				data, err := de.Encode(objects)
+				select {
+				case output <- ePair{Data: data, Err: err}:
+				case <-e.ctx.Done():
+				}
+			}
+		}()
+
+		e.inputs = append(e.inputs, input)
+		e.outputs = append(e.outputs, output)
+	}
+
+	go func() {
+		defer e.wg.Done()
+
+		for i := 0; ; i = (i + 1) % e.procs {
+			var (
+				p  ePair
+				ok bool
+			)
+
+			select {
+			case p, ok = <-e.outputs[i]:
+			case <-e.ctx.Done():
+				return
+			}
+
+			if !ok {
+				return
+			}
+
+			err := p.Err
+			if err == nil {
+				_, err = e.w.Write(p.Data)
+			}
+
+			if err != nil {
+				e.setError(err)
+				e.cancel()
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func encodeOSMHeader(h *Header) ([]byte, error) {
+	headerBlock := &osmpbf.HeaderBlock{
+		RequiredFeatures:                 h.RequiredFeatures,
+		OptionalFeatures:                 h.OptionalFeatures,
+		Writingprogram:                   h.WritingProgram,
+		Source:                           h.Source,
+		OsmosisReplicationSequenceNumber: int64(h.ReplicationSeqNum),
+		OsmosisReplicationBaseUrl:        h.ReplicationBaseURL,
+	}
+
+	if len(headerBlock.RequiredFeatures) == 0 {
+		headerBlock.RequiredFeatures = []string{"OsmSchema-V0.6", "DenseNodes"}
+	}
+
+	for _, feature := range headerBlock.RequiredFeatures {
+		if !parseCapabilities[feature] {
+			return nil, fmt.Errorf("osmpbf: unsupported required feature %s", feature)
+		}
+	}
+
+	if !h.ReplicationTimestamp.IsZero() {
+		headerBlock.OsmosisReplicationTimestamp = h.ReplicationTimestamp.Unix()
+	}
+
+	if h.Bounds != nil {
+		// Units are always in nanodegree and do not obey granularity rules. See osmformat.proto
+		headerBlock.Bbox = &osmpbf.HeaderBBox{
+			Left:   toNanodegrees(h.Bounds.MinLon),
+			Right:  toNanodegrees(h.Bounds.MaxLon),
+			Bottom: toNanodegrees(h.Bounds.MinLat),
+			Top:    toNanodegrees(h.Bounds.MaxLat),
+		}
+	}
+
+	return headerBlock.Marshal()
+}
+
+// encodeFileBlock compresses the data and returns the complete fileblock:
+// the blob header size, the blob header and the blob.
+func encodeFileBlock(t string, data []byte, c Compression) ([]byte, error) {
+	// the decoder checks the raw size too, it can be over the
+	// limit even if the data compresses well.
+	if len(data) > maxBlobSize {
+		return nil, errors.New("osmpbf: raw blob size > 32Mb, use a smaller block size")
+	}
+
+	blob := &osmpbf.Blob{}
+
+	switch c {
+	case CompressionNone:
+		blob.Raw = data
+	case CompressionZlib:
+		buf := &bytes.Buffer{}
+		w := zlibWriter(buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+
+		blob.ZlibData = buf.Bytes()
+		blob.RawSize = int32(len(data))
+	case CompressionZstd:
+		compressed, err := zstdCompress(data)
+		if err != nil {
+			return nil, err
+		}
+
+		blob.ZstdData = compressed
+		blob.RawSize = int32(len(data))
+	case CompressionLZ4:
+		compressed, err := lz4Compress(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(compressed) == 0 {
+			// incompressible data is stored raw
+			blob.Raw = data
+			break
+		}
+
+		blob.Lz4Data = compressed
+		blob.RawSize = int32(len(data))
+	default:
+		return nil, fmt.Errorf("osmpbf: unsupported compression %d", c)
+	}
+
+	blobData, err := blob.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blobData) >= maxBlobSize {
+		return nil, errors.New("osmpbf: blob size >= 32Mb, use a smaller block size")
+	}
+
+	blobHeader := &osmpbf.BlobHeader{
+		Type:     t,
+		Datasize: int32(len(blobData)),
+	}
+
+	headerData, err := blobHeader.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]byte, 4, 4+len(headerData)+len(blobData))
+	binary.BigEndian.PutUint32(result, uint32(len(headerData)))
+	result = append(result, headerData...)
+	result = append(result, blobData...)
+
+	return result, nil
+}
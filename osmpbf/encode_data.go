@@ -0,0 +1,276 @@
+package osmpbf
+
+import (
+	"math"
+	"time"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmpbf/internal/osmpbf"
+)
+
+// dataEncoder is an encoder of elements into a Blob with OSMData (PrimitiveBlock).
+// The default granularities are used, 100 nanodegrees and 1000 milliseconds.
+type dataEncoder struct {
+	compression Compression
+	visibles    bool
+	locations   bool
+
+	// string table for the current block
+	strings map[string]int32
+	table   []string
+}
+
+// Encode returns the complete fileblock for the elements. All the elements
+// are expected to be of the same type.
+func (enc *dataEncoder) Encode(objects []osm.Object) ([]byte, error) {
+	enc.strings = make(map[string]int32, len(enc.table))
+	enc.table = append(enc.table[:0], "") // index 0 is reserved as the delimiter
+
+	group := &osmpbf.PrimitiveGroup{}
+	switch objects[0].(type) {
+	case *osm.Node:
+		group.Dense = enc.encodeDenseNodes(objects)
+	case *osm.Way:
+		group.Ways = make([]*osmpbf.Way, 0, len(objects))
+		for _, o := range objects {
+			group.Ways = append(group.Ways, enc.encodeWay(o.(*osm.Way)))
+		}
+	case *osm.Relation:
+		group.Relations = make([]*osmpbf.Relation, 0, len(objects))
+		for _, o := range objects {
+			group.Relations = append(group.Relations, enc.encodeRelation(o.(*osm.Relation)))
+		}
+	}
+
+	block := &osmpbf.PrimitiveBlock{
+		Stringtable:    &osmpbf.StringTable{S: enc.table},
+		Primitivegroup: []*osmpbf.PrimitiveGroup{group},
+	}
+
+	data, err := block.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeFileBlock(osmDataType, data, enc.compression)
+}
+
+func (enc *dataEncoder) encodeDenseNodes(objects []osm.Object) *osmpbf.DenseNodes {
+	l := len(objects)
+	dense := &osmpbf.DenseNodes{
+		Id:  make([]int64, l),
+		Lat: make([]int64, l),
+		Lon: make([]int64, l),
+		Denseinfo: &osmpbf.DenseInfo{
+			Version:   make([]int32, l),
+			Timestamp: make([]int64, l),
+			Changeset: make([]int64, l),
+			Uid:       make([]int32, l),
+			UserSid:   make([]int32, l),
+		},
+	}
+
+	info := dense.Denseinfo
+	if enc.visibles {
+		info.Visible = make([]bool, l)
+	}
+
+	var (
+		id, lat, lon, timestamp, changeset int64
+		uid, usid                          int32
+		tagged                             bool
+	)
+
+	for i, o := range objects {
+		n := o.(*osm.Node)
+
+		dense.Id[i] = int64(n.ID) - id
+		id = int64(n.ID)
+
+		v := toGranularity(n.Lat)
+		dense.Lat[i] = v - lat
+		lat = v
+
+		v = toGranularity(n.Lon)
+		dense.Lon[i] = v - lon
+		lon = v
+
+		info.Version[i] = int32(n.Version)
+
+		v = toTimestamp(n.Timestamp)
+		info.Timestamp[i] = v - timestamp
+		timestamp = v
+
+		info.Changeset[i] = int64(n.ChangesetID) - changeset
+		changeset = int64(n.ChangesetID)
+
+		info.Uid[i] = int32(n.UserID) - uid
+		uid = int32(n.UserID)
+
+		s := enc.stringID(n.User)
+		info.UserSid[i] = s - usid
+		usid = s
+
+		if enc.visibles {
+			info.Visible[i] = n.Visible
+		}
+
+		tagged = tagged || len(n.Tags) > 0
+	}
+
+	// keys_vals can be omitted if all the nodes are tagless
+	if tagged {
+		dense.KeysVals = make([]int32, 0, 2*l)
+		for _, o := range objects {
+			for _, t := range o.(*osm.Node).Tags {
+				dense.KeysVals = append(dense.KeysVals, enc.stringID(t.Key), enc.stringID(t.Value))
+			}
+			dense.KeysVals = append(dense.KeysVals, 0)
+		}
+	}
+
+	return dense
+}
+
+func (enc *dataEncoder) encodeWay(w *osm.Way) *osmpbf.Way {
+	keys, vals := enc.encodeTags(w.Tags)
+	encoded := &osmpbf.Way{
+		Id:   int64(w.ID),
+		Keys: keys,
+		Vals: vals,
+		Info: enc.encodeInfo(w.Version, w.Timestamp, w.ChangesetID, w.UserID, w.User, w.Visible),
+		Refs: make([]int64, len(w.Nodes)),
+	}
+
+	var prev int64
+	for i, n := range w.Nodes {
+		encoded.Refs[i] = int64(n.ID) - prev
+		prev = int64(n.ID)
+	}
+
+	if enc.locations {
+		encoded.Lat = make([]int64, len(w.Nodes))
+		encoded.Lon = make([]int64, len(w.Nodes))
+
+		var lat, lon int64
+		for i, n := range w.Nodes {
+			v := toGranularity(n.Lat)
+			encoded.Lat[i] = v - lat
+			lat = v
+
+			v = toGranularity(n.Lon)
+			encoded.Lon[i] = v - lon
+			lon = v
+		}
+	}
+
+	return encoded
+}
+
+func (enc *dataEncoder) encodeRelation(r *osm.Relation) *osmpbf.Relation {
+	keys, vals := enc.encodeTags(r.Tags)
+	encoded := &osmpbf.Relation{
+		Id:       int64(r.ID),
+		Keys:     keys,
+		Vals:     vals,
+		Info:     enc.encodeInfo(r.Version, r.Timestamp, r.ChangesetID, r.UserID, r.User, r.Visible),
+		RolesSid: make([]int32, len(r.Members)),
+		Memids:   make([]int64, len(r.Members)),
+		Types:    make([]osmpbf.Relation_MemberType, len(r.Members)),
+	}
+
+	var prev int64
+	for i, m := range r.Members {
+		encoded.RolesSid[i] = enc.stringID(m.Role)
+		encoded.Memids[i] = m.Ref - prev
+		prev = m.Ref
+
+		switch m.Type {
+		case osm.TypeNode:
+			encoded.Types[i] = osmpbf.Relation_NODE
+		case osm.TypeWay:
+			encoded.Types[i] = osmpbf.Relation_WAY
+		case osm.TypeRelation:
+			encoded.Types[i] = osmpbf.Relation_RELATION
+		}
+	}
+
+	return encoded
+}
+
+func (enc *dataEncoder) encodeInfo(
+	version int,
+	timestamp time.Time,
+	changeset osm.ChangesetID,
+	uid osm.UserID,
+	user string,
+	visible bool,
+) *osmpbf.Info {
+	v := int32(version)
+	info := &osmpbf.Info{
+		Version:   &v,
+		Timestamp: toTimestamp(timestamp),
+		Changeset: int64(changeset),
+		Uid:       int32(uid),
+		UserSid:   uint32(enc.stringID(user)),
+	}
+
+	if enc.visibles {
+		info.Visible = &visible
+	}
+
+	return info
+}
+
+func (enc *dataEncoder) encodeTags(tags osm.Tags) ([]uint32, []uint32) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]uint32, len(tags))
+	vals := make([]uint32, len(tags))
+	for i, t := range tags {
+		keys[i] = uint32(enc.stringID(t.Key))
+		vals[i] = uint32(enc.stringID(t.Value))
+	}
+
+	return keys, vals
+}
+
+// stringID returns the index of the string in the string table
+// of the current block, adding it if necessary.
+func (enc *dataEncoder) stringID(s string) int32 {
+	if s == "" {
+		return 0
+	}
+
+	if id, ok := enc.strings[s]; ok {
+		return id
+	}
+
+	id := int32(len(enc.table))
+	enc.strings[s] = id
+	enc.table = append(enc.table, s)
+
+	return id
+}
+
+// toGranularity converts degrees to the default granularity of 100 nanodegrees.
+func toGranularity(v float64) int64 {
+	return int64(math.Round(v * 1e7))
+}
+
+func toNanodegrees(v float64) int64 {
+	return int64(math.Round(v * 1e9))
+}
+
+// toTimestamp converts the time to the default date granularity of seconds.
+// The timestamp can't be omitted for a single element so the zero time is
+// written as 0, it is decoded back to the zero time.
+func toTimestamp(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
@@ -0,0 +1,203 @@
+package osmpbf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmtest"
+)
+
+func TestEncoder(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := New(context.Background(), f, 2)
+	defer scanner.Close()
+
+	var expected osm.Objects
+	for scanner.Scan() {
+		expected = append(expected, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	for _, c := range []Compression{CompressionZlib, CompressionNone, CompressionZstd, CompressionLZ4} {
+		buf := &bytes.Buffer{}
+		enc := NewEncoder(context.Background(), buf, 3)
+		enc.Compression = c
+		for _, o := range expected {
+			if err := enc.Encode(o); err != nil {
+				t.Fatalf("encode error: %v", err)
+			}
+		}
+
+		if err := enc.Close(); err != nil {
+			t.Fatalf("close error: %v", err)
+		}
+
+		scanner := New(context.Background(), buf, 2)
+		i := 0
+		for scanner.Scan() {
+			if i >= len(expected) {
+				t.Fatalf("too many objects")
+			}
+
+			if o := scanner.Object(); !reflect.DeepEqual(o, expected[i]) {
+				t.Fatalf("%d: objects not equal:\n%+v\n%+v", c, o, expected[i])
+			}
+			i++
+		}
+
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("scanner error: %v", err)
+		}
+		scanner.Close()
+
+		if i != len(expected) {
+			t.Errorf("incorrect number of objects: %v != %v", i, len(expected))
+		}
+	}
+}
+
+func TestEncoder_header(t *testing.T) {
+	header := Header{
+		Bounds: &osm.Bounds{
+			MinLat: 38.5, MaxLat: 40,
+			MinLon: -76, MaxLon: -75.25,
+		},
+		RequiredFeatures:     []string{"OsmSchema-V0.6", "DenseNodes", "HistoricalInformation"},
+		OptionalFeatures:     []string{"LocationsOnWays"},
+		WritingProgram:       "osmpbf test",
+		ReplicationTimestamp: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		ReplicationSeqNum:    123,
+		ReplicationBaseURL:   "https://planet.osm.org/replication/minute",
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(context.Background(), buf, 1)
+	enc.Header = header
+	enc.BlockSize = 1
+
+	objects := osm.Objects{
+		&osm.Node{ID: 1, Lat: 1.5, Lon: -2.5, Version: 2, Visible: false},
+		&osm.Node{ID: 2, Lat: 3, Lon: 4, Version: 1, Visible: true, Tags: osm.Tags{{Key: "k", Value: "v"}}},
+		&osm.Way{ID: 3, Version: 1, Visible: true, Nodes: osm.WayNodes{
+			{ID: 2, Lat: 3, Lon: 4},
+			{ID: 1, Lat: 1.5, Lon: -2.5},
+		}},
+		&osm.Relation{ID: 4, Version: 1, Visible: true, Members: osm.Members{
+			{Type: osm.TypeWay, Ref: 3, Role: "outer"},
+			{Type: osm.TypeNode, Ref: 1},
+		}},
+	}
+
+	if err := enc.Encode(&osm.Bounds{}); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	for _, o := range objects {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	if err := enc.Encode(objects[0]); err != ErrEncoderClosed {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	scanner := New(context.Background(), buf, 1)
+	defer scanner.Close()
+
+	h, err := scanner.Header()
+	if err != nil {
+		t.Fatalf("header error: %v", err)
+	}
+
+	if !reflect.DeepEqual(*h, header) {
+		t.Errorf("incorrect header:\n%+v\n%+v", *h, header)
+	}
+
+	var scanned osm.Objects
+	for scanner.Scan() {
+		scanned = append(scanned, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	if len(scanned) != len(objects) {
+		t.Fatalf("incorrect number of objects: %v", len(scanned))
+	}
+
+	for i := range objects {
+		if !reflect.DeepEqual(scanned[i], objects[i]) {
+			t.Errorf("objects not equal:\n%+v\n%+v", scanned[i], objects[i])
+		}
+	}
+}
+
+func TestEncodeFileBlock_tooLarge(t *testing.T) {
+	// compresses to well under the max blob size
+	data := make([]byte, maxBlobSize+1)
+	for _, c := range []Compression{CompressionZlib, CompressionZstd} {
+		if _, err := encodeFileBlock(osmDataType, data, c); err == nil {
+			t.Errorf("%v: should return error for raw data larger than the max blob size", c)
+		}
+	}
+
+	if _, err := encodeFileBlock(osmDataType, data[:maxBlobSize/2], CompressionZlib); err != nil {
+		t.Errorf("encode error: %v", err)
+	}
+}
+
+func TestEncoder_skipped(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	objects := osm.Objects{
+		&osm.Changeset{ID: 1},
+		&osm.Note{ID: 2},
+		&osm.User{ID: 3},
+		&osm.Node{ID: 4, Lat: 1, Lon: 2, Version: 1, Visible: true, Timestamp: ts},
+		&osm.Node{ID: 5, Lat: 3, Lon: 4, Version: 1, Visible: true},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(context.Background(), buf, 1)
+	if err := enc.EncodeScanner(osmtest.NewScanner(objects)); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	scanner := New(context.Background(), buf, 1)
+	defer scanner.Close()
+
+	var scanned osm.Objects
+	for scanner.Scan() {
+		scanned = append(scanned, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	if !reflect.DeepEqual(scanned, objects[3:]) {
+		t.Errorf("incorrect objects:\n%+v\n%+v", scanned, objects[3:])
+	}
+}
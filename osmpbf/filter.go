@@ -0,0 +1,195 @@
+package osmpbf
+
+import (
+	"github.com/ich5003/small-osm"
+	"github.com/paulmach/protoscan"
+)
+
+// Filter defines the elements that will be returned by the Scanner.
+// Elements must match all the conditions that are set. It is evaluated
+// in the decoding goroutines and the tags are matched using the string
+// table of the block, so non-matching elements are not fully decoded.
+type Filter struct {
+	// Tags the element must have at least one of. A tag with an empty
+	// value matches any value of the key, e.g. {Key: "highway"} will
+	// match all the highways.
+	Tags osm.Tags
+
+	// MinID and MaxID limit the element ids, inclusive.
+	// A zero value is unbounded.
+	MinID int64
+	MaxID int64
+
+	// Bounds limit the nodes to those within. Ways and relations are
+	// not affected since their location is not known while decoding.
+	Bounds *osm.Bounds
+}
+
+func (f *Filter) matchID(id int64) bool {
+	if f.MinID != 0 && id < f.MinID {
+		return false
+	}
+
+	if f.MaxID != 0 && id > f.MaxID {
+		return false
+	}
+
+	return true
+}
+
+func (f *Filter) matchLocation(n *osm.Node) bool {
+	return f.Bounds == nil || f.Bounds.ContainsNode(n)
+}
+
+// filterTag is a filter tag resolved to the string table of a block.
+// It can only match if the key, and value if needed, are in the block.
+type filterTag struct {
+	key        uint32
+	value      uint32
+	anyValue   bool
+	keyFound   bool
+	valueFound bool
+}
+
+func (ft *filterTag) match(k, v uint32) bool {
+	return ft.keyFound && ft.key == k &&
+		(ft.anyValue || (ft.valueFound && ft.value == v))
+}
+
+// prepareFilter resolves the filter tags to the string table of the current block.
+func (dec *dataDecoder) prepareFilter() {
+	f := dec.scanner.Filter
+	if f == nil || len(f.Tags) == 0 {
+		return
+	}
+
+	dec.filterTags = dec.filterTags[:0]
+	for _, t := range f.Tags {
+		dec.filterTags = append(dec.filterTags, filterTag{anyValue: t.Value == ""})
+	}
+
+	for i, s := range dec.primitiveBlock.GetStringtable().GetS() {
+		if i == 0 {
+			continue // the delimiter
+		}
+
+		for j, t := range f.Tags {
+			ft := &dec.filterTags[j]
+			if s == t.Key {
+				ft.key, ft.keyFound = uint32(i), true
+			}
+
+			if s == t.Value {
+				ft.value, ft.valueFound = uint32(i), true
+			}
+		}
+	}
+}
+
+// matchTags checks the string table indexes of the element tags
+// against the filter tags.
+func (dec *dataDecoder) matchTags(keys, vals []uint32) bool {
+	f := dec.scanner.Filter
+	if f == nil || len(f.Tags) == 0 {
+		return true
+	}
+
+	for i, k := range keys {
+		if i >= len(vals) {
+			break
+		}
+
+		for j := range dec.filterTags {
+			if dec.filterTags[j].match(k, vals[i]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchElement checks the id and tags of an encoded way or relation
+// against the filter before it is fully decoded.
+func (dec *dataDecoder) matchElement(data []byte) (bool, error) {
+	var (
+		id  int64
+		err error
+	)
+
+	keys, vals := dec.filterKeys[:0], dec.filterVals[:0]
+
+	msg := protoscan.New(data)
+	for msg.Next() {
+		switch msg.FieldNumber() {
+		case 1:
+			id, err = msg.Int64()
+		case 2:
+			keys, err = msg.RepeatedUint32(keys)
+		case 3:
+			vals, err = msg.RepeatedUint32(vals)
+		default:
+			msg.Skip()
+		}
+
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if msg.Err() != nil {
+		return false, msg.Err()
+	}
+
+	dec.filterKeys, dec.filterVals = keys, vals
+	return dec.scanner.Filter.matchID(id) && dec.matchTags(keys, vals), nil
+}
+
+// matchDenseNode reads the tags of the current dense node into the
+// tag buffers and checks the node against the filter.
+func (dec *dataDecoder) matchDenseNode(n *osm.Node) (bool, error) {
+	keys, vals := dec.filterKeys[:0], dec.filterVals[:0]
+
+	// tags, could be missing if all nodes are tagless
+	if dec.keyvals != nil {
+		for {
+			k, err := dec.keyvals.Uint32()
+			if err != nil {
+				return false, err
+			}
+
+			if k == 0 {
+				break
+			}
+
+			v, err := dec.keyvals.Uint32()
+			if err != nil {
+				return false, err
+			}
+
+			keys = append(keys, k)
+			vals = append(vals, v)
+		}
+	}
+
+	dec.filterKeys, dec.filterVals = keys, vals
+
+	f := dec.scanner.Filter
+	return f.matchID(int64(n.ID)) &&
+		f.matchLocation(n) &&
+		dec.matchTags(keys, vals), nil
+}
+
+// denseTags creates the tags from the string table indexes.
+func denseTags(st []string, keys, vals []uint32) osm.Tags {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	tags := make(osm.Tags, len(keys))
+	for i := range keys {
+		tags[i] = osm.Tag{Key: st[keys[i]], Value: st[vals[i]]}
+	}
+
+	return tags
+}
@@ -0,0 +1,427 @@
+package osmpbf
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ich5003/small-osm"
+	"github.com/paulmach/protoscan"
+)
+
+// indexMagic is the start of an encoded index, followed by the version.
+const (
+	indexMagic   = "osmpbfix"
+	indexVersion = 1
+)
+
+// ErrInvalidIndex is returned when reading an index that was
+// not written by Index.WriteTo.
+var ErrInvalidIndex = errors.New("osmpbf: invalid index")
+
+// Index is a list of the data blocks in a pbf file with the type and
+// id range of the elements they contain. It can be used to read only the
+// blocks needed for a set of elements, see Lookup and NewBlockScanner.
+type Index struct {
+	// Blocks are in file order. A block with elements of more than
+	// one type will have an entry for each type.
+	Blocks []IndexBlock
+}
+
+// IndexBlock is the location of a data block and the range of
+// the elements it contains of a single type.
+type IndexBlock struct {
+	// Offset and Size of the complete fileblock in the file,
+	// including the blob header size prefix.
+	Offset int64
+	Size   int64
+
+	Type  osm.Type
+	MinID int64
+	MaxID int64
+	Count int
+}
+
+// BuildIndex reads the pbf file and returns an index of its data blocks.
+// Each block still needs to be decompressed but only the element ids are
+// decoded. procs indicates the amount of parallelism.
+func BuildIndex(ctx context.Context, r io.Reader, procs int) (*Index, error) {
+	info, err := Inspect(ctx, r, procs)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &Index{}
+	for _, b := range info.Blocks {
+		idx.Blocks = append(idx.Blocks, b.Elements...)
+	}
+
+	return idx, nil
+}
+
+// indexPrimitiveBlock returns the blocks entries, without the offset and size,
+// for the decompressed PrimitiveBlock data.
+func indexPrimitiveBlock(data []byte) ([]IndexBlock, error) {
+	blocks := [3]IndexBlock{
+		{Type: osm.TypeNode},
+		{Type: osm.TypeWay},
+		{Type: osm.TypeRelation},
+	}
+
+	msg := protoscan.New(data)
+	for msg.Next() {
+		if msg.FieldNumber() != 2 {
+			msg.Skip()
+			continue
+		}
+
+		group, err := msg.Message()
+		if err != nil {
+			return nil, err
+		}
+
+		for group.Next() {
+			var err error
+			switch group.FieldNumber() {
+			case 1: // nodes
+				err = indexElement(group, &blocks[0], true)
+			case 2: // dense nodes
+				err = indexDenseNodes(group, &blocks[0])
+			case 3: // ways
+				err = indexElement(group, &blocks[1], false)
+			case 4: // relations
+				err = indexElement(group, &blocks[2], false)
+			default:
+				group.Skip()
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if group.Err() != nil {
+			return nil, group.Err()
+		}
+	}
+
+	if msg.Err() != nil {
+		return nil, msg.Err()
+	}
+
+	var result []IndexBlock
+	for _, b := range blocks {
+		if b.Count > 0 {
+			result = append(result, b)
+		}
+	}
+
+	return result, nil
+}
+
+// indexElement reads the id, the first field, of a node, way or relation.
+// Node ids are sint64, ways and relations are int64.
+func indexElement(msg *protoscan.Message, b *IndexBlock, zigzag bool) error {
+	element, err := msg.Message()
+	if err != nil {
+		return err
+	}
+
+	for element.Next() {
+		if element.FieldNumber() != 1 {
+			element.Skip()
+			continue
+		}
+
+		var id int64
+		if zigzag {
+			id, err = element.Sint64()
+		} else {
+			id, err = element.Int64()
+		}
+
+		if err != nil {
+			return err
+		}
+
+		b.add(id)
+		return nil
+	}
+
+	if element.Err() != nil {
+		return element.Err()
+	}
+
+	return errors.New("element does not include an id")
+}
+
+func indexDenseNodes(msg *protoscan.Message, b *IndexBlock) error {
+	dense, err := msg.Message()
+	if err != nil {
+		return err
+	}
+
+	for dense.Next() {
+		if dense.FieldNumber() != 1 {
+			dense.Skip()
+			continue
+		}
+
+		ids, err := dense.Iterator(nil)
+		if err != nil {
+			return err
+		}
+
+		var id int64
+		for ids.HasNext() {
+			v, err := ids.Sint64()
+			if err != nil {
+				return err
+			}
+
+			id += v
+			b.add(id)
+		}
+	}
+
+	return dense.Err()
+}
+
+func (b *IndexBlock) add(id int64) {
+	if b.Count == 0 || id < b.MinID {
+		b.MinID = id
+	}
+
+	if b.Count == 0 || id > b.MaxID {
+		b.MaxID = id
+	}
+
+	b.Count++
+}
+
+// Find returns the blocks that may contain the feature.
+func (idx *Index) Find(id osm.FeatureID) []IndexBlock {
+	t, ref := id.Type(), id.Ref()
+
+	var result []IndexBlock
+	for _, b := range idx.Blocks {
+		if b.Type == t && b.MinID <= ref && ref <= b.MaxID {
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
+
+// BlocksOfType returns the blocks that contain elements of the given type.
+func (idx *Index) BlocksOfType(t osm.Type) []IndexBlock {
+	var result []IndexBlock
+	for _, b := range idx.Blocks {
+		if b.Type == t {
+			result = append(result, b)
+		}
+	}
+
+	return result
+}
+
+// Lookup returns all the versions of the feature found in the file.
+// Only the blocks that may contain the feature are read.
+func (idx *Index) Lookup(ctx context.Context, r io.ReaderAt, id osm.FeatureID) (osm.Objects, error) {
+	blocks := idx.Find(id)
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+
+	s := NewBlockScanner(ctx, r, blocks, 1)
+	defer s.Close()
+
+	t := id.Type()
+	s.SkipNodes = t != osm.TypeNode
+	s.SkipWays = t != osm.TypeWay
+	s.SkipRelations = t != osm.TypeRelation
+
+	var result osm.Objects
+	for s.Scan() {
+		o := s.Object()
+		if o.ObjectID().Ref() == id.Ref() {
+			result = append(result, o)
+		}
+	}
+
+	return result, s.Err()
+}
+
+// NewBlockScanner returns a Scanner that reads only the given blocks of the file.
+// The blocks are read in file order and blocks with multiple entries are only read once.
+// Elements of other types in the blocks are returned unless skipped on the scanner.
+// The scanner does not have a header and FullyScannedBytes is relative
+// to the first block.
+func NewBlockScanner(ctx context.Context, r io.ReaderAt, blocks []IndexBlock, procs int) *Scanner {
+	sorted := make([]IndexBlock, len(blocks))
+	copy(sorted, blocks)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Offset < sorted[j].Offset
+	})
+
+	readers := make([]io.Reader, 0, len(sorted))
+	for i, b := range sorted {
+		if i > 0 && sorted[i-1].Offset == b.Offset {
+			continue
+		}
+
+		readers = append(readers, io.NewSectionReader(r, b.Offset, b.Size))
+	}
+
+	return New(ctx, io.MultiReader(readers...), procs)
+}
+
+var indexTypes = []osm.Type{osm.TypeNode, osm.TypeWay, osm.TypeRelation}
+
+// WriteTo writes the index in a compact binary format. It can be read back
+// using ReadIndex and is meant to be stored next to the pbf file.
+func (idx *Index) WriteTo(w io.Writer) (int64, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteString(indexMagic)
+
+	scratch := make([]byte, binary.MaxVarintLen64)
+	uvarint := func(v uint64) {
+		buf.Write(scratch[:binary.PutUvarint(scratch, v)])
+	}
+
+	uvarint(indexVersion)
+	uvarint(uint64(len(idx.Blocks)))
+
+	var offset int64
+	for _, b := range idx.Blocks {
+		if b.Offset < offset {
+			return 0, errors.New("osmpbf: index blocks must be sorted by offset")
+		}
+
+		t := typeIndex(b.Type)
+		if t < 0 {
+			return 0, fmt.Errorf("osmpbf: invalid index block type: %v", b.Type)
+		}
+
+		uvarint(uint64(b.Offset - offset))
+		uvarint(uint64(b.Size))
+		buf.WriteByte(byte(t))
+		buf.Write(scratch[:binary.PutVarint(scratch, b.MinID)])
+		uvarint(uint64(b.MaxID - b.MinID))
+		uvarint(uint64(b.Count))
+
+		offset = b.Offset
+	}
+
+	return buf.WriteTo(w)
+}
+
+// ReadIndex reads an index written by Index.WriteTo.
+func ReadIndex(r io.Reader) (*Index, error) {
+	ir := &indexReader{r: bufio.NewReader(r)}
+
+	magic := make([]byte, len(indexMagic))
+	if _, err := io.ReadFull(ir.r, magic); err != nil || string(magic) != indexMagic {
+		return nil, ErrInvalidIndex
+	}
+
+	if version := ir.uvarint(); ir.err == nil && version != indexVersion {
+		return nil, fmt.Errorf("osmpbf: unsupported index version %d", version)
+	}
+
+	n := ir.uvarint()
+	if ir.err != nil {
+		return nil, ir.err
+	}
+
+	idx := &Index{}
+	var offset int64
+	for i := uint64(0); i < n; i++ {
+		offset += int64(ir.uvarint())
+		b := IndexBlock{
+			Offset: offset,
+			Size:   int64(ir.uvarint()),
+		}
+
+		t := ir.byte()
+		b.MinID = ir.varint()
+		b.MaxID = b.MinID + int64(ir.uvarint())
+		b.Count = int(ir.uvarint())
+
+		if ir.err != nil {
+			return nil, ir.err
+		}
+
+		if int(t) >= len(indexTypes) {
+			return nil, ErrInvalidIndex
+		}
+		b.Type = indexTypes[t]
+
+		idx.Blocks = append(idx.Blocks, b)
+	}
+
+	return idx, nil
+}
+
+// indexReader reads the index values keeping the first error.
+type indexReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (ir *indexReader) uvarint() uint64 {
+	if ir.err != nil {
+		return 0
+	}
+
+	var v uint64
+	v, ir.err = binary.ReadUvarint(ir.r)
+	if ir.err == io.EOF {
+		ir.err = io.ErrUnexpectedEOF
+	}
+
+	return v
+}
+
+func (ir *indexReader) varint() int64 {
+	if ir.err != nil {
+		return 0
+	}
+
+	var v int64
+	v, ir.err = binary.ReadVarint(ir.r)
+	if ir.err == io.EOF {
+		ir.err = io.ErrUnexpectedEOF
+	}
+
+	return v
+}
+
+func (ir *indexReader) byte() byte {
+	if ir.err != nil {
+		return 0
+	}
+
+	var v byte
+	v, ir.err = ir.r.ReadByte()
+	if ir.err == io.EOF {
+		ir.err = io.ErrUnexpectedEOF
+	}
+
+	return v
+}
+
+func typeIndex(t osm.Type) int {
+	for i, it := range indexTypes {
+		if it == t {
+			return i
+		}
+	}
+
+	return -1
+}
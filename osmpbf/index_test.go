@@ -0,0 +1,213 @@
+package osmpbf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestBuildIndex(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	idx, err := BuildIndex(context.Background(), f, 3)
+	if err != nil {
+		t.Fatalf("build index error: %v", err)
+	}
+
+	// compare with a full scan
+	f.Seek(0, 0)
+	scanner := New(context.Background(), f, 2)
+	defer scanner.Close()
+
+	counts := map[osm.Type]int{}
+	for scanner.Scan() {
+		counts[scanner.Object().ObjectID().Type()]++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	indexed := map[osm.Type]int{}
+	for i, b := range idx.Blocks {
+		indexed[b.Type] += b.Count
+
+		if b.MinID > b.MaxID {
+			t.Errorf("invalid id range: %+v", b)
+		}
+
+		if i > 0 && b.Offset < idx.Blocks[i-1].Offset {
+			t.Errorf("blocks not in file order: %+v", b)
+		}
+	}
+
+	if !reflect.DeepEqual(counts, indexed) {
+		t.Errorf("incorrect counts: %v != %v", indexed, counts)
+	}
+
+	if l := len(idx.BlocksOfType(osm.TypeRelation)); l != 1 {
+		t.Errorf("incorrect number of relation blocks: %v", l)
+	}
+}
+
+func TestIndex_Lookup(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	idx, err := BuildIndex(context.Background(), f, 1)
+	if err != nil {
+		t.Fatalf("build index error: %v", err)
+	}
+
+	// pick some elements from the middle of blocks
+	f.Seek(0, 0)
+	scanner := New(context.Background(), f, 1)
+	defer scanner.Close()
+
+	var expected osm.Objects
+	for i := 0; scanner.Scan(); i++ {
+		if i%50000 == 1234 {
+			expected = append(expected, scanner.Object())
+		}
+	}
+
+	if len(expected) < 3 {
+		t.Fatalf("not enough elements: %v", len(expected))
+	}
+
+	for _, e := range expected {
+		id := e.ObjectID()
+		fid, _ := id.Type().FeatureID(id.Ref())
+
+		if l := len(idx.Find(fid)); l != 1 {
+			t.Errorf("should find one block for %v: %v", fid, l)
+		}
+
+		objects, err := idx.Lookup(context.Background(), f, fid)
+		if err != nil {
+			t.Fatalf("lookup error: %v", err)
+		}
+
+		if len(objects) != 1 || !reflect.DeepEqual(objects[0], e) {
+			t.Errorf("incorrect lookup for %v: %v", fid, objects)
+		}
+	}
+
+	// not in the file
+	objects, err := idx.Lookup(context.Background(), f, osm.NodeID(1).FeatureID())
+	if err != nil {
+		t.Fatalf("lookup error: %v", err)
+	}
+
+	if len(objects) != 0 {
+		t.Errorf("should not find anything: %v", objects)
+	}
+}
+
+func TestNewBlockScanner(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	idx, err := BuildIndex(context.Background(), f, 2)
+	if err != nil {
+		t.Fatalf("build index error: %v", err)
+	}
+
+	blocks := idx.BlocksOfType(osm.TypeRelation)
+	scanner := NewBlockScanner(context.Background(), f, blocks, 2)
+	defer scanner.Close()
+
+	count := 0
+	for scanner.Scan() {
+		if _, ok := scanner.Object().(*osm.Relation); ok {
+			count++
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	expected := 0
+	for _, b := range blocks {
+		expected += b.Count
+	}
+
+	if count != expected {
+		t.Errorf("incorrect number of relations: %v != %v", count, expected)
+	}
+
+	// no blocks
+	scanner = NewBlockScanner(context.Background(), f, nil, 1)
+	if scanner.Scan() {
+		t.Errorf("should not scan anything")
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Errorf("should not return error: %v", err)
+	}
+	scanner.Close()
+}
+
+func TestIndex_WriteTo(t *testing.T) {
+	idx := &Index{
+		Blocks: []IndexBlock{
+			{Offset: 10, Size: 100, Type: osm.TypeNode, MinID: -5, MaxID: 20, Count: 8},
+			{Offset: 110, Size: 50, Type: osm.TypeNode, MinID: 21, MaxID: 30, Count: 2},
+			{Offset: 110, Size: 50, Type: osm.TypeWay, MinID: 1, MaxID: 1, Count: 1},
+			{Offset: 160, Size: 70, Type: osm.TypeRelation, MinID: 1 << 40, MaxID: 1<<40 + 5, Count: 4},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	n, err := idx.WriteTo(buf)
+	if err != nil {
+		t.Fatalf("write error: %v", err)
+	}
+
+	if int(n) != buf.Len() {
+		t.Errorf("incorrect bytes written: %v != %v", n, buf.Len())
+	}
+
+	data := buf.Bytes()
+	read, err := ReadIndex(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("read error: %v", err)
+	}
+
+	if !reflect.DeepEqual(read, idx) {
+		t.Errorf("incorrect index:\n%+v\n%+v", read, idx)
+	}
+
+	// truncated
+	_, err = ReadIndex(bytes.NewReader(data[:len(data)-2]))
+	if err == nil {
+		t.Errorf("should return error for truncated index")
+	}
+
+	// not an index
+	_, err = ReadIndex(bytes.NewReader([]byte("something else")))
+	if err != ErrInvalidIndex {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	// unsorted
+	idx.Blocks[0], idx.Blocks[1] = idx.Blocks[1], idx.Blocks[0]
+	if _, err := idx.WriteTo(&bytes.Buffer{}); err == nil {
+		t.Errorf("should return error for unsorted blocks")
+	}
+}
@@ -0,0 +1,231 @@
+package osmpbf
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/ich5003/small-osm"
+	"github.com/ich5003/small-osm/osmpbf/internal/osmpbf"
+)
+
+// FileInfo is the layout of a pbf file as returned by Inspect.
+type FileInfo struct {
+	// Header is nil if the file does not start with a header block.
+	// The required features are not checked, see Header.RequiredFeatures.
+	Header *Header
+
+	// Blocks are all the fileblocks, including the header, in file order.
+	Blocks []BlockInfo
+}
+
+// BlockInfo is the location, size and contents of a fileblock.
+type BlockInfo struct {
+	// Offset and Size of the complete fileblock in the file,
+	// including the blob header size prefix.
+	Offset int64
+	Size   int64
+
+	// Type is the fileblock type, OSMHeader or OSMData.
+	Type string
+
+	Compression    Compression
+	CompressedSize int
+	RawSize        int
+
+	// Elements are the id range and count of each element type
+	// in an OSMData block. They are the Index entries of the block.
+	Elements []IndexBlock
+}
+
+// Count returns the number of elements of the type in the block.
+func (b BlockInfo) Count(t osm.Type) int {
+	for _, e := range b.Elements {
+		if e.Type == t {
+			return e.Count
+		}
+	}
+
+	return 0
+}
+
+// Count returns the number of elements of the type in the file.
+func (fi *FileInfo) Count(t osm.Type) int {
+	var count int
+	for _, b := range fi.Blocks {
+		count += b.Count(t)
+	}
+
+	return count
+}
+
+// Inspect reads the layout of the pbf file: the header and the offset, sizes
+// and element counts of every fileblock. The data blocks still need to be
+// decompressed but only the element ids are decoded, no objects are created.
+// procs indicates the amount of parallelism.
+func Inspect(ctx context.Context, r io.Reader, procs int) (*FileInfo, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if procs < 1 {
+		procs = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type job struct {
+		seq  int
+		blob *osmpbf.Blob
+	}
+
+	type result struct {
+		seq      int
+		elements []IndexBlock
+		err      error
+	}
+
+	jobs := make(chan job, procs)
+	results := make(chan result, procs)
+
+	var wg sync.WaitGroup
+	wg.Add(procs)
+	for i := 0; i < procs; i++ {
+		go func() {
+			defer wg.Done()
+
+			var data []byte
+			for j := range jobs {
+				var (
+					elements []IndexBlock
+					err      error
+				)
+
+				data, err = getData(j.blob, data)
+				if err == nil {
+					elements, err = indexPrimitiveBlock(data)
+				}
+
+				select {
+				case results <- result{seq: j.seq, elements: elements, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	info := &FileInfo{}
+
+	var readErr error
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		defer close(jobs)
+
+		sizeBuf := make([]byte, 4)
+		headerBuf := make([]byte, maxBlobHeaderSize)
+		blobBuf := make([]byte, maxBlobSize)
+
+		dec := &decoder{r: r}
+		for seq := 0; ; seq++ {
+			offset := dec.bytesRead
+			blobHeader, blob, err := dec.readFileBlock(sizeBuf, headerBuf, blobBuf)
+			if err == io.EOF {
+				return
+			}
+
+			if err == nil && seq == 0 && blobHeader.GetType() == osmHeaderType {
+				info.Header, err = readOSMHeader(blob)
+			}
+
+			if err != nil {
+				readErr = err
+				cancel()
+				return
+			}
+
+			b := BlockInfo{
+				Offset:  offset,
+				Size:    dec.bytesRead - offset,
+				Type:    blobHeader.GetType(),
+				RawSize: int(blob.GetRawSize()),
+			}
+
+			b.Compression, b.CompressedSize = blobCompression(blob)
+			if b.Compression == CompressionNone {
+				// raw_size is only required for compressed data
+				b.RawSize = b.CompressedSize
+			}
+			info.Blocks = append(info.Blocks, b)
+
+			if blobHeader.GetType() != osmDataType {
+				continue
+			}
+
+			select {
+			case jobs <- job{seq: seq, blob: blob}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var err error
+	bySeq := make(map[int][]IndexBlock)
+	for r := range results {
+		if r.err != nil && err == nil {
+			err = r.err
+			cancel()
+		}
+
+		bySeq[r.seq] = r.elements
+	}
+	<-readDone
+
+	if err == nil {
+		err = readErr
+	}
+
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for seq := range info.Blocks {
+		b := &info.Blocks[seq]
+
+		b.Elements = bySeq[seq]
+		for i := range b.Elements {
+			b.Elements[i].Offset = b.Offset
+			b.Elements[i].Size = b.Size
+		}
+	}
+
+	return info, nil
+}
+
+// blobCompression returns the compression and size of the compressed data.
+func blobCompression(blob *osmpbf.Blob) (Compression, int) {
+	switch {
+	case blob.ZlibData != nil:
+		return CompressionZlib, len(blob.ZlibData)
+	case blob.ZstdData != nil:
+		return CompressionZstd, len(blob.ZstdData)
+	case blob.Lz4Data != nil:
+		return CompressionLZ4, len(blob.Lz4Data)
+	case blob.LzmaData != nil:
+		return CompressionLZMA, len(blob.LzmaData)
+	}
+
+	return CompressionNone, len(blob.Raw)
+}
@@ -0,0 +1,109 @@
+package osmpbf
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestInspect(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	info, err := Inspect(context.Background(), f, 3)
+	if err != nil {
+		t.Fatalf("inspect error: %v", err)
+	}
+
+	if info.Header == nil || info.Header.WritingProgram == "" {
+		t.Errorf("should read the header: %+v", info.Header)
+	}
+
+	if b := info.Blocks[0]; b.Type != osmHeaderType || b.Offset != 0 || len(b.Elements) != 0 {
+		t.Errorf("first block should be the header: %+v", b)
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		t.Fatalf("stat error: %v", err)
+	}
+
+	var offset int64
+	for _, b := range info.Blocks {
+		if b.Offset != offset {
+			t.Fatalf("incorrect offset: %v != %v", b.Offset, offset)
+		}
+		offset += b.Size
+
+		if b.Compression != CompressionZlib {
+			t.Errorf("incorrect compression: %v", b.Compression)
+		}
+
+		if b.CompressedSize <= 0 || int64(b.CompressedSize) >= b.Size || b.RawSize <= 0 {
+			t.Errorf("incorrect sizes: %+v", b)
+		}
+	}
+
+	if offset != stat.Size() {
+		t.Errorf("blocks should cover the file: %v != %v", offset, stat.Size())
+	}
+
+	// compare with a full scan
+	f.Seek(0, 0)
+	scanner := New(context.Background(), f, 2)
+	defer scanner.Close()
+
+	counts := map[osm.Type]int{}
+	for scanner.Scan() {
+		counts[scanner.Object().ObjectID().Type()]++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	for _, ot := range []osm.Type{osm.TypeNode, osm.TypeWay, osm.TypeRelation} {
+		if c := info.Count(ot); c != counts[ot] {
+			t.Errorf("incorrect %v count: %v != %v", ot, c, counts[ot])
+		}
+	}
+}
+
+func TestInspect_compression(t *testing.T) {
+	for _, c := range []Compression{CompressionNone, CompressionZstd, CompressionLZ4} {
+		buf := &bytes.Buffer{}
+		enc := NewEncoder(context.Background(), buf, 1)
+		enc.Compression = c
+		enc.Encode(&osm.Node{ID: 1, Visible: true})
+		enc.Encode(&osm.Way{ID: 2, Visible: true})
+
+		if err := enc.Close(); err != nil {
+			t.Fatalf("close error: %v", err)
+		}
+
+		info, err := Inspect(context.Background(), buf, 1)
+		if err != nil {
+			t.Fatalf("inspect error: %v", err)
+		}
+
+		for _, b := range info.Blocks[1:] {
+			if b.Compression != c && b.Compression != CompressionNone {
+				t.Errorf("%v: incorrect compression: %v", c, b.Compression)
+			}
+
+			if b.RawSize <= 0 {
+				t.Errorf("%v: raw size not set: %+v", c, b)
+			}
+		}
+
+		if n, w := info.Count(osm.TypeNode), info.Count(osm.TypeWay); n != 1 || w != 1 {
+			t.Errorf("%v: incorrect counts: %v %v", c, n, w)
+		}
+	}
+}
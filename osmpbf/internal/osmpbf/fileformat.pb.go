@@ -32,6 +32,10 @@ type Blob struct {
 	LzmaData []byte `protobuf:"bytes,4,opt,name=lzma_data,json=lzmaData" json:"lzma_data"`
 	// Formerly used for bzip2 compressed data. Depreciated in 2010.
 	OBSOLETEBzip2Data []byte `protobuf:"bytes,5,opt,name=OBSOLETE_bzip2_data,json=OBSOLETEBzip2Data" json:"OBSOLETE_bzip2_data"` // Deprecated: Do not use.
+	// PROPOSED feature for LZ4 compressed data. SUPPORT IS NOT REQUIRED.
+	Lz4Data []byte `protobuf:"bytes,6,opt,name=lz4_data,json=lz4Data" json:"lz4_data"`
+	// PROPOSED feature for ZSTD compressed data. SUPPORT IS NOT REQUIRED.
+	ZstdData []byte `protobuf:"bytes,7,opt,name=zstd_data,json=zstdData" json:"zstd_data"`
 }
 
 func (m *Blob) Reset()         { *m = Blob{} }
@@ -103,6 +107,20 @@ func (m *Blob) GetOBSOLETEBzip2Data() []byte {
 	return nil
 }
 
+func (m *Blob) GetLz4Data() []byte {
+	if m != nil {
+		return m.Lz4Data
+	}
+	return nil
+}
+
+func (m *Blob) GetZstdData() []byte {
+	if m != nil {
+		return m.ZstdData
+	}
+	return nil
+}
+
 type BlobHeader struct {
 	Type      string `protobuf:"bytes,1,req,name=type" json:"type"`
 	Indexdata []byte `protobuf:"bytes,2,opt,name=indexdata" json:"indexdata"`
@@ -171,25 +189,27 @@ func init() {
 func init() { proto.RegisterFile("fileformat.proto", fileDescriptor_f8d315ffecccb459) }
 
 var fileDescriptor_f8d315ffecccb459 = []byte{
-	// 283 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0x8f, 0x41, 0x6b, 0xb3, 0x30,
-	0x1c, 0xc6, 0x8d, 0xda, 0xbe, 0xfa, 0xe7, 0x1d, 0x6c, 0x19, 0x8c, 0x9c, 0x52, 0xe7, 0x65, 0x9e,
-	0x7a, 0xd8, 0x61, 0x1f, 0x40, 0x56, 0xd8, 0x61, 0x50, 0x68, 0x77, 0x97, 0x64, 0x46, 0x08, 0x68,
-	0x23, 0x51, 0x70, 0xfa, 0x29, 0xf6, 0x85, 0x76, 0xef, 0xb1, 0xc7, 0x9d, 0xc6, 0xd0, 0x2f, 0x32,
-	0x62, 0xbb, 0xce, 0x53, 0xe0, 0x97, 0xdf, 0x93, 0x3c, 0x0f, 0x5c, 0x66, 0x32, 0x17, 0x99, 0xd2,
-	0x05, 0xab, 0x97, 0xa5, 0x56, 0xb5, 0xc2, 0x73, 0x55, 0x15, 0x25, 0xcf, 0xc2, 0x0f, 0x04, 0x6e,
-	0x9c, 0x2b, 0x8e, 0x6f, 0xc0, 0xd1, 0xac, 0x21, 0x28, 0x40, 0xd1, 0xff, 0xd8, 0xdd, 0x7f, 0x2d,
-	0xac, 0x8d, 0x01, 0x78, 0x01, 0x9e, 0x66, 0x4d, 0x52, 0xc9, 0x4e, 0x10, 0x3b, 0x40, 0xd1, 0xec,
-	0x74, 0xf9, 0x4f, 0xb3, 0x66, 0x2b, 0x3b, 0x81, 0x6f, 0xc1, 0xef, 0x72, 0xc9, 0x93, 0x94, 0xd5,
-	0x8c, 0x38, 0x93, 0xb8, 0x67, 0xf0, 0x23, 0xab, 0x99, 0x51, 0xf2, 0xae, 0x60, 0x47, 0xc5, 0x9d,
-	0x2a, 0x06, 0x8f, 0xca, 0x03, 0x5c, 0xaf, 0xe3, 0xed, 0xfa, 0x79, 0xf5, 0xb2, 0x4a, 0x78, 0x27,
-	0xcb, 0xfb, 0xa3, 0x3c, 0x1b, 0xe5, 0xb9, 0x91, 0x09, 0xda, 0x5c, 0xfd, 0x2a, 0xb1, 0x31, 0x4c,
-	0x2e, 0xcc, 0x01, 0x4c, 0xfd, 0x27, 0xc1, 0x52, 0xa1, 0x31, 0x01, 0xb7, 0x6e, 0x4b, 0x41, 0x50,
-	0x60, 0x47, 0xfe, 0xe9, 0x8f, 0x91, 0xe0, 0x10, 0x7c, 0xb9, 0x4b, 0xc5, 0xdb, 0xf8, 0xaa, 0x3d,
-	0xa9, 0xf0, 0x87, 0x71, 0x00, 0x9e, 0x39, 0xc7, 0xa9, 0x4e, 0x60, 0x9f, 0xa7, 0x9e, 0x69, 0x7c,
-	0xb7, 0xef, 0x29, 0x3a, 0xf4, 0x14, 0x7d, 0xf7, 0x14, 0xbd, 0x0f, 0xd4, 0x3a, 0x0c, 0xd4, 0xfa,
-	0x1c, 0xa8, 0x05, 0x17, 0xaf, 0x5a, 0x55, 0xbc, 0x5d, 0x72, 0xb9, 0x63, 0xba, 0xfd, 0x09, 0x00,
-	0x00, 0xff, 0xff, 0x83, 0x61, 0x1f, 0xdf, 0x71, 0x01, 0x00, 0x00,
+	// 305 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0xd0, 0xc1, 0x6a, 0xc2, 0x30,
+	0x18, 0x07, 0xf0, 0xa6, 0x56, 0xad, 0x1f, 0x1b, 0x6c, 0x19, 0x8c, 0x9e, 0x62, 0xe7, 0x65, 0x9e,
+	0x3c, 0x0c, 0xd9, 0x03, 0x94, 0x09, 0x3b, 0x0c, 0x04, 0xdd, 0x5d, 0x92, 0x35, 0x42, 0x20, 0x9a,
+	0x92, 0x06, 0x9c, 0xb9, 0xed, 0x0d, 0xf6, 0x58, 0x1e, 0x3d, 0xee, 0x34, 0x86, 0x7d, 0x91, 0x91,
+	0x54, 0x5d, 0x77, 0x0a, 0xfc, 0xf3, 0xfb, 0xbe, 0x84, 0x3f, 0x5c, 0x2d, 0x85, 0xe4, 0x4b, 0xa5,
+	0x57, 0xd4, 0x8c, 0x0a, 0xad, 0x8c, 0xc2, 0x1d, 0x55, 0xae, 0x0a, 0xb6, 0x1c, 0x7c, 0x84, 0x10,
+	0x65, 0x52, 0x31, 0x7c, 0x0b, 0x2d, 0x4d, 0x37, 0x09, 0x4a, 0xd1, 0xf0, 0x22, 0x8b, 0x76, 0xdf,
+	0xfd, 0x60, 0xe6, 0x02, 0xdc, 0x87, 0x58, 0xd3, 0xcd, 0xa2, 0x14, 0x96, 0x27, 0x61, 0x8a, 0x86,
+	0xed, 0xe3, 0x65, 0x57, 0xd3, 0xcd, 0x5c, 0x58, 0x8e, 0xef, 0xa0, 0x67, 0xa5, 0x60, 0x8b, 0x9c,
+	0x1a, 0x9a, 0xb4, 0x1a, 0xe3, 0xb1, 0x8b, 0x9f, 0xa8, 0xa1, 0x8e, 0x48, 0xbb, 0xa2, 0x35, 0x89,
+	0x9a, 0xc4, 0xc5, 0x9e, 0x3c, 0xc2, 0xcd, 0x34, 0x9b, 0x4f, 0x5f, 0x26, 0xaf, 0x93, 0x05, 0xb3,
+	0xa2, 0x78, 0xa8, 0x71, 0xdb, 0xe3, 0x8e, 0xc3, 0x09, 0x9a, 0x5d, 0x9f, 0x48, 0xe6, 0x84, 0x9f,
+	0xeb, 0x43, 0x2c, 0xed, 0xb8, 0xc6, 0x9d, 0xc6, 0xe6, 0xae, 0xb4, 0xe3, 0xd3, 0xdb, 0xb6, 0x34,
+	0x79, 0x2d, 0xba, 0xff, 0xbe, 0x57, 0x9a, 0xdc, 0x91, 0x81, 0x04, 0x70, 0x15, 0x3c, 0x73, 0x9a,
+	0x73, 0x8d, 0x13, 0x88, 0xcc, 0xb6, 0xe0, 0x09, 0x4a, 0xc3, 0x61, 0xef, 0x68, 0x7d, 0x82, 0x07,
+	0xd0, 0x13, 0xeb, 0x9c, 0xbf, 0xfb, 0x55, 0x61, 0x63, 0xd5, 0x5f, 0x8c, 0x53, 0x88, 0xdd, 0xe9,
+	0xeb, 0x6a, 0xa5, 0xe1, 0xb9, 0xae, 0x73, 0x9a, 0xdd, 0xef, 0x0e, 0x04, 0xed, 0x0f, 0x04, 0xfd,
+	0x1c, 0x08, 0xfa, 0xac, 0x48, 0xb0, 0xaf, 0x48, 0xf0, 0x55, 0x91, 0x00, 0x2e, 0xdf, 0xb4, 0x2a,
+	0xd9, 0x76, 0xc4, 0xc4, 0x9a, 0xea, 0xed, 0xef, 0x00, 0x94, 0x98, 0x19, 0x69, 0xb5, 0x01, 0x00,
+	0x00,
 }
 
 func (m *Blob) Marshal() (dAtA []byte, err error) {
@@ -212,6 +232,20 @@ func (m *Blob) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ZstdData != nil {
+		i -= len(m.ZstdData)
+		copy(dAtA[i:], m.ZstdData)
+		i = encodeVarintFileformat(dAtA, i, uint64(len(m.ZstdData)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Lz4Data != nil {
+		i -= len(m.Lz4Data)
+		copy(dAtA[i:], m.Lz4Data)
+		i = encodeVarintFileformat(dAtA, i, uint64(len(m.Lz4Data)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.OBSOLETEBzip2Data != nil {
 		i -= len(m.OBSOLETEBzip2Data)
 		copy(dAtA[i:], m.OBSOLETEBzip2Data)
@@ -318,6 +352,14 @@ func (m *Blob) Size() (n int) {
 		l = len(m.OBSOLETEBzip2Data)
 		n += 1 + l + sovFileformat(uint64(l))
 	}
+	if m.Lz4Data != nil {
+		l = len(m.Lz4Data)
+		n += 1 + l + sovFileformat(uint64(l))
+	}
+	if m.ZstdData != nil {
+		l = len(m.ZstdData)
+		n += 1 + l + sovFileformat(uint64(l))
+	}
 	return n
 }
 
@@ -527,6 +569,74 @@ func (m *Blob) Unmarshal(dAtA []byte) error {
 				m.OBSOLETEBzip2Data = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lz4Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFileformat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFileformat
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFileformat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Lz4Data = append(m.Lz4Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Lz4Data == nil {
+				m.Lz4Data = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ZstdData", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowFileformat
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthFileformat
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthFileformat
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ZstdData = append(m.ZstdData[:0], dAtA[iNdEx:postIndex]...)
+			if m.ZstdData == nil {
+				m.ZstdData = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipFileformat(dAtA[iNdEx:])
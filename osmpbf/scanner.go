@@ -2,6 +2,7 @@ package osmpbf
 
 import (
 	"context"
+	"errors"
 	"io"
 	"sync/atomic"
 
@@ -27,6 +28,28 @@ type Scanner struct {
 	SkipWays      bool
 	SkipRelations bool
 
+	// SkipMetadata are the element metadata fields that will not be decoded.
+	// For example, osm.MetadataAll will only decode ids, versions, tags and
+	// geometry. Skipped fields are left as their zero value.
+	SkipMetadata osm.MetadataMask
+
+	// Filter limits the elements returned by the scanner. It is evaluated
+	// while decoding so tags and metadata of non-matching elements
+	// are not decoded into new objects.
+	Filter *Filter
+
+	// FilterNode, FilterWay and FilterRelation are called in the decoding
+	// goroutines on the fully decoded elements that match the Filter.
+	// Elements are returned if the function returns true. They must
+	// be safe for concurrent use.
+	FilterNode     func(*osm.Node) bool
+	FilterWay      func(*osm.Way) bool
+	FilterRelation func(*osm.Relation) bool
+
+	// OnlyCoastlines limits the ways to those tagged natural=coastline.
+	// Nodes and relations are not affected.
+	//
+	// Deprecated: use Filter{Tags: osm.Tags{{Key: "natural", Value: "coastline"}}}
 	OnlyCoastlines bool
 
 	ctx    context.Context
@@ -55,15 +78,94 @@ func New(ctx context.Context, r io.Reader, procs int) *Scanner {
 	return s
 }
 
+// Resume returns a new Scanner that continues reading the file at the offset,
+// usually a value previously returned by FullyScannedBytes. The offset must be
+// the start of a block. Objects are returned in the same order as a scan
+// from the start of the file, and offsets, like FullyScannedBytes, remain
+// relative to the start of the file so they can be used to checkpoint again.
+//
+// The header is required to decode the data. If nil, it is read from the
+// start of the file before seeking to the offset. A header previously read using
+// Scanner.Header can be provided to avoid the extra read.
+//
+// In history files the versions of an element may span blocks. If the first
+// element at the offset continues from the previous block, the scanner backs up
+// to the block where its versions start so they are all returned. Objects of
+// other elements in that block are skipped. Finding the previous blocks requires
+// reading the blob headers from the start of the file.
+func Resume(ctx context.Context, r io.ReadSeeker, offset int64, header *Header, procs int) *Scanner {
+	s := New(ctx, r, procs)
+	s.decoder.seeker = r
+	s.decoder.offset = offset
+	s.decoder.header = header
+
+	return s
+}
+
+// LoadHistory reads all the nodes, ways and relations of a pbf file into
+// a history datasource. This is meant for full history files, e.g. the
+// history planet or an extract of it, where all the versions of an element
+// are sorted. The datasource can be used to annotate against local data.
+func LoadHistory(ctx context.Context, r io.Reader, procs int) (*osm.HistoryDatasource, error) {
+	s := New(ctx, r, procs)
+	defer s.Close()
+
+	ds := &osm.HistoryDatasource{}
+	if err := ds.Load(s); err != nil {
+		return nil, err
+	}
+
+	return ds, nil
+}
+
+// ScanBlocks decodes the data blocks in parallel and calls fn with the objects of
+// each block, and the offset of the block, from the decoding goroutines. It is
+// meant for jobs that do not need the objects in file order since it avoids
+// the serialization of the blocks and the per object Scan calls.
+//
+// fn is called concurrently, up to procs at a time, and the blocks can be in any
+// order. The objects slice is not reused and can be retained. If fn returns an error
+// scanning stops and the error is returned. ScanBlocks can not be mixed with Scan,
+// it must be called before any objects are scanned. It returns nil at the end of the input.
+func (s *Scanner) ScanBlocks(fn func(offset int64, objects []osm.Object) error) error {
+	if s.started {
+		return errors.New("osmpbf: scanner already started")
+	}
+	s.start()
+
+	s.err = s.decoder.ScanBlocks(s.procs, fn)
+	if s.err == nil {
+		s.err = io.EOF
+	}
+
+	return s.Err()
+}
+
+// start marks the scanner as started and maps the deprecated
+// options onto the filters before decoding begins.
+func (s *Scanner) start() {
+	s.started = true
+
+	if s.OnlyCoastlines {
+		filter := s.FilterWay
+		s.FilterWay = func(w *osm.Way) bool {
+			if w.Tags.Find("natural") != "coastline" {
+				return false
+			}
+
+			return filter == nil || filter(w)
+		}
+	}
+}
+
 // FullyScannedBytes returns the number of bytes that have been read
 // and fully scanned. OSM protobuf files contain data blocks with
 // 8000 nodes each. The returned value contains the bytes for the blocks
 // that have been fully scanned.
 //
-// A user can use this number of seek forward in a file
-// and begin reading mid-data. Note that while elements are usually sorted
-// by Type, ID, Version in OSM protobuf files, versions of given element may
-// span blocks.
+// A user can use this number to resume reading mid-data, see Resume.
+// Note that while elements are usually sorted by Type, ID, Version in
+// OSM protobuf files, versions of given element may span blocks.
 func (s *Scanner) FullyScannedBytes() int64 {
 	return atomic.LoadInt64(&s.decoder.cOffset)
 }
@@ -88,7 +190,7 @@ func (s *Scanner) Close() error {
 // about how it was created.
 func (s *Scanner) Header() (*Header, error) {
 	if !s.started {
-		s.started = true
+		s.start()
 		// the header gets read before Start returns
 		s.err = s.decoder.Start(s.procs)
 	}
@@ -104,7 +206,7 @@ func (s *Scanner) Header() (*Header, error) {
 // return nil.
 func (s *Scanner) Scan() bool {
 	if !s.started {
-		s.started = true
+		s.start()
 		s.err = s.decoder.Start(s.procs)
 	}
 
@@ -1,9 +1,13 @@
 package osmpbf
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"io"
 	"os"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -77,6 +81,197 @@ func TestScanner_intermediateStart(t *testing.T) {
 	scanner.Close()
 }
 
+func TestResume(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := New(context.Background(), f, 2)
+	header, err := scanner.Header()
+	if err != nil {
+		t.Fatalf("header error: %v", err)
+	}
+
+	var (
+		objects osm.Objects
+		offsets []int64
+	)
+	for scanner.Scan() {
+		objects = append(objects, scanner.Object())
+		offsets = append(offsets, scanner.FullyScannedBytes())
+	}
+	scanner.Close()
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	// find the start of a block in the middle of the file
+	start := len(objects) / 2
+	for offsets[start] == offsets[start-1] {
+		start++
+	}
+
+	for _, h := range []*Header{nil, header} {
+		scanner := Resume(context.Background(), f, offsets[start], h, 3)
+
+		if rh, err := scanner.Header(); err != nil || !reflect.DeepEqual(rh, header) {
+			t.Errorf("incorrect header: %v %v", rh, err)
+		}
+
+		i := start
+		for scanner.Scan() {
+			if !reflect.DeepEqual(scanner.Object(), objects[i]) {
+				t.Fatalf("objects not equal at %d", i)
+			}
+
+			if o := scanner.FullyScannedBytes(); o != offsets[i] {
+				t.Errorf("incorrect offset at %d: %v != %v", i, o, offsets[i])
+			}
+			i++
+		}
+		scanner.Close()
+
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("scanner error: %v", err)
+		}
+
+		if i != len(objects) {
+			t.Errorf("incorrect number of objects: %v != %v", i, len(objects))
+		}
+	}
+
+	// resume from the start should return everything
+	scanner = Resume(context.Background(), f, 0, nil, 1)
+	defer scanner.Close()
+
+	count := 0
+	for scanner.Scan() {
+		count++
+	}
+
+	if count != len(objects) {
+		t.Errorf("incorrect number of objects: %v != %v", count, len(objects))
+	}
+}
+
+func TestResume_history(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// with 2 elements per block the versions of node 2 span 3 blocks.
+	objects := osm.Objects{
+		&osm.Node{ID: 1, Version: 1, Visible: true, Lat: 1, Lon: 2, Timestamp: ts},
+		&osm.Node{ID: 2, Version: 1, Visible: true, Lat: 3, Lon: 4, Timestamp: ts},
+		&osm.Node{ID: 2, Version: 2, Visible: true, Lat: 3, Lon: 5, Timestamp: ts},
+		&osm.Node{ID: 2, Version: 3, Visible: true, Lat: 3, Lon: 6, Timestamp: ts},
+		&osm.Node{ID: 2, Version: 4, Visible: false, Timestamp: ts},
+		&osm.Node{ID: 3, Version: 1, Visible: true, Lat: 5, Lon: 6, Timestamp: ts},
+		&osm.Way{ID: 4, Version: 1, Visible: true, Timestamp: ts, Nodes: osm.WayNodes{{ID: 1}, {ID: 3}}},
+		&osm.Way{ID: 4, Version: 2, Visible: true, Timestamp: ts, Nodes: osm.WayNodes{{ID: 3}, {ID: 1}}},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(context.Background(), buf, 1)
+	enc.BlockSize = 2
+	enc.Header.RequiredFeatures = []string{"OsmSchema-V0.6", "DenseNodes", "HistoricalInformation"}
+	for _, o := range objects {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	r := bytes.NewReader(buf.Bytes())
+	scanner := New(context.Background(), r, 1)
+
+	var offsets []int64
+	for scanner.Scan() {
+		offsets = append(offsets, scanner.FullyScannedBytes())
+	}
+	scanner.Close()
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	if len(offsets) != len(objects) {
+		t.Fatalf("incorrect number of objects: %v", len(offsets))
+	}
+
+	for i := 1; i < len(objects); i++ {
+		if offsets[i] == offsets[i-1] {
+			continue
+		}
+
+		// all the versions of the first element should be returned
+		start := i
+		for start > 0 && objects[start-1].ObjectID().Ref() == objects[i].ObjectID().Ref() &&
+			objects[start-1].ObjectID().Type() == objects[i].ObjectID().Type() {
+			start--
+		}
+
+		scanner := Resume(context.Background(), r, offsets[i], nil, 2)
+
+		j := start
+		for scanner.Scan() {
+			if j >= len(objects) {
+				t.Fatalf("resume at %d: too many objects", i)
+			}
+
+			if !reflect.DeepEqual(scanner.Object(), objects[j]) {
+				t.Errorf("resume at %d: incorrect object at %d: %v", i, j, scanner.Object().ObjectID())
+			}
+
+			if o := scanner.FullyScannedBytes(); o != offsets[j] {
+				t.Errorf("resume at %d: incorrect offset at %d: %v != %v", i, j, o, offsets[j])
+			}
+			j++
+		}
+		scanner.Close()
+
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("resume at %d: scanner error: %v", i, err)
+		}
+
+		if j != len(objects) {
+			t.Errorf("resume at %d: incorrect number of objects: %v != %v", i, j-start, len(objects)-start)
+		}
+
+		var (
+			mu    sync.Mutex
+			count int
+		)
+		scanner = Resume(context.Background(), r, offsets[i], nil, 2)
+		err := scanner.ScanBlocks(func(offset int64, objects []osm.Object) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			count += len(objects)
+			return nil
+		})
+		scanner.Close()
+
+		if err != nil {
+			t.Fatalf("resume at %d: scan blocks error: %v", i, err)
+		}
+
+		if count != len(objects)-start {
+			t.Errorf("resume at %d: incorrect scan blocks count: %v != %v", i, count, len(objects)-start)
+		}
+	}
+
+	scanner = Resume(context.Background(), r, offsets[2]+1, nil, 1)
+	if scanner.Scan() || scanner.Err() == nil {
+		t.Errorf("should error if offset is not the start of a block")
+	}
+	scanner.Close()
+}
+
 func TestScanner_context(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	f, err := os.Open(Delaware)
@@ -275,6 +470,364 @@ func TestScanner_FullyScannedBytes(t *testing.T) {
 	})
 }
 
+func TestScanner_SkipMetadata(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := New(context.Background(), f, 2)
+	scanner.SkipMetadata = osm.MetadataAll &^ osm.MetadataChangeset
+	defer scanner.Close()
+
+	var nodes, ways, relations int
+	check := func(id osm.ObjectID, cs osm.ChangesetID, ts time.Time, uid osm.UserID, user string, visible bool) {
+		t.Helper()
+		if cs == 0 {
+			t.Fatalf("%v: changeset should be decoded", id)
+		}
+
+		if !ts.IsZero() || uid != 0 || user != "" || visible {
+			t.Fatalf("%v: metadata should be skipped: %v %v %v %v", id, ts, uid, user, visible)
+		}
+	}
+
+	for scanner.Scan() {
+		switch o := scanner.Object().(type) {
+		case *osm.Node:
+			nodes++
+			check(o.ObjectID(), o.ChangesetID, o.Timestamp, o.UserID, o.User, o.Visible)
+		case *osm.Way:
+			ways++
+			check(o.ObjectID(), o.ChangesetID, o.Timestamp, o.UserID, o.User, o.Visible)
+		case *osm.Relation:
+			relations++
+			check(o.ObjectID(), o.ChangesetID, o.Timestamp, o.UserID, o.User, o.Visible)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner returned error: %v", err)
+	}
+
+	if nodes == 0 || ways == 0 || relations == 0 {
+		t.Errorf("should scan all types: %v %v %v", nodes, ways, relations)
+	}
+}
+
+func TestScanner_Filter(t *testing.T) {
+	filter := &Filter{
+		Tags:   osm.Tags{{Key: "highway"}, {Key: "amenity", Value: "school"}},
+		MinID:  100000,
+		MaxID:  500000000,
+		Bounds: &osm.Bounds{MinLat: 38.5, MaxLat: 39.5, MinLon: -76, MaxLon: -75},
+	}
+
+	match := func(o osm.Object, tags osm.Tags) bool {
+		id := int64(o.ObjectID().Ref())
+		if id < filter.MinID || id > filter.MaxID {
+			return false
+		}
+
+		if n, ok := o.(*osm.Node); ok && !filter.Bounds.ContainsNode(n) {
+			return false
+		}
+
+		return tags.Find("highway") != "" || tags.Find("amenity") == "school"
+	}
+
+	scan := func(f *Filter) map[osm.ObjectID]bool {
+		t.Helper()
+
+		file, err := os.Open(Delaware)
+		if err != nil {
+			t.Fatalf("unable to open file: %v", err)
+		}
+		defer file.Close()
+
+		scanner := New(context.Background(), file, 3)
+		scanner.Filter = f
+		scanner.FilterRelation = func(r *osm.Relation) bool {
+			return len(r.Members) > 0
+		}
+		defer scanner.Close()
+
+		result := make(map[osm.ObjectID]bool)
+		for scanner.Scan() {
+			o := scanner.Object()
+
+			var tags osm.Tags
+			switch o := o.(type) {
+			case *osm.Node:
+				tags = o.Tags
+			case *osm.Way:
+				tags = o.Tags
+			case *osm.Relation:
+				tags = o.Tags
+			}
+
+			if f != nil && !match(o, tags) {
+				t.Fatalf("object should not match: %v", o.ObjectID())
+			}
+
+			if f == nil && !match(o, tags) {
+				continue
+			}
+
+			result[o.ObjectID()] = true
+		}
+
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("scanner returned error: %v", err)
+		}
+
+		return result
+	}
+
+	expected := scan(nil)
+	filtered := scan(filter)
+
+	var nodes, ways, relations int
+	for id := range expected {
+		if !filtered[id] {
+			t.Fatalf("missing object: %v", id)
+		}
+
+		switch id.Type() {
+		case osm.TypeNode:
+			nodes++
+		case osm.TypeWay:
+			ways++
+		case osm.TypeRelation:
+			relations++
+		}
+	}
+
+	if len(filtered) != len(expected) {
+		t.Errorf("incorrect number of objects: %v != %v", len(filtered), len(expected))
+	}
+
+	if nodes == 0 || ways == 0 || relations == 0 {
+		t.Errorf("should match all types: %v %v %v", nodes, ways, relations)
+	}
+}
+
+func TestScanner_OnlyCoastlines(t *testing.T) {
+	scan := func(onlyCoastlines bool) (int, int) {
+		t.Helper()
+
+		file, err := os.Open(Delaware)
+		if err != nil {
+			t.Fatalf("unable to open file: %v", err)
+		}
+		defer file.Close()
+
+		scanner := New(context.Background(), file, 3)
+		scanner.SkipNodes = true
+		scanner.OnlyCoastlines = onlyCoastlines
+		defer scanner.Close()
+
+		ways, coastlines := 0, 0
+		for scanner.Scan() {
+			w, ok := scanner.Object().(*osm.Way)
+			if !ok {
+				continue
+			}
+
+			ways++
+			if w.Tags.Find("natural") == "coastline" {
+				coastlines++
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			t.Fatalf("scanner returned error: %v", err)
+		}
+
+		return ways, coastlines
+	}
+
+	_, expected := scan(false)
+	ways, coastlines := scan(true)
+
+	if expected == 0 {
+		t.Fatalf("test data should have coastlines")
+	}
+
+	if ways != coastlines || coastlines != expected {
+		t.Errorf("incorrect ways: %v %v != %v", ways, coastlines, expected)
+	}
+}
+
+func TestScanner_ScanBlocks(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	expected := make(map[int64]int)
+
+	scanner := New(context.Background(), f, 1)
+	for scanner.Scan() {
+		expected[scanner.FullyScannedBytes()]++
+	}
+	scanner.Close()
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner returned error: %v", err)
+	}
+
+	f.Seek(0, io.SeekStart)
+	scanner = New(context.Background(), f, 3)
+	defer scanner.Close()
+
+	var mu sync.Mutex
+	counts := make(map[int64]int)
+	err = scanner.ScanBlocks(func(offset int64, objects []osm.Object) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		counts[offset] = len(objects)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("scan blocks returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(counts, expected) {
+		t.Errorf("incorrect block counts")
+		t.Logf("%v", counts)
+		t.Logf("%v", expected)
+	}
+
+	if scanner.Scan() {
+		t.Errorf("should not scan after scan blocks")
+	}
+
+	if h, _ := scanner.Header(); h == nil {
+		t.Errorf("should read header")
+	}
+}
+
+func TestScanner_ScanBlocks_error(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := New(context.Background(), f, 2)
+	defer scanner.Close()
+
+	stop := errors.New("stop")
+	err = scanner.ScanBlocks(func(offset int64, objects []osm.Object) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("incorrect error: %v", err)
+	}
+
+	if err := scanner.ScanBlocks(nil); err == nil {
+		t.Errorf("should not scan blocks twice")
+	}
+}
+
+func TestLoadHistory(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	objects := osm.Objects{
+		&osm.Node{ID: 1, Version: 1, Visible: true, Lat: 1, Lon: 2, Timestamp: ts},
+		&osm.Node{ID: 1, Version: 2, Visible: true, Lat: 1.5, Lon: 2.5, Timestamp: ts.Add(time.Hour)},
+		&osm.Node{ID: 1, Version: 3, Visible: false, Timestamp: ts.Add(2 * time.Hour)},
+		&osm.Node{ID: 2, Version: 1, Visible: true, Lat: 3, Lon: 4, Timestamp: ts},
+		&osm.Way{ID: 3, Version: 1, Visible: true, Timestamp: ts, Nodes: osm.WayNodes{{ID: 1}, {ID: 2}}},
+		&osm.Way{ID: 3, Version: 2, Visible: false, Timestamp: ts.Add(time.Hour)},
+		&osm.Relation{ID: 4, Version: 1, Visible: false, Timestamp: ts},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(context.Background(), buf, 1)
+	enc.Header.RequiredFeatures = []string{"OsmSchema-V0.6", "DenseNodes", "HistoricalInformation"}
+	for _, o := range objects {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	scanner := New(context.Background(), bytes.NewReader(buf.Bytes()), 1)
+	header, err := scanner.Header()
+	if err != nil {
+		t.Fatalf("header error: %v", err)
+	}
+	scanner.Close()
+
+	if !header.HistoricalInformation() {
+		t.Errorf("should be a history file")
+	}
+
+	ds, err := LoadHistory(context.Background(), bytes.NewReader(buf.Bytes()), 2)
+	if err != nil {
+		t.Fatalf("load error: %v", err)
+	}
+
+	ctx := context.Background()
+	nodes, err := ds.NodeHistory(ctx, 1)
+	if err != nil {
+		t.Fatalf("node history error: %v", err)
+	}
+
+	if !reflect.DeepEqual(nodes, osm.Nodes{objects[0].(*osm.Node), objects[1].(*osm.Node), objects[2].(*osm.Node)}) {
+		t.Errorf("incorrect node history: %+v", nodes)
+	}
+
+	ways, err := ds.WayHistory(ctx, 3)
+	if err != nil {
+		t.Fatalf("way history error: %v", err)
+	}
+
+	if len(ways) != 2 || !ways[0].Visible || ways[1].Visible || ways[1].Version != 2 {
+		t.Errorf("incorrect way history: %+v", ways)
+	}
+
+	relations, err := ds.RelationHistory(ctx, 4)
+	if err != nil {
+		t.Fatalf("relation history error: %v", err)
+	}
+
+	if len(relations) != 1 || relations[0].Visible {
+		t.Errorf("incorrect relation history: %+v", relations)
+	}
+
+	if _, err := ds.NodeHistory(ctx, 3); !ds.NotFound(err) {
+		t.Errorf("should be not found error: %v", err)
+	}
+}
+
+func TestHeader_HistoricalInformation(t *testing.T) {
+	f, err := os.Open(Delaware)
+	if err != nil {
+		t.Fatalf("unable to open file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := New(context.Background(), f, 1)
+	defer scanner.Close()
+
+	header, err := scanner.Header()
+	if err != nil {
+		t.Fatalf("header error: %v", err)
+	}
+
+	if header.HistoricalInformation() {
+		t.Errorf("should not be a history file")
+	}
+}
+
 func BenchmarkLondon(b *testing.B) {
 	f, err := os.Open(London)
 	if err != nil {
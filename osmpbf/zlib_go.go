@@ -11,3 +11,7 @@ import (
 func zlibReader(data []byte) (io.ReadCloser, error) {
 	return zlib.NewReader(bytes.NewReader(data))
 }
+
+func zlibWriter(w io.Writer) io.WriteCloser {
+	return zlib.NewWriter(w)
+}
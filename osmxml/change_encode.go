@@ -0,0 +1,214 @@
+package osmxml
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/ich5003/small-osm"
+)
+
+// ChangeEncoder writes a stream of osmChange data, e.g. an .osc file.
+// Consecutive objects with the same action are grouped into a single
+// create, modify or delete block. Objects are written as they are encoded
+// so very large changes do not need to be kept in an osm.Change.
+//
+// The ChangeEncoder is not safe for parallel use.
+type ChangeEncoder struct {
+	// Header contains the attributes of the osmChange element.
+	// It can be modified until the first object is encoded.
+	// The create, modify and delete values are ignored.
+	Header osm.Change
+
+	// Gzip compresses the output, e.g. for an .osc.gz file.
+	// It can be set until the first object is encoded.
+	Gzip bool
+
+	w       io.Writer
+	gz      *gzip.Writer
+	e       *xml.Encoder
+	prefix  string
+	indent  string
+	action  osm.ActionType
+	started bool
+	closed  bool
+	err     error
+}
+
+// NewChangeEncoder returns a new change encoder that writes to w.
+// The header version defaults to 0.6.
+func NewChangeEncoder(w io.Writer) *ChangeEncoder {
+	return &ChangeEncoder{
+		Header: osm.Change{Version: 0.6},
+		w:      w,
+	}
+}
+
+// Indent sets the encoder to generate xml in which each element begins
+// on a new indented line, see xml.Encoder.Indent.
+func (e *ChangeEncoder) Indent(prefix, indent string) {
+	e.prefix, e.indent = prefix, indent
+	if e.e != nil {
+		e.e.Indent(prefix, indent)
+	}
+}
+
+// Encode writes the object within a block for the action. A new block is
+// started if the action is different from the previous object's action.
+// See Encoder.Encode for the supported object types, bounds are not supported.
+func (e *ChangeEncoder) Encode(action osm.ActionType, o osm.Object) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if _, ok := changeAction(string(action)); !ok {
+		return fmt.Errorf("osmxml: unsupported action: %q", action)
+	}
+
+	switch o.(type) {
+	case *osm.Node, *osm.Way, *osm.Relation, *osm.Changeset, *osm.Note, *osm.User:
+	default:
+		return fmt.Errorf("osmxml: unsupported object type: %T", o)
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if action != e.action {
+		if err := e.endAction(); err != nil {
+			return err
+		}
+
+		e.action = action
+		if e.err = e.e.EncodeToken(xml.StartElement{Name: xml.Name{Local: string(action)}}); e.err != nil {
+			return e.err
+		}
+	}
+
+	e.err = e.e.Encode(o)
+	return e.err
+}
+
+// EncodeChange writes all the objects of the change. The objects are
+// written in create, modify, delete order.
+func (e *ChangeEncoder) EncodeChange(c *osm.Change) error {
+	actions := []struct {
+		action osm.ActionType
+		osm    *osm.OSM
+	}{
+		{osm.ActionCreate, c.Create},
+		{osm.ActionModify, c.Modify},
+		{osm.ActionDelete, c.Delete},
+	}
+
+	for _, a := range actions {
+		for _, o := range a.osm.Objects() {
+			if _, ok := o.(*osm.Bounds); ok {
+				continue
+			}
+
+			if err := e.Encode(a.action, o); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// EncodeScanner encodes all the objects returned by the change scanner
+// with their action. The scanner is not closed.
+func (e *ChangeEncoder) EncodeScanner(s *ChangeScanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Action(), s.Object()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close ends the last block and the document and flushes any buffered data.
+// It does not close the underlying writer.
+func (e *ChangeEncoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+	e.closed = true
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if err := e.endAction(); err != nil {
+		return err
+	}
+
+	if err := e.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "osmChange"}}); err != nil {
+		return err
+	}
+
+	if err := e.e.Flush(); err != nil {
+		return err
+	}
+
+	if e.gz != nil {
+		return e.gz.Close()
+	}
+
+	return nil
+}
+
+// endAction closes the current action block, if there is one.
+func (e *ChangeEncoder) endAction() error {
+	if e.action == "" {
+		return nil
+	}
+
+	e.err = e.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: string(e.action)}})
+	e.action = ""
+
+	return e.err
+}
+
+// start writes the xml declaration and the osmChange element.
+func (e *ChangeEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	if e.Gzip {
+		e.gz = gzip.NewWriter(e.w)
+		e.w = e.gz
+	}
+
+	e.e = xml.NewEncoder(e.w)
+	e.e.Indent(e.prefix, e.indent)
+
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		e.err = err
+		return err
+	}
+
+	h := &osm.OSM{
+		Version:     e.Header.Version,
+		Generator:   e.Header.Generator,
+		Copyright:   e.Header.Copyright,
+		Attribution: e.Header.Attribution,
+		License:     e.Header.License,
+	}
+
+	e.err = e.e.EncodeToken(headerStart("osmChange", h))
+	return e.err
+}
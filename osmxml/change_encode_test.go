@@ -0,0 +1,129 @@
+package osmxml
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestChangeEncoder(t *testing.T) {
+	type actionObject struct {
+		Action osm.ActionType
+		Object osm.Object
+	}
+
+	scan := func(s *ChangeScanner) []actionObject {
+		t.Helper()
+
+		var result []actionObject
+		for s.Scan() {
+			result = append(result, actionObject{s.Action(), s.Object()})
+		}
+
+		if err := s.Err(); err != nil {
+			t.Fatalf("scanner error: %v", err)
+		}
+
+		return result
+	}
+
+	f, err := os.Open("../testdata/minute_871.osc")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	expected := scan(NewChangeScanner(context.Background(), f))
+
+	buf := &bytes.Buffer{}
+	enc := NewChangeEncoder(buf)
+	enc.Gzip = true
+	for _, ao := range expected {
+		if err := enc.Encode(ao.Action, ao.Object); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	gzReader, err := gzip.NewReader(buf)
+	if err != nil {
+		t.Fatalf("gzip error: %v", err)
+	}
+	defer gzReader.Close()
+
+	result := scan(NewChangeScanner(context.Background(), gzReader))
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("objects not equal")
+	}
+}
+
+func TestChangeEncoder_grouping(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := NewChangeEncoder(buf)
+	enc.Header.Generator = "test"
+
+	enc.Encode(osm.ActionCreate, &osm.Node{ID: 1})
+	enc.Encode(osm.ActionCreate, &osm.Way{ID: 2})
+	enc.Encode(osm.ActionDelete, &osm.Node{ID: 3})
+	enc.Encode(osm.ActionCreate, &osm.Node{ID: 4})
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	s := buf.String()
+	if c := strings.Count(s, "<create>"); c != 2 {
+		t.Errorf("incorrect number of create blocks: %v", c)
+	}
+
+	if c := strings.Count(s, "<delete>"); c != 1 {
+		t.Errorf("incorrect number of delete blocks: %v", c)
+	}
+
+	if !strings.Contains(s, `<osmChange version="0.6" generator="test"><create><node id="1"`) {
+		t.Errorf("incorrect header: %v", s)
+	}
+
+	if !strings.HasSuffix(s, "</create></osmChange>") {
+		t.Errorf("incorrect end: %v", s)
+	}
+
+	if err := NewChangeEncoder(buf).Encode("update", &osm.Node{}); err == nil {
+		t.Errorf("should return error for unknown actions")
+	}
+
+	if err := NewChangeEncoder(buf).Encode(osm.ActionCreate, &osm.Bounds{}); err == nil {
+		t.Errorf("should return error for bounds")
+	}
+}
+
+func TestChangeEncoder_EncodeChange(t *testing.T) {
+	change := &osm.Change{
+		Create: &osm.OSM{Nodes: osm.Nodes{{ID: 1}}},
+		Delete: &osm.OSM{Ways: osm.Ways{{ID: 2}}},
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewChangeEncoder(buf)
+	if err := enc.EncodeChange(change); err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	expected := `<osmChange version="0.6"><create><node id="1"`
+	if s := buf.String(); !strings.Contains(s, expected) || !strings.Contains(s, `<delete><way id="2"`) {
+		t.Errorf("incorrect output: %v", s)
+	}
+}
@@ -0,0 +1,141 @@
+package osmxml
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+
+	"github.com/ich5003/small-osm"
+)
+
+var _ osm.Scanner = &ChangeScanner{}
+
+// ChangeScanner reads a stream of osmChange data, e.g. an .osc file or
+// a replication diff. Successive calls to the Scan method will step through
+// the objects and the action, create, modify or delete, of the block they are in.
+// This allows processing large changes without decoding them into an osm.Change.
+//
+// Scanning stops unrecoverably at EOF, the first I/O error, the first xml error or
+// the context being cancelled.
+type ChangeScanner struct {
+	// SkipMetadata are the element metadata fields of nodes, ways and
+	// relations that will not be decoded, see Scanner.SkipMetadata.
+	SkipMetadata osm.MetadataMask
+
+	ctx    context.Context
+	done   context.CancelFunc
+	closed bool
+
+	decoder *xml.Decoder
+	action  osm.ActionType
+	next    osm.Object
+	err     error
+}
+
+// NewChangeScanner returns a new ChangeScanner to read from r.
+func NewChangeScanner(ctx context.Context, r io.Reader) *ChangeScanner {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s := &ChangeScanner{
+		decoder: xml.NewDecoder(r),
+	}
+
+	s.ctx, s.done = context.WithCancel(ctx)
+	return s
+}
+
+// Close causes all future calls to Scan to return false.
+// Does not close the underlying reader.
+func (s *ChangeScanner) Close() error {
+	s.closed = true
+	s.done()
+
+	return nil
+}
+
+// Scan advances the ChangeScanner to the next object, which will then be available
+// through the Object and Action methods. It returns false when the scan stops, either
+// by reaching the end of the input, an io error, an xml error or the context
+// being cancelled. After Scan returns false, the Err method will return any
+// error that occurred during scanning, except if it was io.EOF, Err will
+// return nil.
+func (s *ChangeScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		if s.ctx.Err() != nil {
+			return false
+		}
+
+		t, err := s.decoder.Token()
+		if err != nil {
+			s.err = err
+			return false
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			if a, ok := changeAction(t.Name.Local); ok {
+				s.action = a
+				continue
+			}
+
+			s.next, err = decodeObject(s.decoder, &t, s.SkipMetadata)
+			if err != nil {
+				s.err = err
+				return false
+			}
+
+			if s.next != nil {
+				return true
+			}
+		case xml.EndElement:
+			if _, ok := changeAction(t.Name.Local); ok {
+				s.action = ""
+			}
+		}
+	}
+}
+
+// changeAction returns the action for the osmChange block element name.
+func changeAction(name string) (osm.ActionType, bool) {
+	switch a := osm.ActionType(name); a {
+	case osm.ActionCreate, osm.ActionModify, osm.ActionDelete:
+		return a, true
+	}
+
+	return "", false
+}
+
+// Object returns the most recent object generated by a call to Scan.
+// See Scanner.Object for the possible types.
+func (s *ChangeScanner) Object() osm.Object {
+	return s.next
+}
+
+// Action returns the action of the most recent object generated by a call
+// to Scan. It is empty if the object is not within a create, modify or delete block.
+func (s *ChangeScanner) Action() osm.ActionType {
+	return s.action
+}
+
+// Err returns the first non-EOF error that was encountered by the ChangeScanner.
+func (s *ChangeScanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.closed {
+		return osm.ErrScannerClosed
+	}
+
+	return s.ctx.Err()
+}
@@ -0,0 +1,94 @@
+package osmxml
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestChangeScanner(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/minute_871.osc")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	expected := &osm.Change{}
+	if err := xml.Unmarshal(data, expected); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	scanner := NewChangeScanner(context.Background(), bytes.NewReader(data))
+	defer scanner.Close()
+
+	change := &osm.Change{}
+	for scanner.Scan() {
+		switch scanner.Action() {
+		case osm.ActionCreate:
+			change.AppendCreate(scanner.Object())
+		case osm.ActionModify:
+			change.AppendModify(scanner.Object())
+		case osm.ActionDelete:
+			change.AppendDelete(scanner.Object())
+		default:
+			t.Fatalf("object without action: %v", scanner.Object())
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	if !reflect.DeepEqual(change.Create, expected.Create) {
+		t.Errorf("creates not equal")
+	}
+
+	if !reflect.DeepEqual(change.Modify, expected.Modify) {
+		t.Errorf("modifies not equal")
+	}
+
+	if !reflect.DeepEqual(change.Delete, expected.Delete) {
+		t.Errorf("deletes not equal")
+	}
+}
+
+func TestChangeScanner_action(t *testing.T) {
+	data := `<osmChange version="0.6">
+	<node id="1" version="1"/>
+	<delete><way id="2" version="3"/></delete>
+	<relation id="3" version="1"/>
+</osmChange>`
+
+	scanner := NewChangeScanner(context.Background(), strings.NewReader(data))
+	scanner.SkipMetadata = osm.MetadataAll
+
+	var actions []osm.ActionType
+	var ids []osm.ObjectID
+	for scanner.Scan() {
+		actions = append(actions, scanner.Action())
+		ids = append(ids, scanner.Object().ObjectID())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	expected := []osm.ActionType{"", osm.ActionDelete, ""}
+	if !reflect.DeepEqual(actions, expected) {
+		t.Errorf("incorrect actions: %v", actions)
+	}
+
+	if ids[1] != osm.WayID(2).ObjectID(3) {
+		t.Errorf("incorrect object: %v", ids[1])
+	}
+
+	scanner.Close()
+	if scanner.Scan() {
+		t.Errorf("should not scan after close")
+	}
+}
@@ -0,0 +1,859 @@
+package osmxml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ich5003/small-osm"
+	"github.com/paulmach/orb"
+)
+
+// maxStrings is the size of the interned strings map after which it is reset.
+const maxStrings = 1 << 14
+
+// A decoder decodes the raw object elements found by the tokenizer.
+// Nodes, ways, relations and bounds are decoded directly from the bytes
+// without reflection. Other objects, and elements with anything
+// unexpected, e.g. entities in numbers or unknown child elements,
+// are decoded using encoding/xml so the results and errors are the same.
+type decoder struct {
+	skip osm.MetadataMask
+
+	// tag keys, roles and user names are repeated a lot
+	// so the strings are shared between the objects.
+	strings map[string]string
+	buf     []byte
+}
+
+func newDecoder(skip osm.MetadataMask) *decoder {
+	return &decoder{
+		skip:    skip,
+		strings: make(map[string]string),
+	}
+}
+
+// Decode decodes the object element.
+func (d *decoder) Decode(data []byte) (osm.Object, error) {
+	if o := d.decodeFast(data); o != nil {
+		return o, nil
+	}
+
+	return decodeElement(data, d.skip)
+}
+
+// decodeElement decodes the object element using encoding/xml.
+func decodeElement(data []byte, skip osm.MetadataMask) (osm.Object, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			return nil, errUnexpectedEOF
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if se, ok := t.(xml.StartElement); ok {
+			return decodeObject(dec, &se, skip)
+		}
+	}
+}
+
+// decodeFast returns nil if the element can not be decoded
+// directly and must be decoded using encoding/xml.
+func (d *decoder) decodeFast(data []byte) osm.Object {
+	kind, name, end, err := readToken(data, 0)
+	if err != nil || end < 0 || (kind != tokenStart && kind != tokenSelfClosing) {
+		return nil
+	}
+
+	a := &attrs{b: startAttrs(data, kind, name, end)}
+	c := &children{data: data, pos: end, parent: name, done: kind == tokenSelfClosing}
+
+	switch string(name) {
+	case "node":
+		return d.node(a, c)
+	case "way":
+		return d.way(a, c)
+	case "relation":
+		return d.relation(a, c)
+	case "bounds":
+		return d.bounds(a, c)
+	}
+
+	return nil
+}
+
+func (d *decoder) node(a *attrs, c *children) osm.Object {
+	var h header
+	if !d.header(a, &h) {
+		return nil
+	}
+
+	n := &osm.Node{
+		ID:          osm.NodeID(h.id),
+		Lat:         h.lat,
+		Lon:         h.lon,
+		User:        h.user,
+		UserID:      h.userID,
+		Visible:     h.visible,
+		Version:     h.version,
+		ChangesetID: h.changeset,
+		Timestamp:   h.timestamp,
+		Committed:   h.committed,
+	}
+
+	for {
+		name, attrs, ok := c.next()
+		if !ok {
+			break
+		}
+
+		if string(name) != "tag" {
+			return nil
+		}
+
+		t, ok := d.tag(attrs)
+		if !ok {
+			return nil
+		}
+		n.Tags = append(n.Tags, t)
+	}
+
+	if c.bad {
+		return nil
+	}
+
+	return n
+}
+
+func (d *decoder) way(a *attrs, c *children) osm.Object {
+	var h header
+	if !d.header(a, &h) {
+		return nil
+	}
+
+	w := &osm.Way{
+		ID:          osm.WayID(h.id),
+		User:        h.user,
+		UserID:      h.userID,
+		Visible:     h.visible,
+		Version:     h.version,
+		ChangesetID: h.changeset,
+		Timestamp:   h.timestamp,
+		Committed:   h.committed,
+	}
+
+	for {
+		name, attrs, ok := c.next()
+		if !ok {
+			break
+		}
+
+		switch string(name) {
+		case "nd":
+			wn, ok := d.wayNode(attrs)
+			if !ok {
+				return nil
+			}
+			w.Nodes = append(w.Nodes, wn)
+		case "tag":
+			t, ok := d.tag(attrs)
+			if !ok {
+				return nil
+			}
+			w.Tags = append(w.Tags, t)
+		default:
+			return nil
+		}
+	}
+
+	if c.bad {
+		return nil
+	}
+
+	return w
+}
+
+func (d *decoder) relation(a *attrs, c *children) osm.Object {
+	var h header
+	if !d.header(a, &h) {
+		return nil
+	}
+
+	r := &osm.Relation{
+		ID:          osm.RelationID(h.id),
+		User:        h.user,
+		UserID:      h.userID,
+		Visible:     h.visible,
+		Version:     h.version,
+		ChangesetID: h.changeset,
+		Timestamp:   h.timestamp,
+		Committed:   h.committed,
+	}
+
+	for {
+		name, attrs, ok := c.next()
+		if !ok {
+			break
+		}
+
+		switch string(name) {
+		case "member":
+			m, ok := d.member(attrs)
+			if !ok {
+				return nil
+			}
+			r.Members = append(r.Members, m)
+		case "tag":
+			t, ok := d.tag(attrs)
+			if !ok {
+				return nil
+			}
+			r.Tags = append(r.Tags, t)
+		default:
+			return nil
+		}
+	}
+
+	if c.bad {
+		return nil
+	}
+
+	return r
+}
+
+func (d *decoder) bounds(a *attrs, c *children) osm.Object {
+	b := &osm.Bounds{}
+	for {
+		k, v, ok := a.next()
+		if !ok {
+			break
+		}
+
+		switch string(k) {
+		case "minlat":
+			b.MinLat, ok = parseFloat(v)
+		case "maxlat":
+			b.MaxLat, ok = parseFloat(v)
+		case "minlon":
+			b.MinLon, ok = parseFloat(v)
+		case "maxlon":
+			b.MaxLon, ok = parseFloat(v)
+		}
+
+		if !ok {
+			return nil
+		}
+	}
+
+	if a.bad {
+		return nil
+	}
+
+	if _, _, ok := c.next(); ok || c.bad {
+		return nil
+	}
+
+	return b
+}
+
+// header are the attributes shared by nodes, ways and relations.
+type header struct {
+	id        int64
+	lat, lon  float64
+	user      string
+	userID    osm.UserID
+	visible   bool
+	version   int
+	changeset osm.ChangesetID
+	timestamp time.Time
+	committed *time.Time
+}
+
+func (d *decoder) header(a *attrs, h *header) bool {
+	for {
+		k, v, ok := a.next()
+		if !ok {
+			break
+		}
+
+		switch string(k) {
+		case "id":
+			h.id, ok = parseInt(v, 64)
+		case "lat":
+			h.lat, ok = parseFloat(v)
+		case "lon":
+			h.lon, ok = parseFloat(v)
+		case "version":
+			var version int64
+			version, ok = parseInt(v, strconv.IntSize)
+			h.version = int(version)
+		case "user":
+			if d.skip&osm.MetadataUser == 0 {
+				h.user, ok = d.text(v, true)
+			}
+		case "uid":
+			if d.skip&osm.MetadataUserID == 0 {
+				var uid int64
+				uid, ok = parseInt(v, 64)
+				h.userID = osm.UserID(uid)
+			}
+		case "visible":
+			if d.skip&osm.MetadataVisible == 0 {
+				h.visible, ok = parseBool(v)
+			}
+		case "changeset":
+			if d.skip&osm.MetadataChangeset == 0 {
+				var id int64
+				id, ok = parseInt(v, 64)
+				h.changeset = osm.ChangesetID(id)
+			}
+		case "timestamp":
+			if d.skip&osm.MetadataTimestamp == 0 {
+				h.timestamp, ok = parseTime(v)
+			}
+		case "committed":
+			var t time.Time
+			t, ok = parseTime(v)
+			h.committed = &t
+		}
+
+		if !ok {
+			return false
+		}
+	}
+
+	return !a.bad
+}
+
+func (d *decoder) tag(b []byte) (osm.Tag, bool) {
+	var t osm.Tag
+	a := attrs{b: b}
+	for {
+		k, v, ok := a.next()
+		if !ok {
+			break
+		}
+
+		switch string(k) {
+		case "k":
+			t.Key, ok = d.text(v, true)
+		case "v":
+			t.Value, ok = d.text(v, false)
+		}
+
+		if !ok {
+			return t, false
+		}
+	}
+
+	return t, !a.bad
+}
+
+func (d *decoder) wayNode(b []byte) (osm.WayNode, bool) {
+	var wn osm.WayNode
+	a := attrs{b: b}
+	for {
+		k, v, ok := a.next()
+		if !ok {
+			break
+		}
+
+		switch string(k) {
+		case "ref":
+			var id int64
+			id, ok = parseInt(v, 64)
+			wn.ID = osm.NodeID(id)
+		case "version":
+			var version int64
+			version, ok = parseInt(v, strconv.IntSize)
+			wn.Version = int(version)
+		case "changeset":
+			var id int64
+			id, ok = parseInt(v, 64)
+			wn.ChangesetID = osm.ChangesetID(id)
+		case "lat":
+			wn.Lat, ok = parseFloat(v)
+		case "lon":
+			wn.Lon, ok = parseFloat(v)
+		}
+
+		if !ok {
+			return wn, false
+		}
+	}
+
+	return wn, !a.bad
+}
+
+func (d *decoder) member(b []byte) (osm.Member, bool) {
+	var m osm.Member
+	a := attrs{b: b}
+	for {
+		k, v, ok := a.next()
+		if !ok {
+			break
+		}
+
+		switch string(k) {
+		case "type":
+			switch string(v) {
+			case "node":
+				m.Type = osm.TypeNode
+			case "way":
+				m.Type = osm.TypeWay
+			case "relation":
+				m.Type = osm.TypeRelation
+			default:
+				var t string
+				t, ok = d.text(v, false)
+				m.Type = osm.Type(t)
+			}
+		case "ref":
+			m.Ref, ok = parseInt(v, 64)
+		case "role":
+			m.Role, ok = d.text(v, true)
+		case "version":
+			var version int64
+			version, ok = parseInt(v, strconv.IntSize)
+			m.Version = int(version)
+		case "changeset":
+			var id int64
+			id, ok = parseInt(v, 64)
+			m.ChangesetID = osm.ChangesetID(id)
+		case "lat":
+			m.Lat, ok = parseFloat(v)
+		case "lon":
+			m.Lon, ok = parseFloat(v)
+		case "orientation":
+			var o int64
+			o, ok = parseInt(v, 8)
+			m.Orientation = orb.Orientation(o)
+		}
+
+		if !ok {
+			return m, false
+		}
+	}
+
+	return m, !a.bad
+}
+
+// text returns the unescaped attribute value. It returns false if the
+// value is not valid, i.e. has characters not allowed in xml.
+func (d *decoder) text(v []byte, intern bool) (string, bool) {
+	for _, c := range v {
+		if c == '&' || c == '<' || c == '\r' || c >= utf8.RuneSelf ||
+			(c < 0x20 && c != '\t' && c != '\n') {
+			return d.unescape(v)
+		}
+	}
+
+	if !intern {
+		return string(v), true
+	}
+
+	if s, ok := d.strings[string(v)]; ok {
+		return s, true
+	}
+
+	if len(d.strings) >= maxStrings {
+		d.strings = make(map[string]string, len(d.strings))
+	}
+
+	s := string(v)
+	d.strings[s] = s
+
+	return s, true
+}
+
+// unescape replaces the entities and character references in the value
+// and normalizes new lines like encoding/xml.
+func (d *decoder) unescape(v []byte) (string, bool) {
+	buf := d.buf[:0]
+	for i := 0; i < len(v); {
+		c := v[i]
+		switch {
+		case c == '<':
+			return "", false
+		case c == '\r':
+			buf = append(buf, '\n')
+			i++
+			if i < len(v) && v[i] == '\n' {
+				i++
+			}
+		case c == '&':
+			j := bytes.IndexByte(v[i:], ';')
+			if j < 0 {
+				return "", false
+			}
+
+			r, ok := entity(v[i+1 : i+j])
+			if !ok || !isInCharacterRange(r) {
+				return "", false
+			}
+
+			buf = append(buf, string(r)...)
+			i += j + 1
+		default:
+			r, size := utf8.DecodeRune(v[i:])
+			if (r == utf8.RuneError && size == 1) || !isInCharacterRange(r) {
+				return "", false
+			}
+
+			buf = append(buf, v[i:i+size]...)
+			i += size
+		}
+	}
+
+	d.buf = buf
+	return string(buf), true
+}
+
+// entity returns the character for the entity or character reference name.
+func entity(name []byte) (rune, bool) {
+	switch string(name) {
+	case "lt":
+		return '<', true
+	case "gt":
+		return '>', true
+	case "amp":
+		return '&', true
+	case "apos":
+		return '\'', true
+	case "quot":
+		return '"', true
+	}
+
+	if len(name) < 2 || name[0] != '#' {
+		return 0, false
+	}
+
+	var (
+		n   uint64
+		err error
+	)
+	if name[1] == 'x' {
+		n, err = strconv.ParseUint(string(name[2:]), 16, 32)
+	} else {
+		n, err = strconv.ParseUint(string(name[1:]), 10, 32)
+	}
+
+	if err != nil || n > utf8.MaxRune {
+		return 0, false
+	}
+
+	return rune(n), true
+}
+
+// isInCharacterRange returns true if the character is allowed in xml,
+// see the xml spec section 2.2 Characters.
+func isInCharacterRange(r rune) bool {
+	return r == 0x09 ||
+		r == 0x0A ||
+		r == 0x0D ||
+		r >= 0x20 && r <= 0xD7FF ||
+		r >= 0xE000 && r <= 0xFFFD ||
+		r >= 0x10000 && r <= 0x10FFFF
+}
+
+// attrs reads the attributes of a start tag.
+type attrs struct {
+	b   []byte
+	bad bool
+}
+
+// next returns the next attribute name and its escaped value. It returns
+// false at the end of the attributes or if they are not valid.
+func (a *attrs) next() ([]byte, []byte, bool) {
+	b := a.b
+	if len(b) == 0 {
+		return nil, nil, false
+	}
+
+	if !isSpace(b[0]) {
+		a.bad = true
+		return nil, nil, false
+	}
+
+	b = trimSpace(b)
+	if len(b) == 0 {
+		return nil, nil, false
+	}
+
+	n := nameEnd(b, 0)
+	if n == 0 || bytes.IndexByte(b[:n], ':') >= 0 {
+		// prefixed attributes are matched on the local name by encoding/xml
+		a.bad = true
+		return nil, nil, false
+	}
+	name := b[:n]
+
+	b = trimSpace(b[n:])
+	if len(b) == 0 || b[0] != '=' {
+		a.bad = true
+		return nil, nil, false
+	}
+
+	b = trimSpace(b[1:])
+	if len(b) == 0 || (b[0] != '"' && b[0] != '\'') {
+		a.bad = true
+		return nil, nil, false
+	}
+
+	end := bytes.IndexByte(b[1:], b[0])
+	if end < 0 {
+		a.bad = true
+		return nil, nil, false
+	}
+
+	a.b = b[end+2:]
+	return name, b[1 : end+1], true
+}
+
+// startAttrs returns the attributes part of the start tag.
+func startAttrs(data []byte, kind int, name []byte, end int) []byte {
+	if kind == tokenSelfClosing {
+		end--
+	}
+
+	return data[len(name)+1 : end-1]
+}
+
+// children reads the child elements of an object element. Only children
+// without content, e.g. <tag k="a" v="b"/>, are supported.
+type children struct {
+	data   []byte
+	pos    int
+	parent []byte
+	done   bool
+	bad    bool
+}
+
+// next returns the name and attributes of the next child element. It returns
+// false at the end of the parent element or if the content is not supported.
+func (c *children) next() ([]byte, []byte, bool) {
+	for !c.done {
+		kind, name, start, end := c.token()
+		if end < 0 {
+			return nil, nil, false
+		}
+
+		switch kind {
+		case tokenEnd:
+			c.done = true
+			if !bytes.Equal(name, c.parent) {
+				c.bad = true
+			}
+
+			return nil, nil, false
+		case tokenSelfClosing:
+			return name, c.data[start+len(name)+1 : end-2], true
+		case tokenStart:
+			// the child element must be closed right after
+			k, n, _, e := c.token()
+			if e < 0 || k != tokenEnd || !bytes.Equal(name, n) {
+				c.bad = true
+				return nil, nil, false
+			}
+
+			return name, c.data[start+len(name)+1 : end-1], true
+		}
+	}
+
+	return nil, nil, false
+}
+
+// token reads the next token skipping comments and processing instructions.
+// The end is -1, and c.bad is set, if it is not valid.
+func (c *children) token() (int, []byte, int, int) {
+	for {
+		i := bytes.IndexByte(c.data[c.pos:], '<')
+		if i < 0 || bytes.IndexByte(c.data[c.pos:c.pos+i], '&') >= 0 {
+			// text with entities needs to be validated
+			c.bad = true
+			return 0, nil, 0, -1
+		}
+
+		start := c.pos + i
+		kind, name, end, err := readToken(c.data, start)
+		if err != nil || end < 0 || bytes.HasPrefix(c.data[start:], []byte("<![CDATA[")) {
+			c.bad = true
+			return 0, nil, 0, -1
+		}
+		c.pos = end
+
+		if kind != tokenOther {
+			return kind, name, start, end
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\r' || c == '\n'
+}
+
+func trimSpace(b []byte) []byte {
+	for len(b) > 0 && isSpace(b[0]) {
+		b = b[1:]
+	}
+
+	return b
+}
+
+// parseInt parses the integer without allocating, an empty
+// value is zero like encoding/xml.
+func parseInt(b []byte, bitSize int) (int64, bool) {
+	if len(b) == 0 {
+		return 0, true
+	}
+
+	s := b
+	neg := false
+	if s[0] == '-' || s[0] == '+' {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	if len(s) == 0 || len(s) > 18 {
+		n, err := strconv.ParseInt(string(b), 10, bitSize)
+		return n, err == nil
+	}
+
+	var n int64
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int64(c-'0')
+	}
+
+	if neg {
+		n = -n
+	}
+
+	if bitSize < 64 {
+		max := int64(1) << uint(bitSize-1)
+		if n >= max || n < -max {
+			return 0, false
+		}
+	}
+
+	return n, true
+}
+
+// float64pow10 are the powers of ten that can be exactly
+// represented as a float64.
+var float64pow10 = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10,
+	1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+// parseFloat parses the float without allocating for decimals with
+// at most 15 digits, which includes all the osm coordinates. Longer
+// values, and those with exponents, use strconv.
+func parseFloat(b []byte) (float64, bool) {
+	if len(b) == 0 {
+		return 0, true
+	}
+
+	s := b
+	neg := false
+	if s[0] == '-' || s[0] == '+' {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	var (
+		mantissa uint64
+		digits   int
+		decimals int
+		dot      bool
+	)
+	for _, c := range s {
+		if c == '.' && !dot {
+			dot = true
+			continue
+		}
+
+		if c < '0' || c > '9' {
+			digits = -1
+			break
+		}
+
+		mantissa = mantissa*10 + uint64(c-'0')
+		digits++
+		if dot {
+			decimals++
+		}
+	}
+
+	if digits <= 0 || digits > 15 {
+		// exact integers and powers of ten are required for the division to be
+		// correctly rounded, everything else is handled by strconv.
+		f, err := strconv.ParseFloat(string(b), 64)
+		return f, err == nil
+	}
+
+	f := float64(mantissa) / float64pow10[decimals]
+	if neg {
+		f = -f
+	}
+
+	return f, true
+}
+
+func parseBool(b []byte) (bool, bool) {
+	switch string(b) {
+	case "", "0", "f", "F", "false", "FALSE", "False":
+		return false, true
+	case "1", "t", "T", "true", "TRUE", "True":
+		return true, true
+	}
+
+	return false, false
+}
+
+// parseTime parses the timestamp, the usual 2006-01-02T15:04:05Z
+// format is parsed without allocating.
+func parseTime(b []byte) (time.Time, bool) {
+	if len(b) == 20 && b[4] == '-' && b[7] == '-' && b[10] == 'T' &&
+		b[13] == ':' && b[16] == ':' && b[19] == 'Z' {
+		year, ok1 := digits(b[0:4])
+		month, ok2 := digits(b[5:7])
+		day, ok3 := digits(b[8:10])
+		hour, ok4 := digits(b[11:13])
+		min, ok5 := digits(b[14:16])
+		sec, ok6 := digits(b[17:19])
+
+		if ok1 && ok2 && ok3 && ok4 && ok5 && ok6 &&
+			month >= 1 && month <= 12 && day >= 1 &&
+			hour < 24 && min < 60 && sec < 60 {
+			t := time.Date(year, time.Month(month), day, hour, min, sec, 0, time.UTC)
+			if t.Day() == day {
+				return t, true
+			}
+		}
+	}
+
+	t, err := time.Parse(time.RFC3339, string(b))
+	return t, err == nil
+}
+
+func digits(b []byte) (int, bool) {
+	n := 0
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		n = n*10 + int(c-'0')
+	}
+
+	return n, true
+}
@@ -0,0 +1,278 @@
+package osmxml
+
+import (
+	"compress/bzip2"
+	"os"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestDecoder_andorra(t *testing.T) {
+	f, err := os.Open("../testdata/andorra-latest.osm.bz2")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	tokenizer := newTokenizer(bzip2.NewReader(f))
+	d := newDecoder(osm.MetadataNone)
+
+	count := 0
+	for {
+		data, err := tokenizer.Next()
+		if err != nil {
+			break
+		}
+
+		fast := d.decodeFast(data)
+		if fast == nil {
+			t.Fatalf("should decode directly: %s", data)
+		}
+
+		expected, err := decodeElement(data, osm.MetadataNone)
+		if err != nil {
+			t.Fatalf("decode error: %v", err)
+		}
+
+		if !reflect.DeepEqual(fast, expected) {
+			t.Fatalf("incorrect object:\n%v\n%v", fast, expected)
+		}
+		count++
+	}
+
+	if count != 212579 {
+		t.Errorf("incorrect number of objects: %v", count)
+	}
+}
+
+func TestDecoder_Decode(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		fast bool
+	}{
+		{
+			name: "node",
+			data: `<node id="1" lat="-2.5" lon="+3" version="4" timestamp="2014-04-10T00:43:05Z" changeset="5" user="user" uid="6" visible="true"/>`,
+			fast: true,
+		},
+		{
+			name: "single quotes and whitespace",
+			data: "<node\n\tid = '1'\r\n lat='2' ></node >",
+			fast: true,
+		},
+		{
+			name: "entities",
+			data: `<node id="1"><tag k="name" v="a &amp; b &lt;&#62;&#x41;&quot;&apos;"/><tag k="note" v="line1&#13;&#10;line2` + "\r\nline3" + `"/></node>`,
+			fast: true,
+		},
+		{
+			name: "unicode",
+			data: `<node id="1" user="Jos&#233;"><tag k="name:ca" v="Sant Julià de Lòria"/></node>`,
+			fast: true,
+		},
+		{
+			name: "comments",
+			data: `<way id="1"><!-- <nd ref="2"/> --><nd ref="3"></nd><?pi?><tag k="a" v="b"/></way>`,
+			fast: true,
+		},
+		{
+			name: "committed",
+			data: `<way id="1" committed="2014-04-10T00:43:05.5+02:00"><nd ref="3" version="2" changeset="4" lat="5" lon="6"/></way>`,
+			fast: true,
+		},
+		{
+			name: "members",
+			data: `<relation id="1"><member type="way" ref="2" role="outer" orientation="-1"/><member type="area" ref="3" role=""/></relation>`,
+			fast: true,
+		},
+		{
+			name: "long float",
+			data: `<node id="1" lat="12.3456789012345678" lon="1e2"/>`,
+			fast: true,
+		},
+		{
+			name: "bounds",
+			data: `<bounds minlat="1" minlon="2" maxlat="3" maxlon="4"/>`,
+			fast: true,
+		},
+		{
+			name: "entity in number",
+			data: `<node id="&#49;"/>`,
+		},
+		{
+			name: "member nodes",
+			data: `<relation id="1"><member type="way" ref="2" role=""><nd lat="1" lon="2"/></member></relation>`,
+		},
+		{
+			name: "way bounds",
+			data: `<way id="1"><bounds minlat="1" minlon="2" maxlat="3" maxlon="4"></bounds><nd ref="2"/></way>`,
+		},
+		{
+			name: "cdata",
+			data: `<node id="1"><![CDATA[<tag k="a" v="b"/>]]></node>`,
+		},
+		{
+			name: "prefixed attribute",
+			data: `<node xml:lang="en" id="1"/>`,
+		},
+		{
+			name: "changeset",
+			data: `<changeset id="1" open="true"><tag k="a" v="b"/></changeset>`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDecoder(osm.MetadataNone)
+			if fast := d.decodeFast([]byte(tc.data)) != nil; fast != tc.fast {
+				t.Errorf("incorrect decoding path: %v", fast)
+			}
+
+			o, err := d.Decode([]byte(tc.data))
+			if err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+
+			expected, err := decodeElement([]byte(tc.data), osm.MetadataNone)
+			if err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+
+			if !reflect.DeepEqual(o, expected) {
+				t.Errorf("incorrect object:\n%+v\n%+v", o, expected)
+			}
+		})
+	}
+}
+
+func TestDecoder_Decode_errors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "invalid id",
+			data: `<node id="one"/>`,
+		},
+		{
+			name: "invalid timestamp",
+			data: `<node id="1" timestamp="2014-02-30T00:43:05Z"/>`,
+		},
+		{
+			name: "unknown entity",
+			data: `<node id="1"><tag k="a" v="&b;"/></node>`,
+		},
+		{
+			name: "invalid character",
+			data: "<node id=\"1\" user=\"\x01\"/>",
+		},
+		{
+			name: "invalid utf8",
+			data: "<node id=\"1\" user=\"\xff\"/>",
+		},
+		{
+			name: "mismatched end",
+			data: `<way id="1"><nd ref="2"></tag></way>`,
+		},
+		{
+			name: "element type case",
+			data: `<Node id="1"/>`,
+		},
+		{
+			name: "orientation overflow",
+			data: `<relation id="1"><member type="way" ref="2" orientation="128"/></relation>`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDecoder(osm.MetadataNone)
+			if d.decodeFast([]byte(tc.data)) != nil {
+				t.Errorf("should not decode directly")
+			}
+
+			if _, err := d.Decode([]byte(tc.data)); err == nil {
+				t.Errorf("should return error")
+			}
+		})
+	}
+}
+
+func TestDecoder_skipMetadata(t *testing.T) {
+	data := []byte(`<relation id="1" version="2" timestamp="2014-04-10T00:43:05Z" changeset="3" user="user" uid="4" visible="true"><member type="node" ref="5" version="6" changeset="7"/></relation>`)
+
+	for _, skip := range []osm.MetadataMask{osm.MetadataNone, osm.MetadataAll, osm.MetadataUser | osm.MetadataVisible} {
+		d := newDecoder(skip)
+
+		o, err := d.Decode(data)
+		if err != nil {
+			t.Fatalf("decode error: %v", err)
+		}
+
+		expected, err := decodeElement(data, skip)
+		if err != nil {
+			t.Fatalf("decode error: %v", err)
+		}
+
+		if !reflect.DeepEqual(o, expected) {
+			t.Errorf("incorrect object for %v:\n%+v\n%+v", skip, o, expected)
+		}
+	}
+}
+
+func TestParseFloat(t *testing.T) {
+	cases := []string{
+		"0", "-0", "1", "42.5063730", "-71.4838220", "1.", ".5", "0.1",
+		"123456789012345", "1234567890123456", "0.000000000000001",
+		"1.23e4", "-1.7976931348623157e308",
+	}
+
+	for _, tc := range cases {
+		f, ok := parseFloat([]byte(tc))
+		if !ok {
+			t.Errorf("%s: should parse", tc)
+		}
+
+		if expected, _ := strconv.ParseFloat(tc, 64); f != expected {
+			t.Errorf("%s: incorrect value: %v != %v", tc, f, expected)
+		}
+	}
+
+	for _, tc := range []string{".", "-", "1.2.3", "1,5", " 1"} {
+		if _, ok := parseFloat([]byte(tc)); ok {
+			t.Errorf("%s: should not parse", tc)
+		}
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	cases := []struct {
+		data    string
+		bitSize int
+		value   int64
+		ok      bool
+	}{
+		{"", 64, 0, true},
+		{"123", 64, 123, true},
+		{"-123", 64, -123, true},
+		{"+5", 64, 5, true},
+		{"9223372036854775807", 64, 9223372036854775807, true},
+		{"9223372036854775808", 64, 0, false},
+		{"127", 8, 127, true},
+		{"-128", 8, -128, true},
+		{"-129", 8, 0, false},
+		{"-", 64, 0, false},
+		{"1a", 64, 0, false},
+	}
+
+	for _, tc := range cases {
+		v, ok := parseInt([]byte(tc.data), tc.bitSize)
+		if ok != tc.ok || (ok && v != tc.value) {
+			t.Errorf("%s: incorrect result: %v %v", tc.data, v, ok)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package osmxml
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/ich5003/small-osm"
+)
+
+// DiffScanner reads a stream of Overpass augmented diff actions.
+// Successive calls to the Scan method will step through the actions.
+// The rest of the document, the attributes, note, meta, remark and any
+// changesets, is available using the Header method.
+//
+// Scanning stops unrecoverably at EOF, the first I/O error, the first xml error or
+// the context being cancelled.
+type DiffScanner struct {
+	ctx    context.Context
+	done   context.CancelFunc
+	closed bool
+
+	decoder *xml.Decoder
+	header  osm.Diff
+	next    osm.Action
+	err     error
+}
+
+// NewDiffScanner returns a new DiffScanner to read from r.
+func NewDiffScanner(ctx context.Context, r io.Reader) *DiffScanner {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	s := &DiffScanner{
+		decoder: xml.NewDecoder(r),
+	}
+
+	s.ctx, s.done = context.WithCancel(ctx)
+	return s
+}
+
+// Close causes all future calls to Scan to return false.
+// Does not close the underlying reader.
+func (s *DiffScanner) Close() error {
+	s.closed = true
+	s.done()
+
+	return nil
+}
+
+// Scan advances the DiffScanner to the next action, which will then be available
+// through the Action method. It returns false when the scan stops, either
+// by reaching the end of the input, an io error, an xml error or the context
+// being cancelled. After Scan returns false, the Err method will return any
+// error that occurred during scanning, except if it was io.EOF, Err will
+// return nil.
+func (s *DiffScanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		if s.ctx.Err() != nil {
+			return false
+		}
+
+		t, err := s.decoder.Token()
+		if err != nil {
+			s.err = err
+			return false
+		}
+
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch se.Name.Local {
+		case "osm":
+			err = s.readAttrs(se)
+		case "note":
+			err = s.decoder.DecodeElement(&s.header.Note, &se)
+		case "meta":
+			s.header.Meta = &osm.DiffMeta{}
+			err = s.decoder.DecodeElement(s.header.Meta, &se)
+		case "remark":
+			err = s.decoder.DecodeElement(&s.header.Remark, &se)
+		case "changeset":
+			cs := &osm.Changeset{}
+			err = s.decoder.DecodeElement(&cs, &se)
+			s.header.Changesets = append(s.header.Changesets, cs)
+		case "action":
+			s.next = osm.Action{}
+			err = s.decoder.DecodeElement(&s.next, &se)
+			if err == nil {
+				return true
+			}
+		}
+
+		if err != nil {
+			s.err = err
+			return false
+		}
+	}
+}
+
+func (s *DiffScanner) readAttrs(se xml.StartElement) error {
+	for _, a := range se.Attr {
+		switch a.Name.Local {
+		case "version":
+			v, err := strconv.ParseFloat(a.Value, 64)
+			if err != nil {
+				return err
+			}
+			s.header.Version = v
+		case "generator":
+			s.header.Generator = a.Value
+		}
+	}
+
+	return nil
+}
+
+// Action returns the most recent action generated by a call to Scan.
+func (s *DiffScanner) Action() osm.Action {
+	return s.next
+}
+
+// Header returns the diff without the actions, containing the attributes,
+// note, meta, remark and changesets read so far. Overpass may include
+// a remark at the end of the document if there was a runtime error.
+func (s *DiffScanner) Header() *osm.Diff {
+	return &s.header
+}
+
+// Err returns the first non-EOF error that was encountered by the DiffScanner.
+func (s *DiffScanner) Err() error {
+	if s.err == io.EOF {
+		return nil
+	}
+
+	if s.err != nil {
+		return s.err
+	}
+
+	if s.closed {
+		return osm.ErrScannerClosed
+	}
+
+	return s.ctx.Err()
+}
+
+// DiffEncoder writes a stream of Overpass augmented diff actions.
+//
+// The DiffEncoder is not safe for parallel use.
+type DiffEncoder struct {
+	// Header contains the attributes, note, meta and remark of the diff.
+	// It can be modified until the first action is encoded. The changesets
+	// are written after the actions, when the encoder is closed, and
+	// the header actions are ignored.
+	Header osm.Diff
+
+	w       io.Writer
+	e       *xml.Encoder
+	started bool
+	closed  bool
+	err     error
+}
+
+// NewDiffEncoder returns a new diff encoder that writes to w.
+// The header version defaults to 0.6.
+func NewDiffEncoder(w io.Writer) *DiffEncoder {
+	return &DiffEncoder{
+		Header: osm.Diff{Version: 0.6},
+		w:      w,
+		e:      xml.NewEncoder(w),
+	}
+}
+
+// Indent sets the encoder to generate xml in which each element begins
+// on a new indented line, see xml.Encoder.Indent.
+func (e *DiffEncoder) Indent(prefix, indent string) {
+	e.e.Indent(prefix, indent)
+}
+
+// Encode writes the action to the stream.
+func (e *DiffEncoder) Encode(a osm.Action) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	e.err = e.e.EncodeElement(a, xml.StartElement{Name: xml.Name{Local: "action"}})
+	return e.err
+}
+
+// EncodeScanner encodes all the actions returned by the scanner.
+// The scanner is not closed.
+func (e *DiffEncoder) EncodeScanner(s *DiffScanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Action()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close writes the changesets and the end of the document and
+// flushes any buffered data. It does not close the underlying writer.
+func (e *DiffEncoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+	e.closed = true
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if err := e.e.Encode(e.Header.Changesets); err != nil {
+		return err
+	}
+
+	if err := e.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "osm"}}); err != nil {
+		return err
+	}
+
+	return e.e.Flush()
+}
+
+// start writes the xml declaration, the osm element, note, meta and remark.
+func (e *DiffEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		e.err = err
+		return err
+	}
+
+	h := &osm.OSM{
+		Version:   e.Header.Version,
+		Generator: e.Header.Generator,
+	}
+
+	if e.err = e.e.EncodeToken(headerStart("osm", h)); e.err != nil {
+		return e.err
+	}
+
+	if e.Header.Note != "" {
+		if e.err = e.e.EncodeElement(e.Header.Note, xml.StartElement{Name: xml.Name{Local: "note"}}); e.err != nil {
+			return e.err
+		}
+	}
+
+	if e.Header.Meta != nil {
+		if e.err = e.e.EncodeElement(e.Header.Meta, xml.StartElement{Name: xml.Name{Local: "meta"}}); e.err != nil {
+			return e.err
+		}
+	}
+
+	if e.Header.Remark != "" {
+		e.err = e.e.EncodeElement(e.Header.Remark, xml.StartElement{Name: xml.Name{Local: "remark"}})
+	}
+
+	return e.err
+}
@@ -0,0 +1,131 @@
+package osmxml
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestDiffScanner(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/annotated_diff.xml")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	expected := &osm.Diff{}
+	if err := xml.Unmarshal(data, expected); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	diff := scanDiff(t, bytes.NewReader(data))
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("diffs not equal")
+	}
+
+	if diff.Version != 0.6 || diff.Generator != "Overpass API" {
+		t.Errorf("incorrect attributes: %v %v", diff.Version, diff.Generator)
+	}
+
+	if diff.Meta == nil || diff.Meta.OSMBase != "2017-01-10T22:29:02Z" {
+		t.Errorf("incorrect meta: %v", diff.Meta)
+	}
+
+	if !strings.HasPrefix(diff.Note, "The data included") {
+		t.Errorf("incorrect note: %v", diff.Note)
+	}
+}
+
+func TestDiffEncoder(t *testing.T) {
+	data, err := ioutil.ReadFile("../testdata/annotated_diff.xml")
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	expected := scanDiff(t, bytes.NewReader(data))
+	expected.Remark = "runtime error: timeout"
+	expected.Changesets = osm.Changesets{{ID: 123, Tags: osm.Tags{{Key: "comment", Value: "test"}}}}
+
+	buf := &bytes.Buffer{}
+	enc := NewDiffEncoder(buf)
+	enc.Header = *expected
+	for _, a := range expected.Actions {
+		if err := enc.Encode(a); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	// through the scanner and the osm.Diff
+	encoded := buf.Bytes()
+	if diff := scanDiff(t, bytes.NewReader(encoded)); !reflect.DeepEqual(diff, expected) {
+		t.Errorf("scanned diffs not equal")
+	}
+
+	diff := &osm.Diff{}
+	if err := xml.Unmarshal(encoded, diff); err != nil {
+		t.Fatalf("unmarshal error: %v", err)
+	}
+
+	if !reflect.DeepEqual(diff, expected) {
+		t.Errorf("unmarshalled diffs not equal")
+	}
+
+	marshalled, err := xml.Marshal(diff)
+	if err != nil {
+		t.Fatalf("marshal error: %v", err)
+	}
+
+	if !bytes.Equal(append([]byte(xml.Header), marshalled...), encoded) {
+		t.Errorf("encoder and marshal output not equal")
+	}
+}
+
+func TestDiffEncoder_empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := NewDiffEncoder(buf)
+	enc.Header.Meta = &osm.DiffMeta{OSMBase: "2017-01-10T22:29:02Z"}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	expected := xml.Header + `<osm version="0.6"><meta osm_base="2017-01-10T22:29:02Z"></meta></osm>`
+	if s := buf.String(); s != expected {
+		t.Errorf("incorrect output: %v", s)
+	}
+
+	if err := enc.Encode(osm.Action{}); err != ErrEncoderClosed {
+		t.Errorf("should return closed error: %v", err)
+	}
+}
+
+func scanDiff(t testing.TB, r *bytes.Reader) *osm.Diff {
+	t.Helper()
+
+	scanner := NewDiffScanner(context.Background(), r)
+	defer scanner.Close()
+
+	var actions osm.Actions
+	for scanner.Scan() {
+		actions = append(actions, scanner.Action())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	diff := scanner.Header()
+	diff.XMLName = xml.Name{Local: "osm"}
+	diff.Actions = actions
+
+	return diff
+}
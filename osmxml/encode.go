@@ -0,0 +1,177 @@
+package osmxml
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/ich5003/small-osm"
+)
+
+// ErrEncoderClosed is returned when encoding after the encoder is closed.
+var ErrEncoderClosed = errors.New("osmxml: encoder closed")
+
+// Encoder writes a stream of osm data as an osm xml document.
+// Objects are written as they are encoded so large files, e.g. a
+// converted pbf file, can be written with constant memory.
+//
+// The Encoder is not safe for parallel use.
+type Encoder struct {
+	// Header contains the attributes of the osm element and the bounds.
+	// It can be modified until the first object is encoded. Only the
+	// attributes and bounds are written, the elements are ignored.
+	Header osm.OSM
+
+	w       io.Writer
+	e       *xml.Encoder
+	started bool
+	closed  bool
+	err     error
+}
+
+// NewEncoder returns a new encoder that writes to w.
+// The header version defaults to 0.6.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		Header: osm.OSM{Version: 0.6},
+		w:      w,
+		e:      xml.NewEncoder(w),
+	}
+}
+
+// Indent sets the encoder to generate xml in which each element begins
+// on a new indented line, see xml.Encoder.Indent.
+func (e *Encoder) Indent(prefix, indent string) {
+	e.e.Indent(prefix, indent)
+}
+
+// Encode writes the object to the stream. The interface is implemented by:
+//	*osm.Bounds
+//	*osm.Node
+//	*osm.Way
+//	*osm.Relation
+//	*osm.Changeset
+//	*osm.Note
+//	*osm.User
+// Bounds encoded before anything else are used as the header bounds.
+func (e *Encoder) Encode(o osm.Object) error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+
+	if e.err != nil {
+		return e.err
+	}
+
+	switch o := o.(type) {
+	case *osm.Bounds:
+		if !e.started && e.Header.Bounds == nil {
+			e.Header.Bounds = o
+			return nil
+		}
+
+		if err := e.start(); err != nil {
+			return err
+		}
+
+		e.err = e.e.EncodeElement(o, xml.StartElement{Name: xml.Name{Local: "bounds"}})
+	case *osm.Node, *osm.Way, *osm.Relation, *osm.Changeset, *osm.Note, *osm.User:
+		if err := e.start(); err != nil {
+			return err
+		}
+
+		e.err = e.e.Encode(o)
+	default:
+		return fmt.Errorf("osmxml: unsupported object type: %T", o)
+	}
+
+	return e.err
+}
+
+// EncodeScanner encodes all the objects returned by the scanner.
+// The scanner is not closed.
+func (e *Encoder) EncodeScanner(s osm.Scanner) error {
+	for s.Scan() {
+		if err := e.Encode(s.Object()); err != nil {
+			return err
+		}
+	}
+
+	return s.Err()
+}
+
+// Close writes the end of the document and flushes any buffered data.
+// It does not close the underlying writer.
+func (e *Encoder) Close() error {
+	if e.closed {
+		return ErrEncoderClosed
+	}
+	e.closed = true
+
+	if e.err != nil {
+		return e.err
+	}
+
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if err := e.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: "osm"}}); err != nil {
+		return err
+	}
+
+	return e.e.Flush()
+}
+
+// start writes the xml declaration, the osm element and bounds.
+func (e *Encoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		e.err = err
+		return err
+	}
+
+	if err := e.e.EncodeToken(headerStart("osm", &e.Header)); err != nil {
+		e.err = err
+		return err
+	}
+
+	if e.Header.Bounds != nil {
+		e.err = e.e.EncodeElement(e.Header.Bounds, xml.StartElement{Name: xml.Name{Local: "bounds"}})
+	}
+
+	return e.err
+}
+
+// headerStart returns the start of the root element with the header attributes.
+func headerStart(name string, h *osm.OSM) xml.StartElement {
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	if h.Version != 0 {
+		start.Attr = append(start.Attr, xml.Attr{
+			Name:  xml.Name{Local: "version"},
+			Value: strconv.FormatFloat(h.Version, 'g', -1, 64),
+		})
+	}
+
+	attrs := []struct{ name, value string }{
+		{"generator", h.Generator},
+		{"copyright", h.Copyright},
+		{"attribution", h.Attribution},
+		{"license", h.License},
+	}
+
+	for _, a := range attrs {
+		if a.value != "" {
+			start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: a.name}, Value: a.value})
+		}
+	}
+
+	return start
+}
@@ -0,0 +1,128 @@
+package osmxml
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"context"
+	"encoding/xml"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ich5003/small-osm"
+)
+
+func TestEncoder(t *testing.T) {
+	f, err := os.Open("../testdata/andorra-latest.osm.bz2")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := New(context.Background(), bzip2.NewReader(f))
+	defer scanner.Close()
+
+	var expected osm.Objects
+	for scanner.Scan() {
+		expected = append(expected, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	enc.Header.Generator = "test"
+	for _, o := range expected {
+		if err := enc.Encode(o); err != nil {
+			t.Fatalf("encode error: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	o := &osm.OSM{}
+	if err := xml.Unmarshal(buf.Bytes(), o); err != nil {
+		t.Fatalf("invalid xml: %v", err)
+	}
+
+	if o.Version != 0.6 || o.Generator != "test" || o.Bounds == nil {
+		t.Errorf("incorrect header: %v %v %v", o.Version, o.Generator, o.Bounds)
+	}
+
+	scanner = New(context.Background(), buf)
+	i := 0
+	for scanner.Scan() {
+		if i >= len(expected) {
+			t.Fatalf("too many objects")
+		}
+
+		if o := scanner.Object(); !reflect.DeepEqual(o, expected[i]) {
+			t.Fatalf("objects not equal:\n%+v\n%+v", o, expected[i])
+		}
+		i++
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner error: %v", err)
+	}
+
+	if i != len(expected) {
+		t.Errorf("incorrect number of objects: %v != %v", i, len(expected))
+	}
+}
+
+func TestEncoder_header(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	enc.Header.Copyright = osm.Copyright
+
+	err := enc.EncodeScanner(New(context.Background(), changesetReader()))
+	if err != nil {
+		t.Fatalf("encode error: %v", err)
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	if err := enc.Encode(&osm.Node{}); err != ErrEncoderClosed {
+		t.Errorf("should return closed error: %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<osm version="0.6" copyright="OpenStreetMap and contributors"><changeset id="41226352"`
+	if s := buf.String(); !strings.HasPrefix(s, expected) {
+		t.Errorf("incorrect start: %v", s[:len(expected)])
+	}
+
+	scanner := New(context.Background(), buf)
+	count := 0
+	for scanner.Scan() {
+		count++
+	}
+
+	if count != 2 {
+		t.Errorf("incorrect number of changesets: %v", count)
+	}
+}
+
+func TestEncoder_empty(t *testing.T) {
+	buf := &bytes.Buffer{}
+	enc := NewEncoder(buf)
+	enc.Encode(&osm.Bounds{MinLat: 1, MaxLat: 2, MinLon: 3, MaxLon: 4})
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+
+	expected := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<osm version="0.6"><bounds minlat="1" maxlat="2" minlon="3" maxlon="4"></bounds></osm>`
+	if s := buf.String(); s != expected {
+		t.Errorf("incorrect output: %v", s)
+	}
+}
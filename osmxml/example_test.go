@@ -19,3 +19,34 @@ func ExampleScanner() {
 		fmt.Fprintln(os.Stderr, "reading standard input:", err)
 	}
 }
+
+func ExampleEncoder() {
+	scanner := osmxml.New(context.Background(), os.Stdin)
+	defer scanner.Close()
+
+	encoder := osmxml.NewEncoder(os.Stdout)
+	encoder.Header.Generator = "my-program"
+	encoder.Header.Copyright = osm.Copyright
+
+	if err := encoder.EncodeScanner(scanner); err != nil {
+		fmt.Fprintln(os.Stderr, "encoding error:", err)
+	}
+
+	// Close must be called to end the document.
+	if err := encoder.Close(); err != nil {
+		fmt.Fprintln(os.Stderr, "encoding error:", err)
+	}
+}
+
+func ExampleChangeScanner() {
+	scanner := osmxml.NewChangeScanner(context.Background(), os.Stdin)
+	defer scanner.Close()
+
+	for scanner.Scan() {
+		fmt.Println(scanner.Action(), scanner.Object().ObjectID())
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, "reading standard input:", err)
+	}
+}
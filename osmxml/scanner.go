@@ -5,6 +5,7 @@ import (
 	"encoding/xml"
 	"io"
 	"strings"
+	"sync"
 
 	"github.com/ich5003/small-osm"
 )
@@ -14,6 +15,10 @@ var _ osm.Scanner = &Scanner{}
 // Scanner provides a convenient interface reading a stream of osm data
 // from a file or url. Successive calls to the Scan method will step through the data.
 //
+// Nodes, ways, relations and bounds are decoded directly from the xml bytes.
+// Other objects, and elements with unusual content, are decoded using
+// encoding/xml. The objects are the same either way.
+//
 // Scanning stops unrecoverably at EOF, the first I/O error, the first xml error or
 // the context being cancelled. When a scan stops, the reader may have advanced
 // arbitrarily far past the last token.
@@ -21,15 +26,52 @@ var _ osm.Scanner = &Scanner{}
 // The Scanner API is based on bufio.Scanner
 // https://golang.org/pkg/bufio/#Scanner
 type Scanner struct {
-	ctx    context.Context
-	done   context.CancelFunc
-	closed bool
+	// SkipMetadata are the element metadata fields of nodes, ways and
+	// relations that will not be decoded. The attributes are dropped
+	// before the element is unmarshalled. Skipped fields are left as
+	// their zero value.
+	SkipMetadata osm.MetadataMask
+
+	// Procs is the number of goroutines used to decode the elements.
+	// The input is split into batches of elements that are decoded in
+	// parallel and returned in order. Values less than 2 decode in the
+	// goroutine calling Scan. Must be set before the first call to Scan.
+	Procs int
+
+	ctx     context.Context
+	done    context.CancelFunc
+	closed  bool
+	started bool
+	wg      sync.WaitGroup // the decoding goroutines
+
+	tokenizer *tokenizer
+	decoder   *decoder
+
+	// for parallel decoding
+	batches chan chan batch
+	current batch
+	index   int
 
-	decoder *xml.Decoder
-	next    osm.Object
+	next osm.Object
+	err  error
+}
+
+// batch is a group of decoded objects, ending with the first error.
+type batch struct {
+	objects []osm.Object
 	err     error
 }
 
+// job is a group of raw elements to be decoded into the result.
+type job struct {
+	data   []byte
+	ends   []int
+	result chan batch
+}
+
+// batchSize is the approximate size in bytes of the elements decoded together.
+const batchSize = 1 << 17
+
 // New returns a new Scanner to read from r.
 func New(ctx context.Context, r io.Reader) *Scanner {
 	if ctx == nil {
@@ -37,7 +79,7 @@ func New(ctx context.Context, r io.Reader) *Scanner {
 	}
 
 	s := &Scanner{
-		decoder: xml.NewDecoder(r),
+		tokenizer: newTokenizer(r),
 	}
 
 	s.ctx, s.done = context.WithCancel(ctx)
@@ -45,10 +87,13 @@ func New(ctx context.Context, r io.Reader) *Scanner {
 }
 
 // Close causes all future calls to Scan to return false.
-// Does not close the underlying reader.
+// Does not close the underlying reader. When decoding in parallel
+// Close waits for the decoding goroutines, but not for a pending read.
+// The reading goroutine stops when that read returns.
 func (s *Scanner) Close() error {
 	s.closed = true
 	s.done()
+	s.wg.Wait()
 
 	return nil
 }
@@ -60,68 +105,259 @@ func (s *Scanner) Close() error {
 // error that occurred during scanning, except if it was io.EOF, Err will
 // return nil.
 func (s *Scanner) Scan() bool {
-	if s.err != nil {
+	if !s.started {
+		s.start()
+	}
+
+	if s.err != nil || s.ctx.Err() != nil {
+		return false
+	}
+
+	var err error
+	if s.batches != nil {
+		s.next, err = s.nextParallel()
+	} else {
+		s.next, err = s.nextObject()
+	}
+
+	if err != nil {
+		if s.ctx.Err() == nil {
+			s.err = err
+		}
+
 		return false
 	}
 
-Loop:
+	return true
+}
+
+// start starts the decoding goroutines if decoding in parallel.
+func (s *Scanner) start() {
+	s.started = true
+	if s.Procs < 2 {
+		s.decoder = newDecoder(s.SkipMetadata)
+		return
+	}
+
+	jobs := make(chan job, s.Procs)
+	s.batches = make(chan chan batch, 2*s.Procs)
+
+	s.wg.Add(s.Procs)
+	for i := 0; i < s.Procs; i++ {
+		d := newDecoder(s.SkipMetadata)
+		go func() {
+			defer s.wg.Done()
+			for {
+				select {
+				case j, ok := <-jobs:
+					if !ok {
+						return
+					}
+					j.result <- d.decodeBatch(j.data, j.ends)
+				case <-s.ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	// The reading goroutine is not waited for by Close since it
+	// can be blocked reading, it checks the context between reads.
+	go func() {
+		defer close(jobs)
+		defer close(s.batches)
+
+		s.split(jobs)
+	}()
+}
+
+// nextObject reads and decodes the next object in the calling goroutine.
+func (s *Scanner) nextObject() (osm.Object, error) {
 	for {
-		if s.ctx.Err() != nil {
-			return false
+		data, err := s.tokenizer.Next()
+		if err != nil {
+			return nil, err
 		}
 
-		t, err := s.decoder.Token()
+		o, err := s.decoder.Decode(data)
 		if err != nil {
-			s.err = err
-			return false
+			return nil, err
+		}
+
+		if o != nil {
+			return o, nil
+		}
+	}
+}
+
+// split reads the raw elements into jobs for the decoding goroutines.
+// The result channels are sent in the same order to maintain
+// the order of the objects.
+func (s *Scanner) split(jobs chan<- job) {
+	for {
+		j := job{
+			data:   make([]byte, 0, batchSize+batchSize/4),
+			result: make(chan batch, 1),
+		}
+
+		var err error
+		for len(j.data) < batchSize {
+			var data []byte
+			data, err = s.tokenizer.Next()
+			if err != nil {
+				break
+			}
+
+			j.data = append(j.data, data...)
+			j.ends = append(j.ends, len(j.data))
+		}
+
+		if len(j.ends) > 0 {
+			select {
+			case jobs <- j:
+			case <-s.ctx.Done():
+				return
+			}
+
+			select {
+			case s.batches <- j.result:
+			case <-s.ctx.Done():
+				return
+			}
+		}
+
+		if err != nil {
+			result := make(chan batch, 1)
+			result <- batch{err: err}
+
+			select {
+			case s.batches <- result:
+			case <-s.ctx.Done():
+			}
+			return
+		}
+	}
+}
+
+// nextParallel returns the next object decoded by the decoding goroutines.
+func (s *Scanner) nextParallel() (osm.Object, error) {
+	for s.index >= len(s.current.objects) {
+		if s.current.err != nil {
+			return nil, s.current.err
+		}
+
+		var (
+			result chan batch
+			ok     bool
+		)
+		select {
+		case result, ok = <-s.batches:
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
 		}
 
-		se, ok := t.(xml.StartElement)
 		if !ok {
-			continue
+			return nil, io.EOF
 		}
 
-		s.next = nil
-		switch strings.ToLower(se.Name.Local) {
-		case "bounds":
-			bounds := &osm.Bounds{}
-			err = s.decoder.DecodeElement(&bounds, &se)
-			s.next = bounds
-		case "node":
-			node := &osm.Node{}
-			err = s.decoder.DecodeElement(&node, &se)
-			s.next = node
-		case "way":
-			way := &osm.Way{}
-			err = s.decoder.DecodeElement(&way, &se)
-			s.next = way
-		case "relation":
-			relation := &osm.Relation{}
-			err = s.decoder.DecodeElement(&relation, &se)
-			s.next = relation
-		case "changeset":
-			cs := &osm.Changeset{}
-			err = s.decoder.DecodeElement(&cs, &se)
-			s.next = cs
-		case "note":
-			n := &osm.Note{}
-			err = s.decoder.DecodeElement(&n, &se)
-			s.next = n
-		case "user":
-			u := &osm.User{}
-			err = s.decoder.DecodeElement(&u, &se)
-			s.next = u
-		default:
-			continue Loop
+		select {
+		case s.current = <-result:
+		case <-s.ctx.Done():
+			return nil, s.ctx.Err()
 		}
+		s.index = 0
+	}
 
+	o := s.current.objects[s.index]
+	s.index++
+
+	return o, nil
+}
+
+// decodeBatch decodes the elements of a job, stopping at the first error.
+func (d *decoder) decodeBatch(data []byte, ends []int) batch {
+	objects := make([]osm.Object, 0, len(ends))
+
+	start := 0
+	for _, end := range ends {
+		o, err := d.Decode(data[start:end])
 		if err != nil {
-			s.err = err
-			return false
+			return batch{objects: objects, err: err}
 		}
 
-		return true
+		if o != nil {
+			objects = append(objects, o)
+		}
+		start = end
+	}
+
+	return batch{objects: objects}
+}
+
+// decodeObject decodes the start element if it is one of the osm objects.
+// It returns nil if the element is not an object.
+func decodeObject(d *xml.Decoder, se *xml.StartElement, skip osm.MetadataMask) (osm.Object, error) {
+	var err error
+	switch strings.ToLower(se.Name.Local) {
+	case "bounds":
+		bounds := &osm.Bounds{}
+		err = d.DecodeElement(&bounds, se)
+		return bounds, err
+	case "node":
+		node := &osm.Node{}
+		skipMetadata(se, skip)
+		err = d.DecodeElement(&node, se)
+		return node, err
+	case "way":
+		way := &osm.Way{}
+		skipMetadata(se, skip)
+		err = d.DecodeElement(&way, se)
+		return way, err
+	case "relation":
+		relation := &osm.Relation{}
+		skipMetadata(se, skip)
+		err = d.DecodeElement(&relation, se)
+		return relation, err
+	case "changeset":
+		cs := &osm.Changeset{}
+		err = d.DecodeElement(&cs, se)
+		return cs, err
+	case "note":
+		n := &osm.Note{}
+		err = d.DecodeElement(&n, se)
+		return n, err
+	case "user":
+		u := &osm.User{}
+		err = d.DecodeElement(&u, se)
+		return u, err
+	}
+
+	return nil, nil
+}
+
+// metadataAttrs maps the element attribute names to their metadata field.
+var metadataAttrs = map[string]osm.MetadataMask{
+	"timestamp": osm.MetadataTimestamp,
+	"changeset": osm.MetadataChangeset,
+	"uid":       osm.MetadataUserID,
+	"user":      osm.MetadataUser,
+	"visible":   osm.MetadataVisible,
+}
+
+// skipMetadata removes the attributes of the skipped metadata fields
+// from the start element so they are never parsed.
+func skipMetadata(se *xml.StartElement, skip osm.MetadataMask) {
+	if skip == osm.MetadataNone {
+		return
+	}
+
+	attrs := se.Attr[:0]
+	for _, a := range se.Attr {
+		if skip&metadataAttrs[a.Name.Local] == 0 {
+			attrs = append(attrs, a)
+		}
 	}
+	se.Attr = attrs
 }
 
 // Object returns the most recent token generated by a call to Scan
@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"compress/bzip2"
 	"context"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ich5003/small-osm"
 )
@@ -159,6 +163,46 @@ func TestScanner_bounds(t *testing.T) {
 	}
 }
 
+func TestScanner_SkipMetadata(t *testing.T) {
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<osm>
+	<node id="1" lat="2" lon="3" version="4" timestamp="2014-04-10T00:43:05Z" changeset="5" user="user" uid="6" visible="true"></node>
+	<way id="7" version="8" timestamp="2014-04-10T00:43:05Z" changeset="9" user="user" uid="10" visible="true"><nd ref="1"/></way>
+</osm>`)
+
+	scanner := New(context.Background(), bytes.NewReader(data))
+	scanner.SkipMetadata = osm.MetadataTimestamp | osm.MetadataUser
+	defer scanner.Close()
+
+	if v := scanner.Scan(); !v {
+		t.Fatalf("should read first scan: %v", scanner.Err())
+	}
+
+	n := scanner.Object().(*osm.Node)
+	if !n.Timestamp.IsZero() || n.User != "" {
+		t.Errorf("should skip timestamp and user: %v", n)
+	}
+
+	if n.ID != 1 || n.Lat != 2 || n.Lon != 3 || n.Version != 4 ||
+		n.ChangesetID != 5 || n.UserID != 6 || !n.Visible {
+		t.Errorf("should decode other fields: %v", n)
+	}
+
+	if v := scanner.Scan(); !v {
+		t.Fatalf("should read second scan: %v", scanner.Err())
+	}
+
+	w := scanner.Object().(*osm.Way)
+	if !w.Timestamp.IsZero() || w.User != "" {
+		t.Errorf("should skip timestamp and user: %v", w)
+	}
+
+	if w.ID != 7 || w.Version != 8 || w.ChangesetID != 9 ||
+		w.UserID != 10 || !w.Visible || len(w.Nodes) != 1 {
+		t.Errorf("should decode other fields: %v", w)
+	}
+}
+
 func TestAndorra(t *testing.T) {
 	f, err := os.Open("../testdata/andorra-latest.osm.bz2")
 	if err != nil {
@@ -202,6 +246,143 @@ func TestAndorra(t *testing.T) {
 	}
 }
 
+func TestScanner_Procs(t *testing.T) {
+	f, err := os.Open("../testdata/andorra-latest.osm.bz2")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	data, err := ioutil.ReadAll(bzip2.NewReader(f))
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	scanner := New(context.Background(), bytes.NewReader(data))
+	defer scanner.Close()
+
+	var expected osm.Objects
+	for scanner.Scan() {
+		expected = append(expected, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner returned error: %v", err)
+	}
+
+	scanner = New(context.Background(), bytes.NewReader(data))
+	scanner.Procs = 4
+	defer scanner.Close()
+
+	var objects osm.Objects
+	for scanner.Scan() {
+		objects = append(objects, scanner.Object())
+	}
+
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanner returned error: %v", err)
+	}
+
+	if !reflect.DeepEqual(objects, expected) {
+		t.Errorf("should return the same objects in order")
+	}
+}
+
+func TestScanner_ProcsErr(t *testing.T) {
+	scanner := New(context.Background(), changesetReaderErr())
+	scanner.Procs = 2
+
+	if v := scanner.Scan(); !v {
+		t.Fatalf("should read first scan: %v", scanner.Err())
+	}
+
+	if cs := scanner.Object().(*osm.Changeset); cs.ID != 41226352 {
+		t.Fatalf("did not scan correctly, got %v", cs)
+	}
+
+	if v := scanner.Scan(); v {
+		t.Fatalf("should be closed for second scan: %v", scanner.Err())
+	}
+
+	if v := scanner.Err(); v == nil {
+		t.Errorf("incorrect error, got %v", v)
+	}
+
+	scanner.Close()
+	if v := scanner.Err(); v == osm.ErrScannerClosed {
+		t.Errorf("should return xml error not closed error, got %v", v)
+	}
+}
+
+func TestScanner_ProcsClose(t *testing.T) {
+	f, err := os.Open("../testdata/andorra-latest.osm.bz2")
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer f.Close()
+
+	scanner := New(context.Background(), bzip2.NewReader(f))
+	scanner.Procs = 2
+
+	for i := 0; i < 10; i++ {
+		if v := scanner.Scan(); !v {
+			t.Fatalf("should scan: %v", scanner.Err())
+		}
+	}
+
+	scanner.Close()
+
+	if v := scanner.Scan(); v {
+		t.Fatalf("should be closed")
+	}
+
+	if v := scanner.Err(); v != osm.ErrScannerClosed {
+		t.Errorf("incorrect error, got %v", v)
+	}
+}
+
+func TestScanner_ProcsClose_blockedReader(t *testing.T) {
+	r, w := io.Pipe()
+	defer w.Close()
+
+	written := make(chan struct{})
+	go func() {
+		w.Write([]byte(`<osm>`))
+		for i := 1; i <= 10000; i++ {
+			fmt.Fprintf(w, `<node id="%d" lat="1" lon="2"/>`, i)
+		}
+		close(written)
+		// the reader now blocks waiting for more data
+	}()
+
+	scanner := New(context.Background(), r)
+	scanner.Procs = 2
+
+	if v := scanner.Scan(); !v {
+		t.Fatalf("should scan: %v", scanner.Err())
+	}
+
+	// give the reading goroutine time to block on the pipe
+	<-written
+	time.Sleep(50 * time.Millisecond)
+
+	closed := make(chan struct{})
+	go func() {
+		scanner.Close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("close should not wait for the blocked reader")
+	}
+
+	if v := scanner.Err(); v != osm.ErrScannerClosed {
+		t.Errorf("incorrect error, got %v", v)
+	}
+}
+
 func BenchmarkAndorra(b *testing.B) {
 	f, err := os.Open("../testdata/andorra-latest.osm.bz2")
 	if err != nil {
@@ -0,0 +1,360 @@
+package osmxml
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	minReadSize    = 1 << 16
+	maxEmptyReads  = 100
+	maxErrorData   = 64
+	initialBufSize = 1 << 17
+)
+
+var errUnexpectedEOF = errors.New("osmxml: unexpected EOF")
+
+// token kinds returned by readToken.
+const (
+	tokenStart = iota
+	tokenEnd
+	tokenSelfClosing
+	tokenOther // comments, processing instructions, cdata and doctypes
+)
+
+// A tokenizer splits an osm xml stream into the raw bytes of the object
+// elements, e.g. <node ...>...</node>. It only understands enough xml
+// to find where elements start and end, the elements are validated
+// when they are decoded. Object elements are found at any depth, like
+// the encoding/xml based scanner, but not within other objects.
+type tokenizer struct {
+	r   io.Reader
+	err error
+
+	buf []byte
+	pos int // next byte to scan
+	end int // end of the buffered data
+
+	// start of the object element being read, or -1,
+	// and the depth of the current position within it.
+	elem  int
+	depth int
+
+	// open non object elements, e.g. <osm>
+	stack []string
+}
+
+func newTokenizer(r io.Reader) *tokenizer {
+	return &tokenizer{
+		r:    r,
+		buf:  make([]byte, initialBufSize),
+		elem: -1,
+	}
+}
+
+// Next returns the raw bytes of the next object element. The data is
+// only valid until the next call. Returns io.EOF at the end of the input.
+func (t *tokenizer) Next() ([]byte, error) {
+	for {
+		i := bytes.IndexByte(t.buf[t.pos:t.end], '<')
+		if i < 0 {
+			t.pos = t.end
+			if err := t.fill(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		start := t.pos + i
+		kind, name, end, err := readToken(t.buf[:t.end], start)
+		if err != nil {
+			return nil, err
+		}
+
+		if end < 0 {
+			// incomplete token, read more data and try again
+			t.pos = start
+			if err := t.fill(); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+		t.pos = end
+
+		if t.elem >= 0 {
+			switch kind {
+			case tokenStart:
+				t.depth++
+			case tokenEnd:
+				t.depth--
+				if t.depth == 0 {
+					data := t.buf[t.elem:end]
+					t.elem = -1
+					return data, nil
+				}
+			}
+
+			continue
+		}
+
+		switch kind {
+		case tokenStart:
+			if isObject(name) {
+				t.elem = start
+				t.depth = 1
+				continue
+			}
+
+			t.stack = append(t.stack, string(name))
+		case tokenSelfClosing:
+			if isObject(name) {
+				return t.buf[start:end], nil
+			}
+		case tokenEnd:
+			if len(t.stack) == 0 {
+				return nil, fmt.Errorf("osmxml: unexpected end element </%s>", name)
+			}
+
+			open := t.stack[len(t.stack)-1]
+			if open != string(name) {
+				return nil, fmt.Errorf("osmxml: element <%s> closed by </%s>", open, name)
+			}
+			t.stack = t.stack[:len(t.stack)-1]
+		}
+	}
+}
+
+// fill discards the scanned data that is no longer needed and reads
+// more data into the buffer. The buffer is grown if it is full.
+func (t *tokenizer) fill() error {
+	if t.err != nil {
+		return t.eof()
+	}
+
+	keep := t.pos
+	if t.elem >= 0 {
+		keep = t.elem
+	}
+
+	if keep > 0 {
+		copy(t.buf, t.buf[keep:t.end])
+		t.end -= keep
+		t.pos -= keep
+		if t.elem >= 0 {
+			t.elem -= keep
+		}
+	}
+
+	if len(t.buf)-t.end < minReadSize {
+		buf := make([]byte, 2*len(t.buf))
+		copy(buf, t.buf[:t.end])
+		t.buf = buf
+	}
+
+	for i := 0; i < maxEmptyReads; i++ {
+		n, err := t.r.Read(t.buf[t.end:])
+		t.end += n
+		if err != nil {
+			t.err = err
+		}
+
+		if n > 0 {
+			return nil
+		}
+
+		if err != nil {
+			return t.eof()
+		}
+	}
+
+	t.err = io.ErrNoProgress
+	return t.err
+}
+
+// eof returns the read error, io.EOF is only returned if the input
+// ended outside of all elements and tokens.
+func (t *tokenizer) eof() error {
+	if t.err != io.EOF {
+		return t.err
+	}
+
+	if t.elem >= 0 || len(t.stack) > 0 || t.pos < t.end {
+		return errUnexpectedEOF
+	}
+
+	return io.EOF
+}
+
+// isObject returns true if the element name is one of the osm objects.
+// Namespace prefixes are ignored and the name is matched case insensitively
+// like the encoding/xml based decoding.
+func isObject(name []byte) bool {
+	if i := bytes.LastIndexByte(name, ':'); i >= 0 {
+		name = name[i+1:]
+	}
+
+	switch len(name) {
+	case 3:
+		return bytes.EqualFold(name, []byte("way"))
+	case 4:
+		return bytes.EqualFold(name, []byte("node")) ||
+			bytes.EqualFold(name, []byte("note")) ||
+			bytes.EqualFold(name, []byte("user"))
+	case 6:
+		return bytes.EqualFold(name, []byte("bounds"))
+	case 8:
+		return bytes.EqualFold(name, []byte("relation"))
+	case 9:
+		return bytes.EqualFold(name, []byte("changeset"))
+	}
+
+	return false
+}
+
+// readToken reads the markup starting with the '<' at data[i]. It returns
+// the kind, the element name for start and end tags and the index after
+// the closing '>'. The end is -1 if the data ends before the token.
+func readToken(data []byte, i int) (int, []byte, int, error) {
+	if i+1 >= len(data) {
+		return 0, nil, -1, nil
+	}
+
+	switch data[i+1] {
+	case '?':
+		end := indexAfter(data, i+2, "?>")
+		return tokenOther, nil, end, nil
+	case '!':
+		return readDirective(data, i)
+	case '/':
+		n := nameEnd(data, i+2)
+		if n == i+2 {
+			if n == len(data) {
+				return 0, nil, -1, nil
+			}
+			return 0, nil, 0, syntaxError(data, i)
+		}
+
+		// only whitespace is allowed after the name
+		for j := n; j < len(data); j++ {
+			switch data[j] {
+			case ' ', '\t', '\r', '\n':
+			case '>':
+				return tokenEnd, data[i+2 : n], j + 1, nil
+			default:
+				return 0, nil, 0, syntaxError(data, i)
+			}
+		}
+
+		return 0, nil, -1, nil
+	}
+
+	n := nameEnd(data, i+1)
+	if n == i+1 {
+		if n == len(data) {
+			return 0, nil, -1, nil
+		}
+		return 0, nil, 0, syntaxError(data, i)
+	}
+
+	// find the closing '>' outside of the quoted attribute values
+	var quote byte
+	for j := n; j < len(data); j++ {
+		c := data[j]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			if data[j-1] == '/' {
+				return tokenSelfClosing, data[i+1 : n], j + 1, nil
+			}
+			return tokenStart, data[i+1 : n], j + 1, nil
+		case c == '<':
+			return 0, nil, 0, syntaxError(data, i)
+		}
+	}
+
+	return 0, nil, -1, nil
+}
+
+// readDirective reads comments, cdata sections and doctypes.
+func readDirective(data []byte, i int) (int, []byte, int, error) {
+	rest := data[i:]
+	switch {
+	case bytes.HasPrefix(rest, []byte("<!--")):
+		return tokenOther, nil, indexAfter(data, i+4, "-->"), nil
+	case bytes.HasPrefix(rest, []byte("<![CDATA[")):
+		return tokenOther, nil, indexAfter(data, i+9, "]]>"), nil
+	case len(rest) < len("<![CDATA["):
+		// could be the start of a comment or cdata section
+		return 0, nil, -1, nil
+	}
+
+	// doctypes can have an internal subset with nested markup
+	var (
+		quote byte
+		depth int
+	)
+	for j := i + 2; j < len(data); j++ {
+		c := data[j]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '<':
+			depth++
+		case c == '>':
+			if depth == 0 {
+				return tokenOther, nil, j + 1, nil
+			}
+			depth--
+		}
+	}
+
+	return 0, nil, -1, nil
+}
+
+// indexAfter returns the index after the first s in data[i:], or -1.
+func indexAfter(data []byte, i int, s string) int {
+	if i > len(data) {
+		return -1
+	}
+
+	j := bytes.Index(data[i:], []byte(s))
+	if j < 0 {
+		return -1
+	}
+
+	return i + j + len(s)
+}
+
+// nameEnd returns the index after the element name starting at data[i].
+func nameEnd(data []byte, i int) int {
+	for j := i; j < len(data); j++ {
+		switch data[j] {
+		case ' ', '\t', '\r', '\n', '/', '>', '<', '=', '"', '\'':
+			return j
+		}
+	}
+
+	return len(data)
+}
+
+func syntaxError(data []byte, i int) error {
+	end := i + maxErrorData
+	if end > len(data) {
+		end = len(data)
+	}
+
+	return fmt.Errorf("osmxml: syntax error near %q", data[i:end])
+}
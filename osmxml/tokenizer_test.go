@@ -0,0 +1,109 @@
+package osmxml
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestTokenizer(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE osm [ <!ENTITY a "<node>"> ]>
+<!-- <node id="0"/> -->
+<osm version="0.6">
+	<![CDATA[ <way id="0"/> ]]>
+	<node id="1" lat="1" lon="2"/>
+	<group name="a>b">
+		<way id="2"><nd ref="1"/><tag k="a" v="<>"/></way>
+	</group>
+	<relation id="3">
+		<member type="way" ref="2" role=""><nd ref="1"></nd></member>
+	</relation>
+	<user id="4"><description>a <b>bold</b> user</description></user>
+</osm>
+`
+
+	expected := []string{
+		`<node id="1" lat="1" lon="2"/>`,
+		`<way id="2"><nd ref="1"/><tag k="a" v="<>"/></way>`,
+		"<relation id=\"3\">\n\t\t<member type=\"way\" ref=\"2\" role=\"\"><nd ref=\"1\"></nd></member>\n\t</relation>",
+		`<user id="4"><description>a <b>bold</b> user</description></user>`,
+	}
+
+	readers := map[string]io.Reader{
+		"all":      strings.NewReader(data),
+		"one byte": iotest.OneByteReader(strings.NewReader(data)),
+	}
+
+	for name, r := range readers {
+		t.Run(name, func(t *testing.T) {
+			tokenizer := newTokenizer(r)
+			for _, e := range expected {
+				element, err := tokenizer.Next()
+				if err != nil {
+					t.Fatalf("next error: %v", err)
+				}
+
+				if string(element) != e {
+					t.Errorf("incorrect element:\n%s\n%s", element, e)
+				}
+			}
+
+			if _, err := tokenizer.Next(); err != io.EOF {
+				t.Errorf("should be at the end: %v", err)
+			}
+		})
+	}
+}
+
+func TestTokenizer_errors(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "unclosed root",
+			data: `<osm><node id="1"/>`,
+		},
+		{
+			name: "unclosed element",
+			data: `<osm><way id="1"><nd ref="1"/>`,
+		},
+		{
+			name: "incomplete token",
+			data: `<osm></osm><!-- comment`,
+		},
+		{
+			name: "mismatched end",
+			data: `<osm><node id="1"/></osmChange>`,
+		},
+		{
+			name: "unexpected end",
+			data: `</osm>`,
+		},
+		{
+			name: "invalid name",
+			data: `<osm>< node/></osm>`,
+		},
+		{
+			name: "attributes after end name",
+			data: `<osm></osm id="1">`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenizer := newTokenizer(strings.NewReader(tc.data))
+
+			var err error
+			for err == nil {
+				_, err = tokenizer.Next()
+			}
+
+			if err == io.EOF {
+				t.Errorf("should return error")
+			}
+		})
+	}
+}
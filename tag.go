@@ -86,6 +86,9 @@ func (ts *Tags) UnmarshalJSON(data []byte) error {
 		tags = append(tags, Tag{Key: k, Value: v})
 	}
 
+	// the object has no order, so sort to be consistent
+	tags.SortByKeyValue()
+
 	*ts = tags
 	return nil
 }
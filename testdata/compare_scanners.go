@@ -1,16 +1,13 @@
 package main
 
 import (
-	"compress/bzip2"
 	"context"
 	"log"
 	"math"
-	"os"
 	"reflect"
 
 	"github.com/ich5003/small-osm"
-	"github.com/ich5003/small-osm/osmpbf"
-	"github.com/ich5003/small-osm/osmxml"
+	"github.com/ich5003/small-osm/osmfile"
 )
 
 func main() {
@@ -18,8 +15,8 @@ func main() {
 	pbffile := "delaware-latest.osm.pbf"
 	bz2file := "delaware-latest.osm.bz2"
 
-	o1 := readPbf(pbffile)
-	o2 := readBz2(bz2file)
+	o1 := readFile(pbffile)
+	o2 := readFile(bz2file)
 
 	log.Printf("Are they the same? %v", reflect.DeepEqual(o1, o2))
 
@@ -57,54 +54,36 @@ func main() {
 	}
 }
 
-func readPbf(filename string) *osm.OSM {
-	log.Printf("Reading pbf file %v", filename)
-	f, err := os.Open(filename)
+func readFile(filename string) *osm.OSM {
+	log.Printf("Reading file %v", filename)
+	scanner, err := osmfile.Open(context.Background(), filename)
 	if err != nil {
 		panic(err)
 	}
-	defer f.Close()
+	defer scanner.Close()
 
-	scanner := osmpbf.New(context.Background(), f, 1)
-	return scanner2osm(scanner)
-}
-
-func readBz2(filename string) *osm.OSM {
-	log.Printf("Reading bz2 file %v", filename)
-	f, err := os.Open(filename)
-	if err != nil {
-		panic(err)
-	}
-	defer f.Close()
-
-	r := bzip2.NewReader(f)
-	scanner := osmxml.New(context.Background(), r)
+	log.Printf("Detected %v with %q compression", scanner.Format, scanner.Compression)
 	return scanner2osm(scanner)
 }
 
 func scanner2osm(scanner osm.Scanner) *osm.OSM {
 	o := &osm.OSM{}
 	for scanner.Scan() {
-		e := scanner.Element()
-
-		if e.Node != nil {
-			e.Node.Lat = math.Floor(e.Node.Lat*1e7+0.5) / 1e7
-			e.Node.Lon = math.Floor(e.Node.Lon*1e7+0.5) / 1e7
-			e.Node.Visible = true
-			e.Node.Tags.SortByKeyValue()
-			o.Nodes = append(o.Nodes, e.Node)
-		}
-
-		if e.Way != nil {
-			e.Way.Visible = true
-			e.Way.Tags.SortByKeyValue()
-			o.Ways = append(o.Ways, e.Way)
-		}
-
-		if e.Relation != nil {
-			e.Relation.Visible = true
-			e.Relation.Tags.SortByKeyValue()
-			o.Relations = append(o.Relations, e.Relation)
+		switch e := scanner.Object().(type) {
+		case *osm.Node:
+			e.Lat = math.Floor(e.Lat*1e7+0.5) / 1e7
+			e.Lon = math.Floor(e.Lon*1e7+0.5) / 1e7
+			e.Visible = true
+			e.Tags.SortByKeyValue()
+			o.Nodes = append(o.Nodes, e)
+		case *osm.Way:
+			e.Visible = true
+			e.Tags.SortByKeyValue()
+			o.Ways = append(o.Ways, e)
+		case *osm.Relation:
+			e.Visible = true
+			e.Tags.SortByKeyValue()
+			o.Relations = append(o.Relations, e)
 		}
 	}
 